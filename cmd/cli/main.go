@@ -4,30 +4,55 @@ import (
 	"bufio"
 	"context"
 	"crypto/tls"
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"log"
+	"net/http"
 	"os"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/term"
 
+	"github.com/WadeCappa/authmaster/pkg/go/authmaster/v1"
 	"github.com/WadeCappa/taskmaster/internal/calls"
+	"github.com/WadeCappa/taskmaster/internal/certs"
+	"github.com/WadeCappa/taskmaster/internal/formats"
+	"github.com/WadeCappa/taskmaster/internal/profiles"
+	"github.com/WadeCappa/taskmaster/internal/todosync"
+	"github.com/WadeCappa/taskmaster/internal/tracing"
 	"github.com/WadeCappa/taskmaster/internal/tui"
+	"github.com/WadeCappa/taskmaster/internal/types"
+	"github.com/WadeCappa/taskmaster/internal/webhooks"
 	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const (
 	NO_COMMAND        = "no-command"
 	DEFAULT_ADDRESS   = "localhost:6100"
 	SECURE_CONNECTION = false
+	DEFAULT_FORMAT    = "jsonl"
+	DEFAULT_RECEIVER  = "localhost:6102"
+	SERVICE_NAME      = "taskmaster-cli"
+	TRACER_NAME       = "github.com/WadeCappa/taskmaster/cmd/cli"
+	// how old a signed webhook request can be before the receiver rejects it
+	WEBHOOK_TOLERANCE = 5 * time.Minute
 )
 
 var commands = map[string]func() error{
@@ -35,8 +60,56 @@ var commands = map[string]func() error{
 	"get":      get,
 	"describe": describe,
 	"mark":     mark,
+	"assign":   assign,
 	"get-tags": getTags,
 	"tui":      runTui,
+	"export":   export,
+	"import":   importData,
+	"watch":    watch,
+
+	"sync-todotxt": syncTodoTxt,
+
+	"add-webhook":        addWebhook,
+	"list-webhooks":      listWebhooks,
+	"remove-webhook":     removeWebhook,
+	"webhook-deliveries": webhookDeliveries,
+	"receive-webhooks":   receiveWebhooks,
+
+	"login":  login,
+	"logout": logout,
+
+	"create-token": createToken,
+	"list-tokens":  listTokens,
+	"revoke-token": revokeToken,
+
+	"share":       share,
+	"list-shares": listShares,
+	"unshare":     unshare,
+
+	"create-workspace":   createWorkspace,
+	"list-workspaces":    listWorkspaces,
+	"join-workspace":     joinWorkspace,
+	"leave-workspace":    leaveWorkspace,
+	"list-members":       listMembers,
+	"set-workspace-role": setWorkspaceRole,
+}
+
+var exportFormats = map[string]func(io.Writer, []*taskspb.DataRecord) error{
+	"jsonl":       formats.WriteJsonLines,
+	"csv":         formats.WriteCsv,
+	"taskwarrior": formats.WriteTaskwarrior,
+	"ics":         formats.WriteICalendar,
+	"org":         formats.WriteOrg,
+	"md":          formats.WriteMarkdown,
+}
+
+var importFormats = map[string]func(io.Reader) ([]*taskspb.DataRecord, error){
+	"jsonl":       formats.ReadJsonLines,
+	"csv":         formats.ReadCsv,
+	"taskwarrior": formats.ReadTaskwarrior,
+	"ics":         formats.ReadICalendar,
+	"org":         formats.ReadOrg,
+	"md":          formats.ReadMarkdown,
 }
 
 func main() {
@@ -54,10 +127,245 @@ func main() {
 	}
 }
 
+// Used by getGrpcClient when connecting over TLS, set by connectionFlags and login
+var tlsFiles struct {
+	caCert     string
+	clientCert string
+	clientKey  string
+}
+
+// Flags that are not set fall back to the active profile, see login
 func connectionFlags(fs *flag.FlagSet, hostname *string, secure *bool, bearer *string) {
-	fs.StringVar(hostname, "addr", DEFAULT_ADDRESS, "the address to connect to")
-	fs.BoolVar(secure, "secure", SECURE_CONNECTION, "set to true if we need to connect over TLS")
-	fs.StringVar(bearer, "bearer", "", "set this to your user bearer token")
+	profile := profiles.Profile{Addr: DEFAULT_ADDRESS, Secure: SECURE_CONNECTION}
+	if saved, exists := loadProfile(""); exists {
+		profile = saved
+	}
+	fs.Var(&profileFlag{fs: fs}, "profile", "the saved profile to connect with, defaults to the last one logged in to")
+	fs.StringVar(hostname, "addr", profile.Addr, "the address to connect to")
+	fs.BoolVar(secure, "secure", profile.Secure, "set to true if we need to connect over TLS")
+	fs.StringVar(bearer, "bearer", profile.Token, "set this to your user bearer token")
+	tlsFlags(fs, profile)
+	traceFlags(fs)
+}
+
+// Used by withTasksClient, set by connectionFlags
+var traceOptions = tracing.Options{ServiceName: SERVICE_NAME, Writer: os.Stderr}
+
+// Spans are printed to stderr, so that they do not mix with the output of a command
+func traceFlags(fs *flag.FlagSet) {
+	fs.StringVar(&traceOptions.Exporter, "trace", tracing.EXPORTER_NONE, "where to send a trace of this command, either 'none', 'otlp' to send it to --trace-endpoint, or 'stdout' to print it")
+	fs.StringVar(&traceOptions.Endpoint, "trace-endpoint", "", "the host:port of an OTLP collector that accepts grpc, for --trace=otlp. Defaults to OTEL_EXPORTER_OTLP_ENDPOINT or localhost:4317")
+	fs.BoolVar(&traceOptions.Insecure, "trace-insecure", false, "send traces to --trace-endpoint without TLS")
+}
+
+// The parent of every call a command makes. When tracing, withTasksClient
+// replaces this with a root span for the command.
+var rootContext = context.Background()
+
+func tlsFlags(fs *flag.FlagSet, profile profiles.Profile) {
+	fs.StringVar(&tlsFiles.caCert, "ca-cert", profile.CaCert, "PEM certificates to verify the server with instead of the system's, when --secure is set")
+	fs.StringVar(&tlsFiles.clientCert, "client-cert", profile.ClientCert, "a PEM client certificate to connect with, when --secure is set")
+	fs.StringVar(&tlsFiles.clientKey, "client-key", profile.ClientKey, "the PEM key for --client-cert")
+}
+
+// Connection flags default to the active profile. Naming another profile swaps
+// in its values as --profile is parsed, for every connection flag that was not
+// given before it. Flags given after it are parsed later and win anyway.
+type profileFlag struct {
+	fs   *flag.FlagSet
+	name string
+}
+
+func (p *profileFlag) String() string {
+	return p.name
+}
+
+func (p *profileFlag) Set(name string) error {
+	profile, _ := loadProfile(name)
+	given := map[string]bool{}
+	p.fs.Visit(func(f *flag.Flag) {
+		given[f.Name] = true
+	})
+	for flagName, value := range map[string]string{
+		"addr":        profile.Addr,
+		"secure":      strconv.FormatBool(profile.Secure),
+		"bearer":      profile.Token,
+		"ca-cert":     profile.CaCert,
+		"client-cert": profile.ClientCert,
+		"client-key":  profile.ClientKey,
+	} {
+		if given[flagName] {
+			continue
+		}
+		if err := p.fs.Set(flagName, value); err != nil {
+			return fmt.Errorf("setting --%s from profile %s: %w", flagName, name, err)
+		}
+	}
+	p.name = name
+	return nil
+}
+
+func loadProfile(requested string) (profiles.Profile, bool) {
+	path, err := profiles.DefaultPath()
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	config, err := profiles.Load(path)
+	if err != nil {
+		log.Fatalf("loading profiles: %v", err)
+	}
+	profile, exists := config.Profiles[config.Active(requested)]
+	if !exists && requested != "" {
+		log.Fatalf("no profile named %s in %s", requested, path)
+	}
+	if !exists {
+		return profiles.Profile{}, false
+	}
+	if profile.Addr == "" {
+		profile.Addr = DEFAULT_ADDRESS
+	}
+	return profile, true
+}
+
+// Logs in to authmaster and saves the token, along with where to find
+// taskmaster, as a profile that later commands use by default.
+func login() error {
+	loginCmd := flag.NewFlagSet("", flag.ExitOnError)
+	profile := loginCmd.String("profile", profiles.DEFAULT_PROFILE, "the name to save this login under")
+	hostname := loginCmd.String("addr", DEFAULT_ADDRESS, "the address of taskmaster")
+	secure := loginCmd.Bool("secure", SECURE_CONNECTION, "set to true if we need to connect to taskmaster over TLS")
+	authHostname := loginCmd.String("auth-addr", "", "the address of authmaster, defaults to --addr")
+	authSecure := loginCmd.Bool("auth-secure", false, "set to true if we need to connect to authmaster over TLS, defaults to --secure")
+	username := loginCmd.String("username", "", "the user to log in as")
+	password := loginCmd.String("password", "", "the user's password, prompted for when empty")
+	create := loginCmd.Bool("create-user", false, "create the user before logging in")
+	tlsFlags(loginCmd, profiles.Profile{})
+	loginCmd.Parse(os.Args[2:])
+
+	if *username == "" {
+		return errors.New("a username is required")
+	}
+	if *authHostname == "" {
+		*authHostname = *hostname
+	}
+	authSecureSet := false
+	loginCmd.Visit(func(f *flag.Flag) { authSecureSet = authSecureSet || f.Name == "auth-secure" })
+	if !authSecureSet {
+		*authSecure = *secure
+	}
+	if *password == "" {
+		read, err := readPassword()
+		if err != nil {
+			return fmt.Errorf("reading password: %w", err)
+		}
+		*password = read
+	}
+
+	var conn *grpc.ClientConn
+	var err error
+	if *authHostname == *hostname {
+		conn, err = getGrpcClient(*authHostname, *authSecure)
+	} else {
+		// a separate authmaster is verified with the system's certificates
+		conn, err = grpc.NewClient(*authHostname, grpc.WithTransportCredentials(transportCredentials(*authSecure, nil)))
+	}
+	if err != nil {
+		return fmt.Errorf("connecting to authmaster: %w", err)
+	}
+	defer conn.Close()
+	client := authmaster.NewAuthmasterClient(conn)
+	if *create {
+		if _, err := client.CreateUser(context.Background(), &authmaster.CreateUserRequest{
+			Username: *username,
+			Password: *password,
+		}); err != nil {
+			return fmt.Errorf("creating user: %w", err)
+		}
+	}
+	resp, err := client.Login(context.Background(), &authmaster.LoginRequest{
+		Username: *username,
+		Password: *password,
+	})
+	if err != nil {
+		return fmt.Errorf("logging in: %w", err)
+	}
+
+	if err := updateProfiles(func(config *profiles.Config) error {
+		config.Profiles[*profile] = profiles.Profile{
+			Addr:       *hostname,
+			Secure:     *secure,
+			Token:      resp.GetToken(),
+			CaCert:     absolute(tlsFiles.caCert),
+			ClientCert: absolute(tlsFiles.clientCert),
+			ClientKey:  absolute(tlsFiles.clientKey),
+		}
+		config.Current = *profile
+		return nil
+	}); err != nil {
+		return fmt.Errorf("saving login: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "logged in as %s, saved to profile %s\n", *username, *profile)
+	return nil
+}
+
+// Forgets the token of a profile, the rest of the profile is kept for the
+// next login
+func logout() error {
+	logoutCmd := flag.NewFlagSet("", flag.ExitOnError)
+	profile := logoutCmd.String("profile", "", "the profile to log out of, defaults to the active profile")
+	logoutCmd.Parse(os.Args[2:])
+
+	if err := updateProfiles(func(config *profiles.Config) error {
+		name := config.Active(*profile)
+		saved, exists := config.Profiles[name]
+		if !exists {
+			return fmt.Errorf("no profile named %s", name)
+		}
+		saved.Token = ""
+		config.Profiles[name] = saved
+		fmt.Fprintf(os.Stderr, "logged out of profile %s\n", name)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("logging out: %w", err)
+	}
+	return nil
+}
+
+func updateProfiles(update func(*profiles.Config) error) error {
+	path, err := profiles.DefaultPath()
+	if err != nil {
+		return err
+	}
+	config, err := profiles.Load(path)
+	if err != nil {
+		return fmt.Errorf("loading profiles: %w", err)
+	}
+	if err := update(config); err != nil {
+		return err
+	}
+	if err := config.Save(path); err != nil {
+		return fmt.Errorf("saving profiles: %w", err)
+	}
+	return nil
+}
+
+// Reads without echoing when run in a terminal, otherwise reads a line so
+// the password can be piped in
+func readPassword() (string, error) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", err
+		}
+		return strings.TrimRight(line, "\r\n"), nil
+	}
+	fmt.Fprint(os.Stderr, "password: ")
+	password, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+	return string(password), nil
 }
 
 func get() error {
@@ -68,6 +376,8 @@ func get() error {
 	connectionFlags(getCmd, &hostname, &secure, &bearer)
 	statusId := getCmd.Uint64("status-id", 0, "0) tracking, 1) completed, 2) backlog")
 	tags := getCmd.String("tags", "", "tags separated by ','. If empty all tasks will be returned")
+	workspaceId := getCmd.Uint64("workspace-id", 0, "the workspace to get tasks from. If 0 your personal tasks and tasks shared with you are returned")
+	assignmentId := getCmd.Uint64("assignment-id", 0, "0) all tasks, 1) assigned to me, 2) created by me, 3) unassigned")
 	getCmd.Parse(os.Args[2:])
 
 	if err := withTasksClient(hostname, secure, func(client taskspb.TasksClient) error {
@@ -76,7 +386,7 @@ func get() error {
 			tagsToSend = strings.Split(*tags, ",")
 		}
 		ctx := getContext(bearer)
-		tasks, err := calls.Get(ctx, client, tagsToSend, taskspb.Status(*statusId))
+		tasks, err := calls.Get(ctx, client, workspace(*workspaceId), taskspb.AssignmentFilter(*assignmentId), tagsToSend, taskspb.Status(*statusId))
 		if err != nil {
 			return fmt.Errorf("calling client: %w", err)
 		}
@@ -100,6 +410,7 @@ func put() error {
 	var bearer string
 	var secure bool
 	connectionFlags(putCmd, &hostname, &secure, &bearer)
+	workspaceId := putCmd.Uint64("workspace-id", 0, "the workspace to create the task in. If 0 it is created in your personal workspace")
 	putCmd.Parse(os.Args[2:])
 
 	newTask := taskspb.Task{}
@@ -160,9 +471,11 @@ func put() error {
 	}
 
 	if err := withTasksClient(hostname, secure, func(client taskspb.TasksClient) error {
-		resp, err := client.PutTask(getContext(bearer), &taskspb.PutTaskRequest{
-			Task: &newTask,
-		})
+		request := &taskspb.PutTaskRequest{Task: &newTask}
+		if id, exists := workspace(*workspaceId).Unwrap(); exists {
+			request.WorkspaceId = &id
+		}
+		resp, err := client.PutTask(getContext(bearer), request)
 		if err != nil {
 			return fmt.Errorf("putting task: %w", err)
 		}
@@ -236,16 +549,47 @@ func mark() error {
 	return nil
 }
 
+func assign() error {
+	var hostname string
+	var bearer string
+	var secure bool
+	assignCmd := flag.NewFlagSet("", flag.ExitOnError)
+	connectionFlags(assignCmd, &hostname, &secure, &bearer)
+
+	taskId := assignCmd.Uint64("task-id", 0, "the ID of the task that you want to assign")
+	userId := assignCmd.Uint64("user-id", 0, "the ID of the user to assign the task to. If 0 the task is unassigned")
+	assignCmd.Parse(os.Args[2:])
+
+	request := &taskspb.AssignTaskRequest{TaskId: *taskId}
+	if *userId != 0 {
+		request.AssigneeUserId = userId
+	}
+	if err := withTasksClient(hostname, secure, func(client taskspb.TasksClient) error {
+		if _, err := client.AssignTask(getContext(bearer), request); err != nil {
+			return fmt.Errorf("calling task client: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to assign task: %w", err)
+	}
+	return nil
+}
+
 func getTags() error {
 	var hostname string
 	var bearer string
 	var secure bool
 	getTags := flag.NewFlagSet("", flag.ExitOnError)
 	connectionFlags(getTags, &hostname, &secure, &bearer)
+	workspaceId := getTags.Uint64("workspace-id", 0, "the workspace to get tags from. If 0 your personal tags are returned")
 	getTags.Parse(os.Args[2:])
 
+	request := &taskspb.GetTagsRequest{}
+	if id, exists := workspace(*workspaceId).Unwrap(); exists {
+		request.WorkspaceId = &id
+	}
 	if err := withTasksClient(hostname, secure, func(client taskspb.TasksClient) error {
-		stream, err := client.GetTags(getContext(bearer), &taskspb.GetTagsRequest{})
+		stream, err := client.GetTags(getContext(bearer), request)
 		if err != nil {
 			return fmt.Errorf("calling task client: %w", err)
 		}
@@ -269,21 +613,916 @@ func getTags() error {
 	return nil
 }
 
-func getGrpcClient(hostname string, secure bool) (*grpc.ClientConn, error) {
-	var creds credentials.TransportCredentials
-	if secure {
-		creds = credentials.NewTLS(&tls.Config{})
-	} else {
-		creds = insecure.NewCredentials()
+func watch() error {
+	watchCmd := flag.NewFlagSet("", flag.ExitOnError)
+	var hostname string
+	var bearer string
+	var secure bool
+	connectionFlags(watchCmd, &hostname, &secure, &bearer)
+	statusId := watchCmd.Uint64("status-id", 0, "0) tracking, 1) completed, 2) backlog")
+	tags := watchCmd.String("tags", "", "tags separated by ','. If empty events for all tasks will be returned")
+	workspaceId := watchCmd.Uint64("workspace-id", 0, "the workspace to watch. If 0 your personal tasks and tasks shared with you are watched")
+	watchCmd.Parse(os.Args[2:])
+
+	if err := withTasksClient(hostname, secure, func(client taskspb.TasksClient) error {
+		var tagsToSend []string
+		if *tags != "" {
+			tagsToSend = strings.Split(*tags, ",")
+		}
+		request := &taskspb.WatchTasksRequest{
+			Status: taskspb.Status(*statusId),
+			Tags:   tagsToSend,
+		}
+		if id, exists := workspace(*workspaceId).Unwrap(); exists {
+			request.WorkspaceId = &id
+		}
+		stream, err := client.WatchTasks(getContext(bearer), request)
+		if err != nil {
+			return fmt.Errorf("calling client: %w", err)
+		}
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("could not receive next event: %w", err)
+			}
+			jsonBytes, err := protojson.Marshal(res)
+			if err != nil {
+				return fmt.Errorf("converting to json: %w", err)
+			}
+			fmt.Println(string(jsonBytes))
+		}
+	}); err != nil {
+		return fmt.Errorf("watching tasks: %w", err)
 	}
-	return grpc.NewClient(hostname, grpc.WithTransportCredentials(creds))
+	return nil
 }
 
-func withTasksClient(
-	hostname string,
-	secure bool,
-	consumer func(client taskspb.TasksClient) error,
-) error {
+func addWebhook() error {
+	addCmd := flag.NewFlagSet("", flag.ExitOnError)
+	var hostname string
+	var bearer string
+	var secure bool
+	connectionFlags(addCmd, &hostname, &secure, &bearer)
+	url := addCmd.String("url", "", "the url that events are posted to")
+	var filters []*taskspb.WebhookFilter
+	addCmd.Func(
+		"filter",
+		"only send events matching this filter, e.g. type=STATUS_CHANGED,status=COMPLETED or type=CREATED,tag=oncall. May be repeated, if not set every event is sent",
+		func(value string) error {
+			filter, err := webhooks.ParseFilter(value)
+			if err != nil {
+				return err
+			}
+			filters = append(filters, filter)
+			return nil
+		},
+	)
+	addCmd.Parse(os.Args[2:])
+
+	if err := withTasksClient(hostname, secure, func(client taskspb.TasksClient) error {
+		resp, err := client.CreateWebhook(getContext(bearer), &taskspb.CreateWebhookRequest{
+			Webhook: &taskspb.Webhook{
+				Url:     *url,
+				Filters: filters,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("calling task client: %w", err)
+		}
+		jsonBytes, err := protojson.Marshal(resp)
+		if err != nil {
+			return fmt.Errorf("converting to json: %w", err)
+		}
+		fmt.Println(string(jsonBytes))
+		return nil
+	}); err != nil {
+		return fmt.Errorf("adding webhook: %w", err)
+	}
+	return nil
+}
+
+func listWebhooks() error {
+	listCmd := flag.NewFlagSet("", flag.ExitOnError)
+	var hostname string
+	var bearer string
+	var secure bool
+	connectionFlags(listCmd, &hostname, &secure, &bearer)
+	listCmd.Parse(os.Args[2:])
+
+	if err := withTasksClient(hostname, secure, func(client taskspb.TasksClient) error {
+		stream, err := client.ListWebhooks(getContext(bearer), &taskspb.ListWebhooksRequest{})
+		if err != nil {
+			return fmt.Errorf("calling task client: %w", err)
+		}
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("could not receive next webhook: %w", err)
+			}
+			jsonBytes, err := protojson.Marshal(res)
+			if err != nil {
+				return fmt.Errorf("converting to json: %w", err)
+			}
+			fmt.Println(string(jsonBytes))
+		}
+	}); err != nil {
+		return fmt.Errorf("listing webhooks: %w", err)
+	}
+	return nil
+}
+
+func removeWebhook() error {
+	removeCmd := flag.NewFlagSet("", flag.ExitOnError)
+	var hostname string
+	var bearer string
+	var secure bool
+	connectionFlags(removeCmd, &hostname, &secure, &bearer)
+	webhookId := removeCmd.Uint64("webhook-id", 0, "the ID of the webhook to remove")
+	removeCmd.Parse(os.Args[2:])
+
+	if err := withTasksClient(hostname, secure, func(client taskspb.TasksClient) error {
+		if _, err := client.DeleteWebhook(getContext(bearer), &taskspb.DeleteWebhookRequest{
+			WebhookId: *webhookId,
+		}); err != nil {
+			return fmt.Errorf("calling task client: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("removing webhook: %w", err)
+	}
+	return nil
+}
+
+func createToken() error {
+	createCmd := flag.NewFlagSet("", flag.ExitOnError)
+	var hostname string
+	var bearer string
+	var secure bool
+	connectionFlags(createCmd, &hostname, &secure, &bearer)
+	name := createCmd.String("name", "", "what the token is for")
+	scopes := createCmd.String("scopes", "", "scopes separated by ',', any of read-only, write-tasks and write-addendums")
+	expiresIn := createCmd.Duration("expires-in", 0, "how long until the token expires, e.g. 720h. If 0 the token never expires")
+	createCmd.Parse(os.Args[2:])
+
+	request := &taskspb.CreateAccessTokenRequest{Name: *name}
+	for _, s := range strings.Split(*scopes, ",") {
+		if s == "" {
+			continue
+		}
+		scope, exists := taskspb.Scope_value[strings.ToUpper(strings.ReplaceAll(s, "-", "_"))]
+		if !exists {
+			return fmt.Errorf("unrecognized scope %s", s)
+		}
+		request.Scopes = append(request.Scopes, taskspb.Scope(scope))
+	}
+	if *expiresIn > 0 {
+		request.Expires = timestamppb.New(time.Now().Add(*expiresIn))
+	}
+
+	if err := withTasksClient(hostname, secure, func(client taskspb.TasksClient) error {
+		resp, err := client.CreateAccessToken(getContext(bearer), request)
+		if err != nil {
+			return fmt.Errorf("calling task client: %w", err)
+		}
+		jsonBytes, err := protojson.Marshal(resp)
+		if err != nil {
+			return fmt.Errorf("converting to json: %w", err)
+		}
+		fmt.Println(string(jsonBytes))
+		return nil
+	}); err != nil {
+		return fmt.Errorf("creating access token: %w", err)
+	}
+	return nil
+}
+
+func listTokens() error {
+	listCmd := flag.NewFlagSet("", flag.ExitOnError)
+	var hostname string
+	var bearer string
+	var secure bool
+	connectionFlags(listCmd, &hostname, &secure, &bearer)
+	listCmd.Parse(os.Args[2:])
+
+	if err := withTasksClient(hostname, secure, func(client taskspb.TasksClient) error {
+		stream, err := client.ListAccessTokens(getContext(bearer), &taskspb.ListAccessTokensRequest{})
+		if err != nil {
+			return fmt.Errorf("calling task client: %w", err)
+		}
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("could not receive next access token: %w", err)
+			}
+			jsonBytes, err := protojson.Marshal(res)
+			if err != nil {
+				return fmt.Errorf("converting to json: %w", err)
+			}
+			fmt.Println(string(jsonBytes))
+		}
+	}); err != nil {
+		return fmt.Errorf("listing access tokens: %w", err)
+	}
+	return nil
+}
+
+func revokeToken() error {
+	revokeCmd := flag.NewFlagSet("", flag.ExitOnError)
+	var hostname string
+	var bearer string
+	var secure bool
+	connectionFlags(revokeCmd, &hostname, &secure, &bearer)
+	tokenId := revokeCmd.Uint64("token-id", 0, "the ID of the access token to revoke")
+	revokeCmd.Parse(os.Args[2:])
+
+	if err := withTasksClient(hostname, secure, func(client taskspb.TasksClient) error {
+		if _, err := client.RevokeAccessToken(getContext(bearer), &taskspb.RevokeAccessTokenRequest{
+			TokenId: *tokenId,
+		}); err != nil {
+			return fmt.Errorf("calling task client: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("revoking access token: %w", err)
+	}
+	return nil
+}
+
+func share() error {
+	shareCmd := flag.NewFlagSet("", flag.ExitOnError)
+	var hostname string
+	var bearer string
+	var secure bool
+	connectionFlags(shareCmd, &hostname, &secure, &bearer)
+	taskId := shareCmd.Uint64("task-id", 0, "the ID of the task to share")
+	tag := shareCmd.String("tag", "", "share every task you have with this tag, instead of a single task")
+	userId := shareCmd.Uint64("user-id", 0, "the ID of the user to share with")
+	role := shareCmd.String("role", "viewer", "either viewer or editor")
+	shareCmd.Parse(os.Args[2:])
+
+	target, err := shareTarget(*taskId, *tag)
+	if err != nil {
+		return err
+	}
+	wireRole, exists := taskspb.Role_value[strings.ToUpper(*role)]
+	if !exists {
+		return fmt.Errorf("unrecognized role %s", *role)
+	}
+
+	if err := withTasksClient(hostname, secure, func(client taskspb.TasksClient) error {
+		if _, err := client.Share(getContext(bearer), &taskspb.ShareRequest{
+			Target: target,
+			UserId: *userId,
+			Role:   taskspb.Role(wireRole),
+		}); err != nil {
+			return fmt.Errorf("calling task client: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("sharing: %w", err)
+	}
+	return nil
+}
+
+func listShares() error {
+	listCmd := flag.NewFlagSet("", flag.ExitOnError)
+	var hostname string
+	var bearer string
+	var secure bool
+	connectionFlags(listCmd, &hostname, &secure, &bearer)
+	listCmd.Parse(os.Args[2:])
+
+	if err := withTasksClient(hostname, secure, func(client taskspb.TasksClient) error {
+		stream, err := client.ListShares(getContext(bearer), &taskspb.ListSharesRequest{})
+		if err != nil {
+			return fmt.Errorf("calling task client: %w", err)
+		}
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("could not receive next share: %w", err)
+			}
+			jsonBytes, err := protojson.Marshal(res)
+			if err != nil {
+				return fmt.Errorf("converting to json: %w", err)
+			}
+			fmt.Println(string(jsonBytes))
+		}
+	}); err != nil {
+		return fmt.Errorf("listing shares: %w", err)
+	}
+	return nil
+}
+
+func unshare() error {
+	unshareCmd := flag.NewFlagSet("", flag.ExitOnError)
+	var hostname string
+	var bearer string
+	var secure bool
+	connectionFlags(unshareCmd, &hostname, &secure, &bearer)
+	taskId := unshareCmd.Uint64("task-id", 0, "the ID of the shared task")
+	tag := unshareCmd.String("tag", "", "the shared tag, instead of a single task")
+	userId := unshareCmd.Uint64("user-id", 0, "the ID of the user to stop sharing with")
+	unshareCmd.Parse(os.Args[2:])
+
+	target, err := shareTarget(*taskId, *tag)
+	if err != nil {
+		return err
+	}
+	if err := withTasksClient(hostname, secure, func(client taskspb.TasksClient) error {
+		if _, err := client.Unshare(getContext(bearer), &taskspb.UnshareRequest{
+			Target: target,
+			UserId: *userId,
+		}); err != nil {
+			return fmt.Errorf("calling task client: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("unsharing: %w", err)
+	}
+	return nil
+}
+
+func shareTarget(taskId uint64, tag string) (*taskspb.ShareTarget, error) {
+	switch {
+	case taskId != 0 && tag != "":
+		return nil, errors.New("pass either --task-id or --tag, not both")
+	case tag != "":
+		return &taskspb.ShareTarget{Target: &taskspb.ShareTarget_Tag{Tag: tag}}, nil
+	case taskId != 0:
+		return &taskspb.ShareTarget{Target: &taskspb.ShareTarget_TaskId{TaskId: taskId}}, nil
+	}
+	return nil, errors.New("pass either --task-id or --tag")
+}
+
+func createWorkspace() error {
+	createCmd := flag.NewFlagSet("", flag.ExitOnError)
+	var hostname string
+	var bearer string
+	var secure bool
+	connectionFlags(createCmd, &hostname, &secure, &bearer)
+	name := createCmd.String("name", "", "the name of the workspace")
+	createCmd.Parse(os.Args[2:])
+
+	if err := withTasksClient(hostname, secure, func(client taskspb.TasksClient) error {
+		resp, err := client.CreateWorkspace(getContext(bearer), &taskspb.CreateWorkspaceRequest{Name: *name})
+		if err != nil {
+			return fmt.Errorf("calling task client: %w", err)
+		}
+		jsonBytes, err := protojson.Marshal(resp)
+		if err != nil {
+			return fmt.Errorf("converting to json: %w", err)
+		}
+		fmt.Println(string(jsonBytes))
+		return nil
+	}); err != nil {
+		return fmt.Errorf("creating workspace: %w", err)
+	}
+	return nil
+}
+
+func listWorkspaces() error {
+	listCmd := flag.NewFlagSet("", flag.ExitOnError)
+	var hostname string
+	var bearer string
+	var secure bool
+	connectionFlags(listCmd, &hostname, &secure, &bearer)
+	listCmd.Parse(os.Args[2:])
+
+	if err := withTasksClient(hostname, secure, func(client taskspb.TasksClient) error {
+		stream, err := client.ListWorkspaces(getContext(bearer), &taskspb.ListWorkspacesRequest{})
+		if err != nil {
+			return fmt.Errorf("calling task client: %w", err)
+		}
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("could not receive next workspace: %w", err)
+			}
+			jsonBytes, err := protojson.Marshal(res)
+			if err != nil {
+				return fmt.Errorf("converting to json: %w", err)
+			}
+			fmt.Println(string(jsonBytes))
+		}
+	}); err != nil {
+		return fmt.Errorf("listing workspaces: %w", err)
+	}
+	return nil
+}
+
+func joinWorkspace() error {
+	joinCmd := flag.NewFlagSet("", flag.ExitOnError)
+	var hostname string
+	var bearer string
+	var secure bool
+	connectionFlags(joinCmd, &hostname, &secure, &bearer)
+	inviteCode := joinCmd.String("invite-code", "", "the invite code an owner of the workspace gave you")
+	joinCmd.Parse(os.Args[2:])
+
+	if err := withTasksClient(hostname, secure, func(client taskspb.TasksClient) error {
+		resp, err := client.JoinWorkspace(getContext(bearer), &taskspb.JoinWorkspaceRequest{InviteCode: *inviteCode})
+		if err != nil {
+			return fmt.Errorf("calling task client: %w", err)
+		}
+		jsonBytes, err := protojson.Marshal(resp)
+		if err != nil {
+			return fmt.Errorf("converting to json: %w", err)
+		}
+		fmt.Println(string(jsonBytes))
+		return nil
+	}); err != nil {
+		return fmt.Errorf("joining workspace: %w", err)
+	}
+	return nil
+}
+
+func leaveWorkspace() error {
+	leaveCmd := flag.NewFlagSet("", flag.ExitOnError)
+	var hostname string
+	var bearer string
+	var secure bool
+	connectionFlags(leaveCmd, &hostname, &secure, &bearer)
+	workspaceId := leaveCmd.Uint64("workspace-id", 0, "the ID of the workspace to leave")
+	leaveCmd.Parse(os.Args[2:])
+
+	if err := withTasksClient(hostname, secure, func(client taskspb.TasksClient) error {
+		if _, err := client.LeaveWorkspace(getContext(bearer), &taskspb.LeaveWorkspaceRequest{
+			WorkspaceId: *workspaceId,
+		}); err != nil {
+			return fmt.Errorf("calling task client: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("leaving workspace: %w", err)
+	}
+	return nil
+}
+
+func listMembers() error {
+	listCmd := flag.NewFlagSet("", flag.ExitOnError)
+	var hostname string
+	var bearer string
+	var secure bool
+	connectionFlags(listCmd, &hostname, &secure, &bearer)
+	workspaceId := listCmd.Uint64("workspace-id", 0, "the ID of the workspace")
+	listCmd.Parse(os.Args[2:])
+
+	if err := withTasksClient(hostname, secure, func(client taskspb.TasksClient) error {
+		stream, err := client.ListWorkspaceMembers(getContext(bearer), &taskspb.ListWorkspaceMembersRequest{
+			WorkspaceId: *workspaceId,
+		})
+		if err != nil {
+			return fmt.Errorf("calling task client: %w", err)
+		}
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("could not receive next member: %w", err)
+			}
+			jsonBytes, err := protojson.Marshal(res)
+			if err != nil {
+				return fmt.Errorf("converting to json: %w", err)
+			}
+			fmt.Println(string(jsonBytes))
+		}
+	}); err != nil {
+		return fmt.Errorf("listing workspace members: %w", err)
+	}
+	return nil
+}
+
+func setWorkspaceRole() error {
+	setCmd := flag.NewFlagSet("", flag.ExitOnError)
+	var hostname string
+	var bearer string
+	var secure bool
+	connectionFlags(setCmd, &hostname, &secure, &bearer)
+	workspaceId := setCmd.Uint64("workspace-id", 0, "the ID of the workspace")
+	userId := setCmd.Uint64("user-id", 0, "the ID of the member whose role to change")
+	role := setCmd.String("role", "member", "one of owner, member or viewer")
+	setCmd.Parse(os.Args[2:])
+
+	wireRole, exists := taskspb.WorkspaceRole_value["WORKSPACE_"+strings.ToUpper(*role)]
+	if !exists {
+		return fmt.Errorf("unrecognized workspace role %s", *role)
+	}
+	if err := withTasksClient(hostname, secure, func(client taskspb.TasksClient) error {
+		if _, err := client.SetWorkspaceRole(getContext(bearer), &taskspb.SetWorkspaceRoleRequest{
+			WorkspaceId: *workspaceId,
+			UserId:      *userId,
+			Role:        taskspb.WorkspaceRole(wireRole),
+		}); err != nil {
+			return fmt.Errorf("calling task client: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("setting workspace role: %w", err)
+	}
+	return nil
+}
+
+// Workspace ids start above 0, so 0 stands for the personal workspace
+func workspace(workspaceId uint64) types.Option[uint64] {
+	if workspaceId == 0 {
+		return types.None[uint64]()
+	}
+	return types.Some(workspaceId)
+}
+
+func webhookDeliveries() error {
+	deliveriesCmd := flag.NewFlagSet("", flag.ExitOnError)
+	var hostname string
+	var bearer string
+	var secure bool
+	connectionFlags(deliveriesCmd, &hostname, &secure, &bearer)
+	webhookId := deliveriesCmd.Uint64("webhook-id", 0, "only show deliveries to this webhook. If 0 deliveries to all webhooks are shown")
+	limit := deliveriesCmd.Uint("limit", 0, "the number of most recent deliveries to show, the server default is used if 0")
+	deliveriesCmd.Parse(os.Args[2:])
+
+	if err := withTasksClient(hostname, secure, func(client taskspb.TasksClient) error {
+		request := &taskspb.ListWebhookDeliveriesRequest{
+			Limit: uint32(*limit),
+		}
+		if *webhookId != 0 {
+			request.WebhookId = webhookId
+		}
+		stream, err := client.ListWebhookDeliveries(getContext(bearer), request)
+		if err != nil {
+			return fmt.Errorf("calling task client: %w", err)
+		}
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("could not receive next delivery: %w", err)
+			}
+			jsonBytes, err := protojson.Marshal(res)
+			if err != nil {
+				return fmt.Errorf("converting to json: %w", err)
+			}
+			fmt.Println(string(jsonBytes))
+		}
+	}); err != nil {
+		return fmt.Errorf("listing webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+// A local endpoint to point webhooks at while testing them, it prints every
+// payload that was signed with secret.
+func receiveWebhooks() error {
+	receiveCmd := flag.NewFlagSet("", flag.ExitOnError)
+	address := receiveCmd.String("listen", DEFAULT_RECEIVER, "the address to receive webhooks on")
+	secret := receiveCmd.String("secret", "", "the secret returned when the webhook was added")
+	receiveCmd.Parse(os.Args[2:])
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "reading body", http.StatusBadRequest)
+			return
+		}
+		if err := webhooks.Verify(*secret, r.Header, body, time.Now(), WEBHOOK_TOLERANCE); err != nil {
+			fmt.Fprintf(os.Stderr, "rejected delivery %s: %v\n", r.Header.Get(webhooks.DELIVERY_HEADER), err)
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+		fmt.Println(string(body))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	fmt.Fprintf(os.Stderr, "receiving webhooks at http://%s\n", *address)
+	if err := http.ListenAndServe(*address, handler); err != nil {
+		return fmt.Errorf("serving webhook receiver: %w", err)
+	}
+	return nil
+}
+
+func export() error {
+	var hostname string
+	var bearer string
+	var secure bool
+	exportCmd := flag.NewFlagSet("", flag.ExitOnError)
+	connectionFlags(exportCmd, &hostname, &secure, &bearer)
+	format := exportCmd.String("format", DEFAULT_FORMAT, formatsDescription())
+	exportCmd.Parse(os.Args[2:])
+
+	writer := exportFormats[*format]
+	if writer == nil {
+		return fmt.Errorf("unrecognized format %s", *format)
+	}
+
+	if err := withTasksClient(hostname, secure, func(client taskspb.TasksClient) error {
+		records, err := calls.Export(getContext(bearer), client)
+		if err != nil {
+			return fmt.Errorf("exporting records: %w", err)
+		}
+		if err := writer(os.Stdout, records); err != nil {
+			return fmt.Errorf("writing records as %s: %w", *format, err)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to export data: %w", err)
+	}
+	return nil
+}
+
+func importData() error {
+	var hostname string
+	var bearer string
+	var secure bool
+	importCmd := flag.NewFlagSet("", flag.ExitOnError)
+	connectionFlags(importCmd, &hostname, &secure, &bearer)
+	format := importCmd.String("format", DEFAULT_FORMAT, formatsDescription())
+	importCmd.Parse(os.Args[2:])
+
+	if *format == "csv" {
+		return importCsv(hostname, secure, bearer)
+	}
+	reader := importFormats[*format]
+	if reader == nil {
+		return fmt.Errorf("unrecognized format %s", *format)
+	}
+	records, err := reader(os.Stdin)
+	if err != nil {
+		return fmt.Errorf("reading records as %s: %w", *format, err)
+	}
+
+	if err := withTasksClient(hostname, secure, func(client taskspb.TasksClient) error {
+		resp, err := calls.Import(getContext(bearer), client, records)
+		if err != nil {
+			return fmt.Errorf("importing records: %w", err)
+		}
+		jsonBytes, err := protojson.Marshal(resp)
+		if err != nil {
+			return fmt.Errorf("converting to json: %w", err)
+		}
+		fmt.Println(string(jsonBytes))
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to import data: %w", err)
+	}
+	return nil
+}
+
+// Rows of a csv that came from export update the tasks they were exported
+// from, so that it can be edited and imported again. The other rows are
+// imported as new tasks.
+func importCsv(hostname string, secure bool, bearer string) error {
+	if err := withTasksClient(hostname, secure, func(client taskspb.TasksClient) error {
+		ctx := getContext(bearer)
+		exported, err := calls.Export(ctx, client)
+		if err != nil {
+			return fmt.Errorf("getting existing tasks: %w", err)
+		}
+		existing := map[uint64]*taskspb.Task{}
+		for _, r := range exported {
+			if task := r.GetTask(); task != nil {
+				existing[task.GetTaskId()] = task.GetTask()
+			}
+		}
+
+		plan, err := formats.ReadCsvImport(os.Stdin, existing)
+		if err != nil {
+			return fmt.Errorf("reading records as csv: %w", err)
+		}
+		for taskId, task := range plan.Updates {
+			if _, err := client.PutTask(ctx, &taskspb.PutTaskRequest{
+				Task:   task,
+				TaskId: &taskId,
+			}); err != nil {
+				return fmt.Errorf("updating task %d: %w", taskId, err)
+			}
+		}
+		resp := &taskspb.ImportDataResponse{}
+		if len(plan.Creates) > 0 {
+			resp, err = calls.Import(ctx, client, plan.Creates)
+			if err != nil {
+				return fmt.Errorf("importing records: %w", err)
+			}
+		}
+		jsonBytes, err := protojson.Marshal(resp)
+		if err != nil {
+			return fmt.Errorf("converting to json: %w", err)
+		}
+		fmt.Println(string(jsonBytes))
+		fmt.Fprintf(os.Stderr, "created %d, updated %d\n", len(plan.Creates), len(plan.Updates))
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to import data: %w", err)
+	}
+	return nil
+}
+
+func syncTodoTxt() error {
+	var hostname string
+	var bearer string
+	var secure bool
+	syncCmd := flag.NewFlagSet("", flag.ExitOnError)
+	connectionFlags(syncCmd, &hostname, &secure, &bearer)
+	file := syncCmd.String("file", "todo.txt", "the todo.txt file to sync with")
+	statePath := syncCmd.String("state", "", "where to keep the state of the last sync, defaults to a hidden file next to --file")
+	onConflict := syncCmd.String("on-conflict", "file", "which side wins when a task changed in both places, file or server")
+	syncCmd.Parse(os.Args[2:])
+
+	prefer := todosync.PreferFile
+	switch *onConflict {
+	case "file":
+	case "server":
+		prefer = todosync.PreferServer
+	default:
+		return fmt.Errorf("unrecognized conflict resolution %s", *onConflict)
+	}
+	if *statePath == "" {
+		*statePath = filepath.Join(filepath.Dir(*file), "."+filepath.Base(*file)+".taskmaster")
+	}
+
+	var lines []string
+	content, err := os.ReadFile(*file)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("reading %s: %w", *file, err)
+	}
+	if len(content) > 0 {
+		lines = strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+	}
+
+	state := todosync.State{}
+	stateContent, err := os.ReadFile(*statePath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("reading sync state %s: %w", *statePath, err)
+	}
+	if len(stateContent) > 0 {
+		if err := json.Unmarshal(stateContent, &state); err != nil {
+			return fmt.Errorf("parsing sync state %s: %w", *statePath, err)
+		}
+	}
+
+	if err := withTasksClient(hostname, secure, func(client taskspb.TasksClient) error {
+		ctx := getContext(bearer)
+		remote := map[uint64]*taskspb.Task{}
+		for _, status := range taskspb.Status_value {
+			tasks, err := calls.Get(ctx, client, types.None[uint64](), taskspb.AssignmentFilter_ALL_TASKS, nil, taskspb.Status(status))
+			if err != nil {
+				return fmt.Errorf("getting tasks: %w", err)
+			}
+			for _, t := range tasks {
+				remote[t.GetTaskId()] = t.GetTask()
+			}
+		}
+
+		plan, err := todosync.Reconcile(lines, state, remote, prefer)
+		if err != nil {
+			return fmt.Errorf("reconciling %s: %w", *file, err)
+		}
+		for _, c := range plan.Conflicts {
+			fmt.Fprintf(os.Stderr, "conflict: %s\n", c)
+		}
+
+		for taskId, task := range plan.Updates {
+			if _, err := client.PutTask(ctx, &taskspb.PutTaskRequest{
+				Task:   task,
+				TaskId: &taskId,
+			}); err != nil {
+				return fmt.Errorf("updating task %d: %w", taskId, err)
+			}
+		}
+		for _, line := range plan.Creates {
+			resp, err := client.PutTask(ctx, &taskspb.PutTaskRequest{Task: line.Task})
+			if err != nil {
+				return fmt.Errorf("creating task for %q: %w", line.Text, err)
+			}
+			line.TaskId = types.Some(resp.GetTaskId())
+			line.Text = formats.WithTodoTxtId(line.Text, resp.GetTaskId())
+		}
+
+		var output strings.Builder
+		for _, line := range plan.Lines {
+			output.WriteString(line.Text)
+			output.WriteByte('\n')
+		}
+		if err := writeFileAtomically(*file, []byte(output.String())); err != nil {
+			return fmt.Errorf("writing %s: %w", *file, err)
+		}
+		stateBytes, err := json.Marshal(plan.State())
+		if err != nil {
+			return fmt.Errorf("converting sync state to json: %w", err)
+		}
+		if err := writeFileAtomically(*statePath, stateBytes); err != nil {
+			return fmt.Errorf("writing sync state %s: %w", *statePath, err)
+		}
+
+		fmt.Printf(
+			"created %d, updated %d, conflicts %d\n",
+			len(plan.Creates), len(plan.Updates), len(plan.Conflicts),
+		)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to sync todo.txt: %w", err)
+	}
+	return nil
+}
+
+func writeFileAtomically(path string, content []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("creating temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing temporary file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("closing temporary file: %w", err)
+	}
+	if info, err := os.Stat(path); err == nil {
+		os.Chmod(tmp.Name(), info.Mode())
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func formatsDescription() string {
+	var names []string
+	for name := range exportFormats {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return "one of " + strings.Join(names, ", ")
+}
+
+func getGrpcClient(hostname string, secure bool) (*grpc.ClientConn, error) {
+	var config *tls.Config
+	if secure {
+		var err error
+		config, err = certs.ClientConfig(tlsFiles.caCert, tlsFiles.clientCert, tlsFiles.clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("setting up tls: %w", err)
+		}
+	}
+	return grpc.NewClient(
+		hostname,
+		grpc.WithTransportCredentials(transportCredentials(secure, config)),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithChainUnaryInterceptor(calls.UnaryRequestIdInterceptor),
+		grpc.WithChainStreamInterceptor(calls.StreamRequestIdInterceptor),
+	)
+}
+
+func transportCredentials(secure bool, config *tls.Config) credentials.TransportCredentials {
+	if !secure {
+		return insecure.NewCredentials()
+	}
+	if config == nil {
+		config = &tls.Config{}
+	}
+	return credentials.NewTLS(config)
+}
+
+// Profiles outlive the directory they were saved from
+func absolute(path string) string {
+	if path == "" {
+		return ""
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
+func withTasksClient(
+	hostname string,
+	secure bool,
+	consumer func(client taskspb.TasksClient) error,
+) error {
+	shutdownTracing, err := tracing.Setup(context.Background(), traceOptions)
+	if err != nil {
+		return fmt.Errorf("setting up tracing: %w", err)
+	}
+	defer shutdownTracing(context.Background())
+	var span trace.Span
+	rootContext, span = otel.Tracer(TRACER_NAME).Start(context.Background(), os.Args[1])
+	defer span.End()
+
 	conn, err := getGrpcClient(hostname, secure)
 	if err != nil {
 		return fmt.Errorf("connecting to grpc server: %w", err)
@@ -294,7 +1533,7 @@ func withTasksClient(
 
 func getContext(bearer string) context.Context {
 	return metadata.NewOutgoingContext(
-		context.Background(),
+		rootContext,
 		metadata.Pairs("Authorization", bearer),
 	)
 }
@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/WadeCappa/taskmaster/internal/types"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+)
+
+const (
+	// tells access tokens apart from login sessions without asking anyone
+	ACCESS_TOKEN_PREFIX = "tmpat_"
+	ACCESS_TOKEN_BYTES  = 32
+)
+
+// Who is making a request, and what they may do
+type Caller struct {
+	UserId UserId
+	// nil for login sessions, which may do anything
+	Scopes []taskspb.Scope
+}
+
+func (c Caller) IsSession() bool {
+	return c.Scopes == nil
+}
+
+// Both write scopes also allow reading
+func (c Caller) HasScope(scope taskspb.Scope) bool {
+	if c.IsSession() {
+		return true
+	}
+	if scope == taskspb.Scope_READ_ONLY {
+		return len(c.Scopes) > 0
+	}
+	return slices.Contains(c.Scopes, scope)
+}
+
+// Finds the live, unexpired access token with the given hash
+type AccessTokenStore interface {
+	LookupAccessToken(ctx context.Context, hash []byte) (types.Option[Caller], error)
+}
+
+// Returned when a caller is known, but their token does not allow the request.
+type InsufficientScopeError struct {
+	Method string
+	// empty when the method needs a login session
+	Missing []taskspb.Scope
+}
+
+func (e *InsufficientScopeError) Error() string {
+	if len(e.Missing) == 0 {
+		return fmt.Sprintf("%s needs a login session, access tokens are not accepted", e.Method)
+	}
+	missing := make([]string, len(e.Missing))
+	for i, s := range e.Missing {
+		missing[i] = s.String()
+	}
+	return fmt.Sprintf("%s needs an access token with the %s scopes", e.Method, strings.Join(missing, ", "))
+}
+
+// Returns a new access token, and the hash of it that is kept at rest
+func NewAccessToken() (string, []byte, error) {
+	b := make([]byte, ACCESS_TOKEN_BYTES)
+	if _, err := rand.Read(b); err != nil {
+		return "", nil, fmt.Errorf("generating access token: %w", err)
+	}
+	token := ACCESS_TOKEN_PREFIX + hex.EncodeToString(b)
+	return token, HashAccessToken(token), nil
+}
+
+// Tokens are long and random, so a plain hash is enough to make a leaked
+// table useless
+func HashAccessToken(token string) []byte {
+	hash := sha256.Sum256([]byte(token))
+	return hash[:]
+}
+
+type callerKey struct{}
+
+// Remembers an authorized caller, so handlers further down do not look the
+// token up again
+func WithCaller(ctx context.Context, caller Caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+func callerFromContext(ctx context.Context) (Caller, bool) {
+	caller, ok := ctx.Value(callerKey{}).(Caller)
+	return caller, ok
+}
@@ -0,0 +1,74 @@
+package auth_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/WadeCappa/taskmaster/internal/auth"
+	"github.com/WadeCappa/taskmaster/internal/types"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAccessTokens struct {
+	hashes  map[string]auth.Caller
+	lookups int
+}
+
+func (f *fakeAccessTokens) LookupAccessToken(ctx context.Context, hash []byte) (types.Option[auth.Caller], error) {
+	f.lookups++
+	if caller, exists := f.hashes[string(hash)]; exists {
+		return types.Some(caller), nil
+	}
+	return types.None[auth.Caller](), nil
+}
+
+func TestAccessTokensAreAcceptedNextToSessions(t *testing.T) {
+	token, hash, err := auth.NewAccessToken()
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(token, auth.ACCESS_TOKEN_PREFIX))
+	require.Equal(t, auth.HashAccessToken(token), hash)
+
+	store := &fakeAccessTokens{hashes: map[string]auth.Caller{
+		string(hash): {UserId: 3, Scopes: []taskspb.Scope{taskspb.Scope_WRITE_TASKS}},
+	}}
+	client := &fakeAuthmaster{}
+	a := auth.NewAuth(client, auth.DefaultCacheOptions()).AcceptAccessTokens(store)
+
+	caller, err := a.GetCaller(withToken(token))
+	require.NoError(t, err)
+	require.Equal(t, auth.UserId(3), caller.UserId)
+	require.False(t, caller.IsSession())
+	require.True(t, caller.HasScope(taskspb.Scope_READ_ONLY))
+	require.True(t, caller.HasScope(taskspb.Scope_WRITE_TASKS))
+	require.False(t, caller.HasScope(taskspb.Scope_WRITE_ADDENDUMS))
+
+	userId, err := a.GetUserId(withToken(auth.BEARER_PREFIX + token))
+	require.NoError(t, err)
+	require.Equal(t, auth.UserId(3), userId)
+
+	// access tokens are never cached, so revoking one is immediate
+	delete(store.hashes, string(hash))
+	_, err = a.GetUserIdFromToken(context.Background(), token)
+	var unauthenticated *auth.UnauthenticatedError
+	require.ErrorAs(t, err, &unauthenticated)
+	require.Equal(t, 3, store.lookups)
+	require.Equal(t, int32(0), client.calls.Load())
+
+	caller, err = a.GetCaller(withToken("session"))
+	require.NoError(t, err)
+	require.True(t, caller.IsSession())
+	require.True(t, caller.HasScope(taskspb.Scope_WRITE_ADDENDUMS))
+}
+
+func TestCallerFromContextSkipsLookup(t *testing.T) {
+	client := &fakeAuthmaster{}
+	a := auth.NewAuth(client, auth.DefaultCacheOptions())
+
+	ctx := auth.WithCaller(withToken("token"), auth.Caller{UserId: 9})
+	userId, err := a.GetUserId(ctx)
+	require.NoError(t, err)
+	require.Equal(t, auth.UserId(9), userId)
+	require.Equal(t, int32(0), client.calls.Load())
+}
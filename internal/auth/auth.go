@@ -2,34 +2,156 @@ package auth
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/WadeCappa/authmaster/pkg/go/authmaster/v1"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	AUTHORIZATION_HEADER = "authorization"
 )
 
 type Auth struct {
-	client authmaster.AuthmasterClient
+	// turns a bearer token into the user it belongs to
+	verify func(ctx context.Context, token string) (UserId, error)
+	cache  *tokenCache
+	// nil until AcceptAccessTokens is called
+	accessTokens AccessTokenStore
+	// set when callers are identified by client certificate, see NewCertificateAuth
+	certificates map[string]UserId
+	// nil when callers are identified without calling out to another server
+	ping func(ctx context.Context) error
 }
 
-func NewAuth(client authmaster.AuthmasterClient) *Auth {
+// Asks authmaster who every token belongs to
+func NewAuth(client authmaster.AuthmasterClient, cache CacheOptions) *Auth {
 	return &Auth{
-		client: client,
+		verify: testAuth(client),
+		cache:  newTokenCache(cache),
+		ping:   pingAuthmaster(client),
+	}
+}
+
+// Returned when a caller could not be identified. Failing to reach the auth
+// server is reported as an ordinary error instead.
+type UnauthenticatedError struct {
+	reason string
+	cause  error
+}
+
+func (e *UnauthenticatedError) Error() string {
+	return e.reason
+}
+
+func (e *UnauthenticatedError) Unwrap() error {
+	return e.cause
+}
+
+// Accepts access tokens from store next to whatever this Auth already accepts.
+// Access tokens are looked up on every request so that revoking one takes
+// effect immediately.
+func (a *Auth) AcceptAccessTokens(store AccessTokenStore) *Auth {
+	a.accessTokens = store
+	return a
+}
+
+// Fails when the server that identifies callers cannot be reached. Always
+// succeeds when callers are identified locally.
+func (a *Auth) Ping(ctx context.Context) error {
+	if a.ping == nil {
+		return nil
 	}
+	return a.ping(ctx)
 }
 
 func (a *Auth) GetUserId(ctx context.Context) (UserId, error) {
+	caller, err := a.GetCaller(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return caller.UserId, nil
+}
+
+// Identifies the caller from the authorization header, unless they were
+// already identified by WithCaller. With certificate auth, callers without
+// the header are identified by their client certificate instead.
+func (a *Auth) GetCaller(ctx context.Context) (Caller, error) {
+	if caller, ok := callerFromContext(ctx); ok {
+		return caller, nil
+	}
 	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return 0, errors.New("no authorization header for request")
+	if !ok || len(md.Get(AUTHORIZATION_HEADER)) == 0 || md.Get(AUTHORIZATION_HEADER)[0] == "" {
+		if a.certificates != nil {
+			return a.callerFromCertificate(ctx)
+		}
+		return Caller{}, &UnauthenticatedError{reason: "no authorization header for request"}
+	}
+	return a.callerFromToken(ctx, md.Get(AUTHORIZATION_HEADER)[0])
+}
+
+// For callers that do not come in over grpc and hold the bearer token directly
+func (a *Auth) GetUserIdFromToken(ctx context.Context, token string) (UserId, error) {
+	if token == "" {
+		return 0, &UnauthenticatedError{reason: "no authorization token for request"}
+	}
+	caller, err := a.callerFromToken(ctx, token)
+	if err != nil {
+		return 0, err
+	}
+	return caller.UserId, nil
+}
+
+func (a *Auth) callerFromToken(ctx context.Context, token string) (Caller, error) {
+	if accessToken := strings.TrimPrefix(token, BEARER_PREFIX); a.accessTokens != nil && strings.HasPrefix(accessToken, ACCESS_TOKEN_PREFIX) {
+		found, err := a.accessTokens.LookupAccessToken(ctx, HashAccessToken(accessToken))
+		if err != nil {
+			return Caller{}, fmt.Errorf("looking up access token: %w", err)
+		}
+		caller, exists := found.Unwrap()
+		if !exists {
+			return Caller{}, &UnauthenticatedError{reason: "access token is unknown, expired or revoked"}
+		}
+		return caller, nil
 	}
 
-	newContext := metadata.NewOutgoingContext(ctx, md)
-	resp, err := a.client.TestAuth(newContext, &authmaster.TestAuthRequest{})
+	userId, err := a.cache.get(ctx, token, a.verify)
 	if err != nil {
-		return 0, fmt.Errorf("testing auth-header: %w", err)
+		return Caller{}, err
+	}
+	return Caller{UserId: userId}, nil
+}
+
+// Any answer at all, even rejecting the missing token, means authmaster is up
+func pingAuthmaster(client authmaster.AuthmasterClient) func(context.Context) error {
+	return func(ctx context.Context) error {
+		_, err := client.TestAuth(ctx, &authmaster.TestAuthRequest{})
+		switch status.Code(err) {
+		case codes.Unavailable, codes.DeadlineExceeded, codes.Canceled:
+			return fmt.Errorf("reaching authmaster: %w", err)
+		}
+		return nil
 	}
+}
 
-	return UserId(resp.GetUserId()), nil
+func testAuth(client authmaster.AuthmasterClient) func(context.Context, string) (UserId, error) {
+	return func(ctx context.Context, token string) (UserId, error) {
+		newContext := metadata.NewOutgoingContext(ctx, metadata.Pairs(AUTHORIZATION_HEADER, token))
+		start := time.Now()
+		resp, err := client.TestAuth(newContext, &authmaster.TestAuthRequest{})
+		authmasterLatency.WithLabelValues(status.Code(err).String()).Observe(time.Since(start).Seconds())
+		if err != nil {
+			switch status.Code(err) {
+			case codes.Unavailable, codes.DeadlineExceeded, codes.Canceled:
+				return 0, fmt.Errorf("testing auth-header: %w", err)
+			}
+			return 0, &UnauthenticatedError{reason: "authorization was rejected", cause: err}
+		}
+
+		return UserId(resp.GetUserId()), nil
+	}
 }
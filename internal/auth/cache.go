@@ -0,0 +1,157 @@
+package auth
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	DEFAULT_CACHE_TTL          = time.Minute
+	DEFAULT_NEGATIVE_CACHE_TTL = 5 * time.Second
+	DEFAULT_CACHE_SIZE         = 10000
+	// lookups are shared between callers, so they cannot use any one caller's deadline
+	LOOKUP_TIMEOUT = 10 * time.Second
+)
+
+type CacheOptions struct {
+	// how long a successful lookup is reused for, nothing is cached when this is zero
+	TTL time.Duration
+	// how long a rejected token is remembered for, rejections are not cached when this is zero
+	NegativeTTL time.Duration
+	// once this many tokens are cached the least recently used one is evicted
+	MaxEntries int
+}
+
+func DefaultCacheOptions() CacheOptions {
+	return CacheOptions{
+		TTL:         DEFAULT_CACHE_TTL,
+		NegativeTTL: DEFAULT_NEGATIVE_CACHE_TTL,
+		MaxEntries:  DEFAULT_CACHE_SIZE,
+	}
+}
+
+// Tokens are only held as hashes, so a dump of the cache does not leak them.
+type tokenHash [sha256.Size]byte
+
+type cacheEntry struct {
+	key     tokenHash
+	userId  UserId
+	err     error
+	expires time.Time
+}
+
+// An LRU cache of token lookups. Concurrent lookups of the same token that
+// miss the cache share a single call to lookup.
+type tokenCache struct {
+	options CacheOptions
+	group   singleflight.Group
+
+	lock    sync.Mutex
+	entries map[tokenHash]*list.Element
+	// most recently used at the front
+	order *list.List
+}
+
+func newTokenCache(options CacheOptions) *tokenCache {
+	return &tokenCache{
+		options: options,
+		entries: map[tokenHash]*list.Element{},
+		order:   list.New(),
+	}
+}
+
+func (c *tokenCache) get(
+	ctx context.Context,
+	token string,
+	lookup func(context.Context, string) (UserId, error),
+) (UserId, error) {
+	if c.options.TTL <= 0 || c.options.MaxEntries <= 0 {
+		return lookup(ctx, token)
+	}
+
+	ctx, span := otel.Tracer(TRACER_NAME).Start(ctx, "token cache")
+	defer span.End()
+	key := tokenHash(sha256.Sum256([]byte(token)))
+	if entry, exists := c.load(key); exists {
+		cacheLookups.WithLabelValues(CACHE_HIT).Inc()
+		span.SetAttributes(attribute.Bool("auth.cache.hit", true))
+		return entry.userId, entry.err
+	}
+	cacheLookups.WithLabelValues(CACHE_MISS).Inc()
+	span.SetAttributes(attribute.Bool("auth.cache.hit", false))
+
+	result := c.group.DoChan(string(key[:]), func() (any, error) {
+		lookupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), LOOKUP_TIMEOUT)
+		defer cancel()
+		userId, err := lookup(lookupCtx, token)
+		c.store(key, userId, err)
+		return userId, err
+	})
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	case r := <-result:
+		if r.Err != nil {
+			return 0, r.Err
+		}
+		return r.Val.(UserId), nil
+	}
+}
+
+func (c *tokenCache) load(key tokenHash) (cacheEntry, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	element, exists := c.entries[key]
+	if !exists {
+		return cacheEntry{}, false
+	}
+	entry := element.Value.(cacheEntry)
+	if time.Now().After(entry.expires) {
+		c.order.Remove(element)
+		delete(c.entries, key)
+		return cacheEntry{}, false
+	}
+	c.order.MoveToFront(element)
+	return entry, true
+}
+
+func (c *tokenCache) store(key tokenHash, userId UserId, err error) {
+	ttl := c.options.TTL
+	if err != nil {
+		// only remember that a token was rejected, a failure to reach the
+		// auth server says nothing about the token
+		var unauthenticated *UnauthenticatedError
+		if !errors.As(err, &unauthenticated) || c.options.NegativeTTL <= 0 {
+			return
+		}
+		ttl = c.options.NegativeTTL
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	entry := cacheEntry{
+		key:     key,
+		userId:  userId,
+		err:     err,
+		expires: time.Now().Add(ttl),
+	}
+	if element, exists := c.entries[key]; exists {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	for c.order.Len() > c.options.MaxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(cacheEntry).key)
+	}
+}
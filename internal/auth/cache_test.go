@@ -0,0 +1,134 @@
+package auth_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/WadeCappa/authmaster/pkg/go/authmaster/v1"
+	"github.com/WadeCappa/taskmaster/internal/auth"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type fakeAuthmaster struct {
+	authmaster.AuthmasterClient
+	calls   atomic.Int32
+	release chan struct{}
+	err     error
+}
+
+func (f *fakeAuthmaster) TestAuth(
+	ctx context.Context,
+	request *authmaster.TestAuthRequest,
+	opts ...grpc.CallOption,
+) (*authmaster.TestAuthResponse, error) {
+	f.calls.Add(1)
+	if f.release != nil {
+		<-f.release
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	md, _ := metadata.FromOutgoingContext(ctx)
+	if md.Get(auth.AUTHORIZATION_HEADER)[0] == "bad-token" {
+		return nil, status.Error(codes.Unauthenticated, "bad token")
+	}
+	return &authmaster.TestAuthResponse{UserId: int64(len(md.Get(auth.AUTHORIZATION_HEADER)[0]))}, nil
+}
+
+func withToken(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.AUTHORIZATION_HEADER, token))
+}
+
+func TestCachedTokensSkipAuthmaster(t *testing.T) {
+	client := &fakeAuthmaster{}
+	a := auth.NewAuth(client, auth.DefaultCacheOptions())
+
+	for range 3 {
+		userId, err := a.GetUserId(withToken("token"))
+		require.NoError(t, err)
+		require.Equal(t, auth.UserId(5), userId)
+	}
+	userId, err := a.GetUserIdFromToken(context.Background(), "token")
+	require.NoError(t, err)
+	require.Equal(t, auth.UserId(5), userId)
+	require.Equal(t, int32(1), client.calls.Load())
+
+	_, err = a.GetUserId(withToken("other-token"))
+	require.NoError(t, err)
+	require.Equal(t, int32(2), client.calls.Load())
+}
+
+func TestRejectedTokensAreCachedForTheNegativeTtl(t *testing.T) {
+	client := &fakeAuthmaster{}
+	a := auth.NewAuth(client, auth.CacheOptions{
+		TTL:         time.Minute,
+		NegativeTTL: 50 * time.Millisecond,
+		MaxEntries:  10,
+	})
+
+	for range 2 {
+		_, err := a.GetUserId(withToken("bad-token"))
+		var unauthenticated *auth.UnauthenticatedError
+		require.ErrorAs(t, err, &unauthenticated)
+	}
+	require.Equal(t, int32(1), client.calls.Load())
+
+	time.Sleep(100 * time.Millisecond)
+	_, err := a.GetUserId(withToken("bad-token"))
+	require.Error(t, err)
+	require.Equal(t, int32(2), client.calls.Load())
+}
+
+func TestUnreachableAuthmasterIsNotCached(t *testing.T) {
+	client := &fakeAuthmaster{err: status.Error(codes.Unavailable, "connection refused")}
+	a := auth.NewAuth(client, auth.DefaultCacheOptions())
+
+	for range 2 {
+		_, err := a.GetUserId(withToken("token"))
+		require.Equal(t, codes.Unavailable, status.Code(err))
+	}
+	require.Equal(t, int32(2), client.calls.Load())
+}
+
+func TestLeastRecentlyUsedTokenIsEvicted(t *testing.T) {
+	client := &fakeAuthmaster{}
+	a := auth.NewAuth(client, auth.CacheOptions{
+		TTL:        time.Minute,
+		MaxEntries: 2,
+	})
+
+	for _, token := range []string{"first", "second", "first", "third", "first", "second"} {
+		_, err := a.GetUserId(withToken(token))
+		require.NoError(t, err)
+	}
+	// second was evicted by third, first was kept because it was used more recently
+	require.Equal(t, int32(4), client.calls.Load())
+}
+
+func TestConcurrentLookupsShareOneCall(t *testing.T) {
+	client := &fakeAuthmaster{release: make(chan struct{})}
+	a := auth.NewAuth(client, auth.DefaultCacheOptions())
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			userId, err := a.GetUserId(withToken("token"))
+			require.NoError(t, err)
+			require.Equal(t, auth.UserId(5), userId)
+		}()
+	}
+	require.Eventually(t, func() bool { return client.calls.Load() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	close(client.release)
+	wg.Wait()
+	require.Equal(t, int32(1), client.calls.Load())
+}
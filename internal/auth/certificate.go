@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	FINGERPRINT_PREFIX = "sha256:"
+)
+
+// Identifies callers by the verified client certificate they connected with.
+// users maps either the sha256 fingerprint of a certificate, written as
+// sha256:<hex>, or a certificate's subject common name to a user. Access
+// tokens are still accepted from callers that send one.
+func NewCertificateAuth(users map[string]UserId) *Auth {
+	return &Auth{
+		verify: func(ctx context.Context, token string) (UserId, error) {
+			return 0, &UnauthenticatedError{reason: "this server identifies callers by client certificate, only access tokens are accepted as bearer tokens"}
+		},
+		cache:        newTokenCache(CacheOptions{}),
+		certificates: users,
+	}
+}
+
+// The leaf of the chain the tls handshake verified. Certificates that the
+// server did not verify are never trusted.
+func verifiedCertificate(ctx context.Context) (*x509.Certificate, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return nil, false
+	}
+	return info.State.VerifiedChains[0][0], true
+}
+
+func (a *Auth) callerFromCertificate(ctx context.Context) (Caller, error) {
+	certificate, ok := verifiedCertificate(ctx)
+	if !ok {
+		return Caller{}, &UnauthenticatedError{reason: "no verified client certificate or authorization header for request"}
+	}
+	fingerprint := sha256.Sum256(certificate.Raw)
+	if userId, exists := a.certificates[FINGERPRINT_PREFIX+hex.EncodeToString(fingerprint[:])]; exists {
+		return Caller{UserId: userId}, nil
+	}
+	if userId, exists := a.certificates[certificate.Subject.CommonName]; exists && certificate.Subject.CommonName != "" {
+		return Caller{UserId: userId}, nil
+	}
+	return Caller{}, &UnauthenticatedError{reason: "client certificate does not belong to any user"}
+}
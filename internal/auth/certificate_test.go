@@ -0,0 +1,72 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"testing"
+
+	"github.com/WadeCappa/taskmaster/internal/auth"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func withCertificate(ctx context.Context, certificate *x509.Certificate, verified bool) context.Context {
+	state := tls.ConnectionState{PeerCertificates: []*x509.Certificate{certificate}}
+	if verified {
+		state.VerifiedChains = [][]*x509.Certificate{{certificate}}
+	}
+	return peer.NewContext(ctx, &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}})
+}
+
+func TestCertificateAuth(t *testing.T) {
+	alice := &x509.Certificate{Raw: []byte("alice"), Subject: pkix.Name{CommonName: "Alice Smith"}}
+	bob := &x509.Certificate{Raw: []byte("bob"), Subject: pkix.Name{CommonName: "bob"}}
+	bobFingerprint := sha256.Sum256(bob.Raw)
+	mallory := &x509.Certificate{Raw: []byte("mallory"), Subject: pkix.Name{CommonName: "mallory"}}
+
+	a := auth.NewCertificateAuth(map[string]auth.UserId{
+		"Alice Smith": 1,
+		auth.FINGERPRINT_PREFIX + hex.EncodeToString(bobFingerprint[:]): 2,
+	})
+
+	userId, err := a.GetUserId(withCertificate(context.Background(), alice, true))
+	require.NoError(t, err)
+	require.Equal(t, auth.UserId(1), userId)
+
+	userId, err = a.GetUserId(withCertificate(context.Background(), bob, true))
+	require.NoError(t, err)
+	require.Equal(t, auth.UserId(2), userId)
+
+	var unauthenticated *auth.UnauthenticatedError
+	for _, ctx := range []context.Context{
+		withCertificate(context.Background(), mallory, true),
+		// presented, but never verified against the client CA
+		withCertificate(context.Background(), alice, false),
+		context.Background(),
+		// only access tokens are accepted as bearer tokens
+		withCertificate(withToken("session"), alice, true),
+	} {
+		_, err := a.GetUserId(ctx)
+		require.ErrorAs(t, err, &unauthenticated)
+	}
+}
+
+func TestCertificateAuthAcceptsAccessTokens(t *testing.T) {
+	token, hash, err := auth.NewAccessToken()
+	require.NoError(t, err)
+	a := auth.NewCertificateAuth(map[string]auth.UserId{}).AcceptAccessTokens(&fakeAccessTokens{
+		hashes: map[string]auth.Caller{string(hash): {UserId: 5, Scopes: []taskspb.Scope{taskspb.Scope_READ_ONLY}}},
+	})
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.AUTHORIZATION_HEADER, token))
+	userId, err := a.GetUserId(ctx)
+	require.NoError(t, err)
+	require.Equal(t, auth.UserId(5), userId)
+}
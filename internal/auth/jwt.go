@@ -0,0 +1,283 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	DEFAULT_USER_ID_CLAIM = "sub"
+	DEFAULT_CLOCK_SKEW    = 30 * time.Second
+	BEARER_PREFIX         = "Bearer "
+)
+
+// only asymmetric algorithms, the key file holds public keys
+var jwtMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+type JwtOptions struct {
+	// a JWKS document, or one or more PEM encoded public keys or certificates
+	KeyFile  string
+	Issuer   string
+	Audience string
+	// the claim holding the user id, as a number or a string of digits
+	UserIdClaim string
+	// how far the exp and nbf claims may be off from our clock
+	ClockSkew time.Duration
+}
+
+type verificationKey struct {
+	// empty for keys read from PEM, which have no id
+	id  string
+	key crypto.PublicKey
+}
+
+// Verifies bearer tokens locally as signed JWTs, without talking to authmaster.
+// Tokens may optionally be prefixed with "Bearer ".
+func NewJwtAuth(options JwtOptions) (*Auth, error) {
+	if options.Issuer == "" || options.Audience == "" {
+		return nil, errors.New("jwt auth needs both an issuer and an audience")
+	}
+	if options.UserIdClaim == "" {
+		options.UserIdClaim = DEFAULT_USER_ID_CLAIM
+	}
+	content, err := os.ReadFile(options.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading jwt key file: %w", err)
+	}
+	keys, err := parseKeys(content)
+	if err != nil {
+		return nil, fmt.Errorf("parsing jwt key file %s: %w", options.KeyFile, err)
+	}
+
+	parser := jwt.NewParser(
+		jwt.WithValidMethods(jwtMethods),
+		jwt.WithIssuer(options.Issuer),
+		jwt.WithAudience(options.Audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(options.ClockSkew),
+	)
+	return &Auth{
+		verify: func(ctx context.Context, token string) (UserId, error) {
+			return verifyJwt(parser, keys, options.UserIdClaim, strings.TrimPrefix(token, BEARER_PREFIX))
+		},
+		// verifying locally is cheap, and caching would outlive a token's expiry
+		cache: newTokenCache(CacheOptions{}),
+	}, nil
+}
+
+func verifyJwt(
+	parser *jwt.Parser,
+	keys []verificationKey,
+	userIdClaim string,
+	token string,
+) (UserId, error) {
+	claims := jwt.MapClaims{}
+	if _, err := parser.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
+		return keyFor(t, keys)
+	}); err != nil {
+		return 0, &UnauthenticatedError{reason: fmt.Sprintf("invalid bearer token: %v", err), cause: err}
+	}
+
+	userId, err := userIdFromClaim(claims[userIdClaim])
+	if err != nil {
+		return 0, &UnauthenticatedError{reason: fmt.Sprintf("invalid %s claim: %v", userIdClaim, err)}
+	}
+	return userId, nil
+}
+
+// Picks the key named by the token's kid header. Tokens without a kid are
+// tried against every key.
+func keyFor(token *jwt.Token, keys []verificationKey) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	set := jwt.VerificationKeySet{}
+	for _, k := range keys {
+		if kid == "" || k.id == kid {
+			set.Keys = append(set.Keys, k.key)
+		}
+	}
+	if len(set.Keys) == 0 {
+		return nil, fmt.Errorf("no key with id %q", kid)
+	}
+	return set, nil
+}
+
+func userIdFromClaim(claim any) (UserId, error) {
+	switch value := claim.(type) {
+	case float64:
+		if value < 0 || value != float64(uint64(value)) {
+			return 0, fmt.Errorf("%v is not a user id", value)
+		}
+		return UserId(value), nil
+	case string:
+		userId, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not a user id", value)
+		}
+		return UserId(userId), nil
+	case nil:
+		return 0, errors.New("claim is missing")
+	default:
+		return 0, fmt.Errorf("unexpected claim type %T", claim)
+	}
+}
+
+func parseKeys(content []byte) ([]verificationKey, error) {
+	if bytes.HasPrefix(bytes.TrimSpace(content), []byte("{")) {
+		return parseJwks(content)
+	}
+
+	var keys []verificationKey
+	for {
+		var block *pem.Block
+		block, content = pem.Decode(content)
+		if block == nil {
+			break
+		}
+		var key crypto.PublicKey
+		var err error
+		switch block.Type {
+		case "PUBLIC KEY":
+			key, err = x509.ParsePKIXPublicKey(block.Bytes)
+		case "RSA PUBLIC KEY":
+			key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+		case "CERTIFICATE":
+			var certificate *x509.Certificate
+			certificate, err = x509.ParseCertificate(block.Bytes)
+			if err == nil {
+				key = certificate.PublicKey
+			}
+		default:
+			return nil, fmt.Errorf("unsupported pem block %q, expected public keys or certificates", block.Type)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", strings.ToLower(block.Type), err)
+		}
+		keys = append(keys, verificationKey{key: key})
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("found no keys")
+	}
+	return keys, nil
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func parseJwks(content []byte) ([]verificationKey, error) {
+	var document struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(content, &document); err != nil {
+		return nil, fmt.Errorf("parsing jwks: %w", err)
+	}
+
+	var keys []verificationKey
+	for i, k := range document.Keys {
+		// encryption keys have no business verifying signatures
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("parsing key %d (%q): %w", i, k.Kid, err)
+		}
+		keys = append(keys, verificationKey{id: k.Kid, key: key})
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("found no signing keys")
+	}
+	return keys, nil
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("decoding n: %w", err)
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("decoding e: %w", err)
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("exponent is too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("decoding x: %w", err)
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("decoding y: %w", err)
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("point is not on the curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("decoding x: %w", err)
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("expected %d bytes of key, found %d", ed25519.PublicKeySize, len(x))
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(decoded) == 0 {
+		return nil, errors.New("value is empty")
+	}
+	return new(big.Int).SetBytes(decoded), nil
+}
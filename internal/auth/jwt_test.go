@@ -0,0 +1,215 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/WadeCappa/taskmaster/internal/auth"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	TEST_ISSUER   = "https://issuer.example.com"
+	TEST_AUDIENCE = "taskmaster"
+)
+
+func writeFile(t *testing.T, name string, content []byte) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, content, 0o600))
+	return path
+}
+
+func writeJwks(t *testing.T, keys ...map[string]string) string {
+	content, err := json.Marshal(map[string]any{"keys": keys})
+	require.NoError(t, err)
+	return writeFile(t, "jwks.json", content)
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func jwtOptions(keyFile string) auth.JwtOptions {
+	return auth.JwtOptions{
+		KeyFile:   keyFile,
+		Issuer:    TEST_ISSUER,
+		Audience:  TEST_AUDIENCE,
+		ClockSkew: auth.DEFAULT_CLOCK_SKEW,
+	}
+}
+
+func validClaims(subject string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss": TEST_ISSUER,
+		"aud": TEST_AUDIENCE,
+		"sub": subject,
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key any, claims jwt.Claims) string {
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func requireUnauthenticated(t *testing.T, a *auth.Auth, token string) {
+	_, err := a.GetUserIdFromToken(context.Background(), token)
+	var unauthenticated *auth.UnauthenticatedError
+	require.ErrorAs(t, err, &unauthenticated)
+}
+
+func TestJwtSignedWithJwksKeys(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	edPublic, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	keyFile := writeJwks(t,
+		map[string]string{
+			"kty": "RSA",
+			"kid": "rsa",
+			"n":   encode(rsaKey.N.Bytes()),
+			"e":   encode(big.NewInt(int64(rsaKey.E)).Bytes()),
+		},
+		map[string]string{
+			"kty": "EC",
+			"kid": "ec",
+			"crv": "P-256",
+			"x":   encode(ecKey.X.FillBytes(make([]byte, 32))),
+			"y":   encode(ecKey.Y.FillBytes(make([]byte, 32))),
+		},
+		map[string]string{
+			"kty": "OKP",
+			"kid": "ed",
+			"crv": "Ed25519",
+			"x":   encode(edPublic),
+		},
+	)
+	a, err := auth.NewJwtAuth(jwtOptions(keyFile))
+	require.NoError(t, err)
+
+	userId, err := a.GetUserIdFromToken(context.Background(), sign(t, jwt.SigningMethodRS256, "rsa", rsaKey, validClaims("1")))
+	require.NoError(t, err)
+	require.Equal(t, auth.UserId(1), userId)
+
+	userId, err = a.GetUserId(withToken(auth.BEARER_PREFIX + sign(t, jwt.SigningMethodES256, "ec", ecKey, validClaims("2"))))
+	require.NoError(t, err)
+	require.Equal(t, auth.UserId(2), userId)
+
+	// without a kid every key is tried
+	userId, err = a.GetUserIdFromToken(context.Background(), sign(t, jwt.SigningMethodEdDSA, "", edKey, validClaims("3")))
+	require.NoError(t, err)
+	require.Equal(t, auth.UserId(3), userId)
+
+	requireUnauthenticated(t, a, sign(t, jwt.SigningMethodRS256, "ec", rsaKey, validClaims("1")))
+	requireUnauthenticated(t, a, sign(t, jwt.SigningMethodRS256, "unknown", rsaKey, validClaims("1")))
+}
+
+func TestJwtSignedWithPemKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	keyFile := writeFile(t, "key.pem", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	a, err := auth.NewJwtAuth(jwtOptions(keyFile))
+	require.NoError(t, err)
+
+	userId, err := a.GetUserIdFromToken(context.Background(), sign(t, jwt.SigningMethodES384, "", key, validClaims("42")))
+	require.NoError(t, err)
+	require.Equal(t, auth.UserId(42), userId)
+
+	other, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	requireUnauthenticated(t, a, sign(t, jwt.SigningMethodES384, "", other, validClaims("42")))
+	requireUnauthenticated(t, a, "not-a-jwt")
+}
+
+func TestJwtClaimsAreChecked(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyFile := writeFile(t, "key.pem", pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&key.PublicKey)}))
+	a, err := auth.NewJwtAuth(jwtOptions(keyFile))
+	require.NoError(t, err)
+
+	withClaim := func(name string, value any) string {
+		claims := validClaims("7")
+		if value == nil {
+			delete(claims, name)
+		} else {
+			claims[name] = value
+		}
+		return sign(t, jwt.SigningMethodRS256, "", key, claims)
+	}
+
+	requireUnauthenticated(t, a, withClaim("iss", "https://someone-else.example.com"))
+	requireUnauthenticated(t, a, withClaim("aud", "another-service"))
+	requireUnauthenticated(t, a, withClaim("exp", nil))
+	requireUnauthenticated(t, a, withClaim("exp", time.Now().Add(-time.Minute).Unix()))
+	requireUnauthenticated(t, a, withClaim("sub", nil))
+	requireUnauthenticated(t, a, withClaim("sub", "not-a-number"))
+
+	// expired, but within the allowed clock skew
+	userId, err := a.GetUserIdFromToken(context.Background(), withClaim("exp", time.Now().Add(-10*time.Second).Unix()))
+	require.NoError(t, err)
+	require.Equal(t, auth.UserId(7), userId)
+
+	// symmetric tokens are never accepted, even when signed with the public key
+	hmacToken := jwt.NewWithClaims(jwt.SigningMethodHS256, validClaims("7"))
+	signed, err := hmacToken.SignedString(x509.MarshalPKCS1PublicKey(&key.PublicKey))
+	require.NoError(t, err)
+	requireUnauthenticated(t, a, signed)
+}
+
+func TestJwtUserIdFromCustomClaim(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyFile := writeFile(t, "key.pem", pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&key.PublicKey)}))
+	options := jwtOptions(keyFile)
+	options.UserIdClaim = "uid"
+	a, err := auth.NewJwtAuth(options)
+	require.NoError(t, err)
+
+	claims := validClaims("not-a-user-id")
+	claims["uid"] = 12345
+	userId, err := a.GetUserIdFromToken(context.Background(), sign(t, jwt.SigningMethodRS256, "", key, claims))
+	require.NoError(t, err)
+	require.Equal(t, auth.UserId(12345), userId)
+}
+
+func TestJwtAuthNeedsUsableKeys(t *testing.T) {
+	_, err := auth.NewJwtAuth(jwtOptions(filepath.Join(t.TempDir(), "missing.pem")))
+	require.Error(t, err)
+
+	_, err = auth.NewJwtAuth(jwtOptions(writeFile(t, "empty.pem", []byte("nothing here"))))
+	require.Error(t, err)
+
+	_, err = auth.NewJwtAuth(jwtOptions(writeJwks(t, map[string]string{"kty": "oct", "k": "c2VjcmV0"})))
+	require.Error(t, err)
+
+	options := jwtOptions(writeJwks(t))
+	options.Audience = ""
+	_, err = auth.NewJwtAuth(options)
+	require.Error(t, err)
+}
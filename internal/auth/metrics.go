@@ -0,0 +1,25 @@
+package auth
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	TRACER_NAME = "github.com/WadeCappa/taskmaster/internal/auth"
+
+	CACHE_HIT  = "hit"
+	CACHE_MISS = "miss"
+)
+
+var (
+	cacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "taskmaster_auth_cache_lookups_total",
+		Help: "Bearer tokens looked up in the auth cache, by whether they were cached. Rejected tokens that are cached count as hits",
+	}, []string{"result"})
+	authmasterLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "taskmaster_authmaster_request_duration_seconds",
+		Help:    "How long authmaster took to check a bearer token, by grpc status code",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"code"})
+)
@@ -0,0 +1,187 @@
+package auth
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/WadeCappa/authmaster/pkg/go/authmaster/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	STUB_TOKEN_BYTES = 32
+)
+
+type stubUser struct {
+	userId UserId
+	salt   []byte
+	hash   [sha256.Size]byte
+}
+
+// An in memory stand in for authmaster, for running taskmaster locally. It
+// accepts the tokens it was seeded with and the tokens it hands out on Login.
+// Nothing survives a restart.
+type StubAuthmaster struct {
+	authmaster.UnimplementedAuthmasterServer
+
+	lock       sync.Mutex
+	tokens     map[string]UserId
+	users      map[string]stubUser
+	nextUserId UserId
+}
+
+func NewStubAuthmaster(tokens map[string]UserId) *StubAuthmaster {
+	stub := &StubAuthmaster{
+		tokens:     map[string]UserId{},
+		users:      map[string]stubUser{},
+		nextUserId: 1,
+	}
+	for token, userId := range tokens {
+		stub.tokens[token] = userId
+		// users created later never collide with the seeded ones
+		stub.nextUserId = max(stub.nextUserId, userId+1)
+	}
+	return stub
+}
+
+// Verifies tokens against the stub directly, without a grpc round trip
+func NewStaticAuth(stub *StubAuthmaster) *Auth {
+	return &Auth{
+		verify: func(ctx context.Context, token string) (UserId, error) {
+			return stub.lookup(token)
+		},
+		cache: newTokenCache(CacheOptions{}),
+	}
+}
+
+// Reads a file where each line ends with the id of a user, and everything
+// before the id is a bearer token or certificate name that belongs to them.
+// Blank lines and lines starting with # are skipped.
+func ReadUsersFile(path string) (map[string]UserId, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening users file: %w", err)
+	}
+	defer file.Close()
+
+	users := map[string]UserId{}
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		split := strings.LastIndexAny(text, " \t")
+		if split < 0 {
+			return nil, fmt.Errorf("line %d: expected a name followed by a user id", line)
+		}
+		name := strings.TrimSpace(text[:split])
+		userId, err := strconv.ParseUint(text[split+1:], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: parsing user id: %w", line, err)
+		}
+		if _, exists := users[name]; exists {
+			return nil, fmt.Errorf("line %d: %s is listed more than once", line, name)
+		}
+		users[name] = UserId(userId)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading users file: %w", err)
+	}
+	return users, nil
+}
+
+func (s *StubAuthmaster) TestAuth(
+	ctx context.Context,
+	request *authmaster.TestAuthRequest,
+) (*authmaster.TestAuthResponse, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get(AUTHORIZATION_HEADER)) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "no authorization header for request")
+	}
+	userId, err := s.lookup(md.Get(AUTHORIZATION_HEADER)[0])
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return &authmaster.TestAuthResponse{UserId: int64(userId)}, nil
+}
+
+func (s *StubAuthmaster) Login(
+	ctx context.Context,
+	request *authmaster.LoginRequest,
+) (*authmaster.LoginResponse, error) {
+	s.lock.Lock()
+	user, exists := s.users[request.GetUsername()]
+	s.lock.Unlock()
+	if !exists || subtle.ConstantTimeCompare(user.hash[:], hashPassword(user.salt, request.GetPassword())) != 1 {
+		return nil, status.Error(codes.Unauthenticated, "wrong username or password")
+	}
+
+	token, err := randomHex(STUB_TOKEN_BYTES)
+	if err != nil {
+		return nil, fmt.Errorf("generating token: %w", err)
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.tokens[token] = user.userId
+	return &authmaster.LoginResponse{Token: token}, nil
+}
+
+func (s *StubAuthmaster) CreateUser(
+	ctx context.Context,
+	request *authmaster.CreateUserRequest,
+) (*authmaster.CreateUserResponse, error) {
+	if request.GetUsername() == "" || request.GetPassword() == "" {
+		return nil, status.Error(codes.InvalidArgument, "username and password are required")
+	}
+	salt := make([]byte, sha256.Size)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("generating salt: %w", err)
+	}
+	user := stubUser{salt: salt}
+	copy(user.hash[:], hashPassword(salt, request.GetPassword()))
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, exists := s.users[request.GetUsername()]; exists {
+		return nil, status.Errorf(codes.AlreadyExists, "user %s already exists", request.GetUsername())
+	}
+	user.userId = s.nextUserId
+	s.nextUserId++
+	s.users[request.GetUsername()] = user
+	return &authmaster.CreateUserResponse{}, nil
+}
+
+func (s *StubAuthmaster) lookup(token string) (UserId, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	userId, exists := s.tokens[token]
+	if !exists {
+		return 0, &UnauthenticatedError{reason: "unknown bearer token"}
+	}
+	return userId, nil
+}
+
+func hashPassword(salt []byte, password string) []byte {
+	hash := sha256.Sum256(append(salt[:len(salt):len(salt)], password...))
+	return hash[:]
+}
+
+func randomHex(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,88 @@
+package auth_test
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/WadeCappa/authmaster/pkg/go/authmaster/v1"
+	"github.com/WadeCappa/taskmaster/internal/auth"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+func TestReadUsersFile(t *testing.T) {
+	path := writeFile(t, "tokens", []byte("# local users\nalice-token 1\n\n  bob-token\t2  \n"))
+	tokens, err := auth.ReadUsersFile(path)
+	require.NoError(t, err)
+	require.Equal(t, map[string]auth.UserId{"alice-token": 1, "bob-token": 2}, tokens)
+
+	// certificate names may have spaces in them
+	names, err := auth.ReadUsersFile(writeFile(t, "names", []byte("Alice Smith  3\nsha256:ab12 4\n")))
+	require.NoError(t, err)
+	require.Equal(t, map[string]auth.UserId{"Alice Smith": 3, "sha256:ab12": 4}, names)
+
+	for _, content := range []string{"token", "token 1 extra", "token one", "token 1\ntoken 2"} {
+		_, err := auth.ReadUsersFile(writeFile(t, "tokens", []byte(content)))
+		require.Error(t, err, content)
+	}
+	_, err = auth.ReadUsersFile(filepath.Join(t.TempDir(), "missing"))
+	require.Error(t, err)
+}
+
+func TestStaticAuthAcceptsSeededTokens(t *testing.T) {
+	a := auth.NewStaticAuth(auth.NewStubAuthmaster(map[string]auth.UserId{"alice-token": 1}))
+
+	userId, err := a.GetUserId(withToken("alice-token"))
+	require.NoError(t, err)
+	require.Equal(t, auth.UserId(1), userId)
+
+	_, err = a.GetUserId(withToken("mallory-token"))
+	var unauthenticated *auth.UnauthenticatedError
+	require.ErrorAs(t, err, &unauthenticated)
+}
+
+func TestStubAuthmasterOverGrpc(t *testing.T) {
+	stub := auth.NewStubAuthmaster(map[string]auth.UserId{"alice-token": 7})
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := grpc.NewServer()
+	authmaster.RegisterAuthmasterServer(s, stub)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	client := authmaster.NewAuthmasterClient(conn)
+	ctx := context.Background()
+
+	_, err = client.CreateUser(ctx, &authmaster.CreateUserRequest{Username: "bob", Password: "hunter2"})
+	require.NoError(t, err)
+	_, err = client.CreateUser(ctx, &authmaster.CreateUserRequest{Username: "bob", Password: "other"})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	_, err = client.Login(ctx, &authmaster.LoginRequest{Username: "bob", Password: "wrong"})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	login, err := client.Login(ctx, &authmaster.LoginRequest{Username: "bob", Password: "hunter2"})
+	require.NoError(t, err)
+
+	// the stub is a drop in authmaster, so the regular client auth works against it
+	a := auth.NewAuth(client, auth.DefaultCacheOptions())
+	userId, err := a.GetUserIdFromToken(ctx, login.GetToken())
+	require.NoError(t, err)
+	// ids for new users start after the seeded ones
+	require.Equal(t, auth.UserId(8), userId)
+
+	userId, err = a.GetUserIdFromToken(ctx, "alice-token")
+	require.NoError(t, err)
+	require.Equal(t, auth.UserId(7), userId)
+
+	_, err = a.GetUserIdFromToken(ctx, "unknown-token")
+	var unauthenticated *auth.UnauthenticatedError
+	require.ErrorAs(t, err, &unauthenticated)
+}
@@ -0,0 +1,55 @@
+package calls
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+)
+
+func Export(
+	ctx context.Context,
+	client taskspb.TasksClient,
+) ([]*taskspb.DataRecord, error) {
+	stream, err := client.ExportData(ctx, &taskspb.ExportDataRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("calling client: %w", err)
+	}
+	var records []*taskspb.DataRecord
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not receive next entry: %w", err)
+		}
+		records = append(records, res.GetRecord())
+	}
+}
+
+func Import(
+	ctx context.Context,
+	client taskspb.TasksClient,
+	records []*taskspb.DataRecord,
+) (*taskspb.ImportDataResponse, error) {
+	stream, err := client.ImportData(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("calling client: %w", err)
+	}
+	for _, r := range records {
+		if err := stream.Send(&taskspb.ImportDataRequest{Record: r}); err != nil {
+			// the server has already failed, the real error is returned by CloseAndRecv
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("sending next record: %w", err)
+		}
+	}
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return nil, fmt.Errorf("closing import stream: %w", err)
+	}
+	return resp, nil
+}
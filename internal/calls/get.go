@@ -5,19 +5,27 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/WadeCappa/taskmaster/internal/types"
 	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
 )
 
 func Get(
 	ctx context.Context,
 	client taskspb.TasksClient,
+	workspace types.Option[uint64],
+	assignment taskspb.AssignmentFilter,
 	tags []string,
 	statusId taskspb.Status,
 ) ([]*taskspb.GetTasksResponse, error) {
-	task, err := client.GetTasks(ctx, &taskspb.GetTasksRequest{
-		Status: statusId,
-		Tags:   tags,
-	})
+	request := &taskspb.GetTasksRequest{
+		Status:     statusId,
+		Tags:       tags,
+		Assignment: assignment,
+	}
+	if workspaceId, exists := workspace.Unwrap(); exists {
+		request.WorkspaceId = &workspaceId
+	}
+	task, err := client.GetTasks(ctx, request)
 	if err != nil {
 		return nil, fmt.Errorf("calling client: %w", err)
 	}
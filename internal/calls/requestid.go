@@ -0,0 +1,68 @@
+package calls
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// The server sends back the id it logged each request under in this header
+const REQUEST_ID_HEADER = "x-request-id"
+
+// Adds the request id the server sent back to errors, so that they can be
+// matched to the server's logs
+func UnaryRequestIdInterceptor(
+	ctx context.Context,
+	method string,
+	request any,
+	reply any,
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	var header metadata.MD
+	err := invoker(ctx, method, request, reply, cc, append(opts, grpc.Header(&header))...)
+	return withRequestId(header, err)
+}
+
+// Adds the request id the server sent back to errors, so that they can be
+// matched to the server's logs
+func StreamRequestIdInterceptor(
+	ctx context.Context,
+	desc *grpc.StreamDesc,
+	cc *grpc.ClientConn,
+	method string,
+	streamer grpc.Streamer,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &requestIdStream{ClientStream: stream}, nil
+}
+
+type requestIdStream struct {
+	grpc.ClientStream
+}
+
+// Errors from sending come back from RecvMsg too, so only it needs wrapping
+func (s *requestIdStream) RecvMsg(message any) error {
+	err := s.ClientStream.RecvMsg(message)
+	if err == nil || err == io.EOF {
+		return err
+	}
+	header, _ := s.Header()
+	return withRequestId(header, err)
+}
+
+// Errors keep their status, only the message is changed
+func withRequestId(header metadata.MD, err error) error {
+	if err == nil || len(header.Get(REQUEST_ID_HEADER)) == 0 {
+		return err
+	}
+	return fmt.Errorf("request %s: %w", header.Get(REQUEST_ID_HEADER)[0], err)
+}
@@ -0,0 +1,116 @@
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+)
+
+// Serves a certificate, and optionally checks client certificates, from files
+// that can be reloaded while the server is running. Every handshake uses
+// whatever was loaded last, so connections that are already open are left
+// alone by a reload.
+type Reloader struct {
+	certFile     string
+	keyFile      string
+	clientCaFile string
+	clientAuth   tls.ClientAuthType
+
+	current atomic.Pointer[tls.Config]
+}
+
+// clientCaFile may be empty, in which case client certificates are not asked
+// for. Otherwise clientAuth says whether they are required.
+func NewReloader(
+	certFile string,
+	keyFile string,
+	clientCaFile string,
+	clientAuth tls.ClientAuthType,
+) (*Reloader, error) {
+	if certFile == "" || keyFile == "" {
+		return nil, errors.New("both a certificate and a key are needed to serve tls")
+	}
+	r := &Reloader{
+		certFile:     certFile,
+		keyFile:      keyFile,
+		clientCaFile: clientCaFile,
+		clientAuth:   clientAuth,
+	}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reads the files again. When they cannot be read the previous certificates
+// stay in use.
+func (r *Reloader) Reload() error {
+	certificate, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("loading certificate: %w", err)
+	}
+	config := &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if r.clientCaFile != "" {
+		pool, err := LoadPool(r.clientCaFile)
+		if err != nil {
+			return fmt.Errorf("loading client ca: %w", err)
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = r.clientAuth
+	}
+	r.current.Store(config)
+	return nil
+}
+
+// The config to serve with, it picks up reloads on the next handshake
+func (r *Reloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return r.current.Load(), nil
+		},
+	}
+}
+
+// A config for connecting to a server. caFile replaces the system roots when
+// set, and certFile and keyFile are presented as a client certificate when set.
+func ClientConfig(caFile string, certFile string, keyFile string) (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile != "" {
+		pool, err := LoadPool(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading ca: %w", err)
+		}
+		config.RootCAs = pool
+	}
+	if (certFile == "") != (keyFile == "") {
+		return nil, errors.New("a client certificate needs both a certificate and a key")
+	}
+	if certFile != "" {
+		certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+	return config, nil
+}
+
+// Reads a bundle of one or more PEM encoded certificates
+func LoadPool(path string) (*x509.CertPool, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading certificates: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(content) {
+		return nil, fmt.Errorf("found no certificates in %s", path)
+	}
+	return pool, nil
+}
@@ -0,0 +1,194 @@
+package certs_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/WadeCappa/taskmaster/internal/certs"
+	"github.com/stretchr/testify/require"
+)
+
+type keyPair struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+}
+
+// Signs a certificate for name with parent, or self signs a CA when parent is nil
+func newKeyPair(t *testing.T, name string, parent *keyPair) keyPair {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		signer, signerKey = parent.certificate, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return keyPair{certificate: certificate, key: key}
+}
+
+// Returns the paths of the certificate and key
+func (k keyPair) write(t *testing.T, dir string, name string) (string, string) {
+	certFile := filepath.Join(dir, name+".crt")
+	keyFile := filepath.Join(dir, name+".key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: k.certificate.Raw}), 0o600))
+	der, err := x509.MarshalECPrivateKey(k.key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0o600))
+	return certFile, keyFile
+}
+
+// Runs a handshake over loopback and returns what each side saw. With TLS 1.3
+// a rejected client certificate only fails the server's side.
+func handshake(t *testing.T, server *tls.Config, client *tls.Config) (tls.ConnectionState, tls.ConnectionState, error) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer lis.Close()
+
+	type result struct {
+		state tls.ConnectionState
+		err   error
+	}
+	results := make(chan result, 1)
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			results <- result{err: err}
+			return
+		}
+		defer conn.Close()
+		serverSide := tls.Server(conn, server)
+		err = serverSide.Handshake()
+		results <- result{state: serverSide.ConnectionState(), err: err}
+	}()
+
+	conn, err := net.Dial("tcp", lis.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+	clientSide := tls.Client(conn, client)
+	clientErr := clientSide.Handshake()
+	serverResult := <-results
+	if serverResult.err != nil {
+		return tls.ConnectionState{}, tls.ConnectionState{}, serverResult.err
+	}
+	return serverResult.state, clientSide.ConnectionState(), clientErr
+}
+
+func TestMutualTls(t *testing.T) {
+	dir := t.TempDir()
+	ca := newKeyPair(t, "ca", nil)
+	caFile, _ := ca.write(t, dir, "ca")
+	serverCert, serverKey := newKeyPair(t, "localhost", &ca).write(t, dir, "server")
+	clientCert, clientKey := newKeyPair(t, "alice", &ca).write(t, dir, "client")
+
+	reloader, err := certs.NewReloader(serverCert, serverKey, caFile, tls.VerifyClientCertIfGiven)
+	require.NoError(t, err)
+
+	client, err := certs.ClientConfig(caFile, clientCert, clientKey)
+	require.NoError(t, err)
+	client.ServerName = "localhost"
+	serverState, _, err := handshake(t, reloader.TLSConfig(), client)
+	require.NoError(t, err)
+	require.Len(t, serverState.VerifiedChains, 1)
+	require.Equal(t, "alice", serverState.VerifiedChains[0][0].Subject.CommonName)
+
+	// a client certificate is optional unless it is required
+	anonymous, err := certs.ClientConfig(caFile, "", "")
+	require.NoError(t, err)
+	anonymous.ServerName = "localhost"
+	serverState, _, err = handshake(t, reloader.TLSConfig(), anonymous)
+	require.NoError(t, err)
+	require.Empty(t, serverState.VerifiedChains)
+
+	required, err := certs.NewReloader(serverCert, serverKey, caFile, tls.RequireAndVerifyClientCert)
+	require.NoError(t, err)
+	_, _, err = handshake(t, required.TLSConfig(), anonymous)
+	require.Error(t, err)
+
+	// certificates from another CA are never verified
+	other := newKeyPair(t, "other-ca", nil)
+	otherCert, otherKey := newKeyPair(t, "mallory", &other).write(t, dir, "mallory")
+	mallory, err := certs.ClientConfig(caFile, otherCert, otherKey)
+	require.NoError(t, err)
+	mallory.ServerName = "localhost"
+	serverState, _, err = handshake(t, reloader.TLSConfig(), mallory)
+	require.NoError(t, err)
+	require.Empty(t, serverState.VerifiedChains)
+	_, _, err = handshake(t, required.TLSConfig(), mallory)
+	require.Error(t, err)
+}
+
+func TestReloadServesNewCertificates(t *testing.T) {
+	dir := t.TempDir()
+	ca := newKeyPair(t, "ca", nil)
+	caFile, _ := ca.write(t, dir, "ca")
+	first := newKeyPair(t, "localhost", &ca)
+	serverCert, serverKey := first.write(t, dir, "server")
+
+	reloader, err := certs.NewReloader(serverCert, serverKey, "", tls.NoClientCert)
+	require.NoError(t, err)
+	serverConfig := reloader.TLSConfig()
+	client, err := certs.ClientConfig(caFile, "", "")
+	require.NoError(t, err)
+	client.ServerName = "localhost"
+
+	_, clientState, err := handshake(t, serverConfig, client)
+	require.NoError(t, err)
+	require.Equal(t, first.certificate.SerialNumber, clientState.PeerCertificates[0].SerialNumber)
+
+	second := newKeyPair(t, "localhost", &ca)
+	second.write(t, dir, "server")
+	require.NoError(t, reloader.Reload())
+	_, clientState, err = handshake(t, serverConfig, client)
+	require.NoError(t, err)
+	require.Equal(t, second.certificate.SerialNumber, clientState.PeerCertificates[0].SerialNumber)
+
+	// a broken file leaves the last good certificate in place
+	require.NoError(t, os.WriteFile(serverKey, []byte("garbage"), 0o600))
+	require.Error(t, reloader.Reload())
+	_, clientState, err = handshake(t, serverConfig, client)
+	require.NoError(t, err)
+	require.Equal(t, second.certificate.SerialNumber, clientState.PeerCertificates[0].SerialNumber)
+}
+
+func TestBadCertificateFiles(t *testing.T) {
+	dir := t.TempDir()
+	empty := filepath.Join(dir, "empty.pem")
+	require.NoError(t, os.WriteFile(empty, nil, 0o600))
+
+	_, err := certs.NewReloader("", "", "", tls.NoClientCert)
+	require.Error(t, err)
+	_, err = certs.NewReloader(filepath.Join(dir, "missing.crt"), filepath.Join(dir, "missing.key"), "", tls.NoClientCert)
+	require.Error(t, err)
+	_, err = certs.ClientConfig(empty, "", "")
+	require.Error(t, err)
+	_, err = certs.ClientConfig("", "client.crt", "")
+	require.Error(t, err)
+}
@@ -0,0 +1,102 @@
+package database
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/WadeCappa/taskmaster/internal/types"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type AccessTokenId uint64
+
+type AccessToken struct {
+	name        string
+	scopes      []taskspb.Scope
+	expires     types.Option[time.Time]
+	createdTime time.Time
+}
+
+func AccessTokenFromWireType(request *taskspb.CreateAccessTokenRequest, now time.Time) (AccessToken, error) {
+	var violations []FieldViolation
+	if request.GetName() == "" {
+		violations = append(violations, FieldViolation{"name", "access tokens need a name"})
+	}
+	if len(request.GetScopes()) == 0 {
+		violations = append(violations, FieldViolation{"scopes", "access tokens need at least one scope"})
+	}
+	var scopes []taskspb.Scope
+	for i, s := range request.GetScopes() {
+		if _, exists := taskspb.Scope_name[int32(s)]; !exists {
+			violations = append(violations, FieldViolation{
+				fmt.Sprintf("scopes[%d]", i),
+				fmt.Sprintf("unrecognized scope of %d", s),
+			})
+		}
+		if !slices.Contains(scopes, s) {
+			scopes = append(scopes, s)
+		}
+	}
+	expires := types.None[time.Time]()
+	if request.Expires != nil {
+		if !request.GetExpires().AsTime().After(now) {
+			violations = append(violations, FieldViolation{"expires", "access tokens must expire in the future"})
+		}
+		expires = types.Some(request.GetExpires().AsTime())
+	}
+
+	if len(violations) > 0 {
+		return AccessToken{}, &InvalidArgumentError{Violations: violations}
+	}
+	return AccessToken{
+		name:    request.GetName(),
+		scopes:  scopes,
+		expires: expires,
+	}, nil
+}
+
+func (a *AccessToken) ToWireType(tokenId AccessTokenId) *taskspb.ListAccessTokensResponse {
+	response := &taskspb.ListAccessTokensResponse{
+		TokenId:     uint64(tokenId),
+		Name:        a.name,
+		Scopes:      a.scopes,
+		TimeCreated: timestamppb.New(a.createdTime),
+	}
+	if expires, exists := a.expires.Unwrap(); exists {
+		response.Expires = timestamppb.New(expires)
+	}
+	return response
+}
+
+func scopesToRow(scopes []taskspb.Scope) []int32 {
+	row := make([]int32, len(scopes))
+	for i, s := range scopes {
+		row[i] = int32(s)
+	}
+	return row
+}
+
+// Never nil, even for an empty row, since a nil auth.Caller.Scopes is a login session
+func scopesFromRow(row []int32) []taskspb.Scope {
+	scopes := make([]taskspb.Scope, len(row))
+	for i, s := range row {
+		scopes[i] = taskspb.Scope(s)
+	}
+	return scopes
+}
+
+func expiresToRow(expires types.Option[time.Time]) *time.Time {
+	if t, exists := expires.Unwrap(); exists {
+		return &t
+	}
+	return nil
+}
+
+func expiresFromRow(row *time.Time) types.Option[time.Time] {
+	if row == nil {
+		return types.None[time.Time]()
+	}
+	return types.Some(*row)
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"time"
 
+	"github.com/WadeCappa/taskmaster/internal/auth"
 	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -10,12 +11,26 @@ import (
 type Addendum struct {
 	created time.Time
 	content string
+	// never read from the wire, addendums are always written by the caller
+	author auth.UserId
 }
 
-func NewAddendum(created time.Time, content string) Addendum {
+func NewAddendum(created time.Time, content string, author auth.UserId) Addendum {
 	return Addendum{
 		created: created,
 		content: content,
+		author:  author,
+	}
+}
+
+func AddendumFromWireType(wire *taskspb.Addendum) Addendum {
+	created := time.Now()
+	if wire.GetTimeCreated() != nil {
+		created = wire.GetTimeCreated().AsTime()
+	}
+	return Addendum{
+		created: created,
+		content: wire.GetContent(),
 	}
 }
 
@@ -23,5 +38,6 @@ func (a *Addendum) ToWireType() *taskspb.Addendum {
 	return &taskspb.Addendum{
 		Content:     a.content,
 		TimeCreated: timestamppb.New(a.created),
+		AuthorId:    uint64(a.author),
 	}
 }
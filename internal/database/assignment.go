@@ -0,0 +1,69 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/WadeCappa/taskmaster/internal/auth"
+	"github.com/WadeCappa/taskmaster/internal/types"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+)
+
+// Which tasks Get returns, relative to the user asking for them
+type Assignment int
+
+const (
+	AllTasks Assignment = iota
+	AssignedToMe
+	CreatedByMe
+	Unassigned
+)
+
+func AssignmentFromWire(assignment taskspb.AssignmentFilter) (Assignment, error) {
+	switch assignment {
+	case taskspb.AssignmentFilter_ALL_TASKS:
+		return AllTasks, nil
+	case taskspb.AssignmentFilter_ASSIGNED_TO_ME:
+		return AssignedToMe, nil
+	case taskspb.AssignmentFilter_CREATED_BY_ME:
+		return CreatedByMe, nil
+	case taskspb.AssignmentFilter_UNASSIGNED:
+		return Unassigned, nil
+	}
+	return AllTasks, fmt.Errorf("unrecognized assignment filter of %d", assignment.Number())
+}
+
+func AssigneeFromWire(assignee *uint64) types.Option[auth.UserId] {
+	if assignee == nil {
+		return types.None[auth.UserId]()
+	}
+	return types.Some(auth.UserId(*assignee))
+}
+
+func assigneeToRow(assignee types.Option[auth.UserId]) *uint64 {
+	if userId, exists := assignee.Unwrap(); exists {
+		asUint := uint64(userId)
+		return &asUint
+	}
+	return nil
+}
+
+func assigneeFromRow(row *uint64) types.Option[auth.UserId] {
+	if row == nil {
+		return types.None[auth.UserId]()
+	}
+	return types.Some(auth.UserId(*row))
+}
+
+// The addendum that records a change of assignee
+func assignmentNote(previous types.Option[auth.UserId], next types.Option[auth.UserId]) string {
+	previousId, wasAssigned := previous.Unwrap()
+	nextId, isAssigned := next.Unwrap()
+	switch {
+	case wasAssigned && isAssigned:
+		return fmt.Sprintf("reassigned from user %d to user %d", previousId, nextId)
+	case isAssigned:
+		return fmt.Sprintf("assigned to user %d", nextId)
+	default:
+		return fmt.Sprintf("unassigned from user %d", previousId)
+	}
+}
@@ -2,39 +2,162 @@ package database
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/WadeCappa/taskmaster/internal/auth"
 	"github.com/WadeCappa/taskmaster/internal/store"
 	"github.com/WadeCappa/taskmaster/internal/types"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 const (
-	insertTaskQuery              = "insert into tasks (task_id, user_id, fields, priority, status) values (nextval('task_ids'), $1, $2, $3, $4) returning task_id"
-	getTasksWithTags             = "select t.task_id, t.fields, t.priority, t.status from tasks t join tags_to_tasks ttt on t.task_id = ttt.task_id join tags tg on tg.tag_id = ttt.tag_id where t.user_id = $1 and tg.name = any ($2) and t.status = $3 group by t.task_id having count(distinct tg.tag_id) = cardinality($2) order by priority;"
-	getTasksWithIgnoringTags     = "select t.task_id, t.fields, t.priority, t.status from tasks t join tags_to_tasks ttt on t.task_id = ttt.task_id join tags tg on tg.tag_id = ttt.tag_id where t.user_id = $1 and t.status = $2 group by t.task_id order by priority"
-	describeTask                 = "select t.fields, t.priority, t.status from tasks t where t.task_id = $1 and t.user_id = $2"
+	insertTaskQuery              = "insert into tasks (task_id, user_id, fields, priority, status, workspace_id) values (nextval('task_ids'), $1, $2, $3, $4, $5) returning task_id"
+	getTasksWithTags             = "select t.task_id, t.fields, t.priority, t.status, t.user_id, max(a.role), t.assignee_id from tasks t join task_access a on a.task_id = t.task_id join tags_to_tasks ttt on t.task_id = ttt.task_id join tags tg on tg.tag_id = ttt.tag_id where a.user_id = $1 and tg.name = any ($2) and t.status = $3 and a.workspace_id is not distinct from $4 and case $5::smallint when 1 then t.assignee_id = $1 when 2 then t.user_id = $1 when 3 then t.assignee_id is null else true end group by t.task_id having count(distinct tg.tag_id) = cardinality($2) order by priority;"
+	getTasksWithIgnoringTags     = "select t.task_id, t.fields, t.priority, t.status, t.user_id, max(a.role), t.assignee_id from tasks t join task_access a on a.task_id = t.task_id join tags_to_tasks ttt on t.task_id = ttt.task_id join tags tg on tg.tag_id = ttt.tag_id where a.user_id = $1 and t.status = $2 and a.workspace_id is not distinct from $3 and case $4::smallint when 1 then t.assignee_id = $1 when 2 then t.user_id = $1 when 3 then t.assignee_id is null else true end group by t.task_id order by priority"
+	describeTask                 = "select t.fields, t.priority, t.status, t.user_id, max(a.role), t.assignee_id from tasks t join task_access a on a.task_id = t.task_id where t.task_id = $1 and a.user_id = $2 group by t.task_id"
 	getTagsForTasksQuery         = "select distinct tg.tag_id, ttt.task_id, tg.name from tags tg join tags_to_tasks ttt on tg.tag_id = ttt.tag_id where ttt.task_id = any($1)"
 	getNumberOfAddendumsForTasks = "select t.task_id, count(a.addendum_id) from tasks t left join addendums a on a.task_id = t.task_id where t.task_id = any($1) group by t.task_id"
-	getAddendumsForTasksQuery    = "select a.task_id, a.content, a.write_time from addendums a where a.task_id = any($1) order by a.write_time"
-	getTagsFromString            = "select tg.tag_id, tg.name from tags tg where tg.name = any ($1)"
-	insertTag                    = "insert into tags (user_id, tag_id, write_time, name) values ($1, nextval('tag_ids'), now(), $2) returning tag_id, name"
+	getAddendumsForTasksQuery    = "select a.task_id, a.content, a.write_time, a.user_id from addendums a where a.task_id = any($1) order by a.write_time"
+	getTagsFromString            = "select tg.tag_id, tg.name from tags tg where tg.name = any ($1) and tg.workspace_id is not distinct from $2 and (tg.workspace_id is not null or tg.user_id = $3)"
+	insertTag                    = "insert into tags (user_id, tag_id, write_time, name, workspace_id) values ($1, nextval('tag_ids'), now(), $2, $3) returning tag_id, name"
 	insertTagsToTasks            = "insert into tags_to_tasks (task_id, tag_id) values ($1, $2)"
-	insertAddundum               = "insert into addendums (addendum_id, user_id, task_id, content, write_time) values (nextval('addendum_ids'), $1, $2, $3, now())"
-	getTags                      = "select tg.tag_id, tg.name, tg.write_time, count(ttt.task_id) from tags tg left join tags_to_tasks ttt on tg.tag_id = ttt.tag_id where tg.user_id = $1 group by tg.tag_id order by tg.tag_id"
-	setStatus                    = "update tasks set status = $1 where task_id = $2 and user_id = $3"
+	insertAddundum               = "insert into addendums (addendum_id, user_id, task_id, content, write_time) select nextval('addendum_ids'), $1, $2, $3, now() where exists (select 1 from task_access a where a.task_id = $2 and a.user_id = $1 and a.role >= $4)"
+	getTags                      = "select tg.tag_id, tg.name, tg.write_time, count(ttt.task_id) from tags tg left join tags_to_tasks ttt on tg.tag_id = ttt.tag_id where tg.user_id = $1 and tg.workspace_id is null group by tg.tag_id order by tg.tag_id"
+	getWorkspaceTags             = "select tg.tag_id, tg.name, tg.write_time, count(ttt.task_id) from tags tg left join tags_to_tasks ttt on tg.tag_id = ttt.tag_id where tg.workspace_id = $1 group by tg.tag_id order by tg.tag_id"
+	setStatus                    = "update tasks t set status = $1 where t.task_id = $2 and exists (select 1 from task_access a where a.task_id = t.task_id and a.user_id = $3 and a.role >= $4)"
+	getAllTasks                  = "select t.task_id, t.fields, t.priority, t.status from tasks t where t.user_id = $1 and t.workspace_id is null order by t.task_id"
+	reserveTaskIds               = "select nextval('task_ids') from generate_series(1, $1::int)"
+	insertTaskWithIdQuery        = "insert into tasks (task_id, user_id, fields, priority, status) values ($1, $2, $3, $4, $5)"
+	insertAddundumWithTime       = "insert into addendums (addendum_id, user_id, task_id, content, write_time) values (nextval('addendum_ids'), $1, $2, $3, $4)"
+	getTaskFieldsForUpdate       = "select t.fields, t.workspace_id from tasks t where t.task_id = $1 and exists (select 1 from task_access a where a.task_id = t.task_id and a.user_id = $2 and (a.role >= $3 or (a.workspace_id is not null and a.role >= $4))) for update of t"
+	updateTask                   = "update tasks set fields = $1, priority = $2, status = $3 where task_id = $4"
+	deleteTagsToTasks            = "delete from tags_to_tasks where task_id = $1"
+	getTaskOwner                 = "select t.user_id from tasks t where t.task_id = $1"
+	getAssigneeForUpdate         = "select t.assignee_id from tasks t where t.task_id = $1 and exists (select 1 from task_access a where a.task_id = t.task_id and a.user_id = $2 and (a.role >= $3 or (a.workspace_id is not null and a.role >= $4))) for update of t"
+	updateAssignee               = "update tasks set assignee_id = $1 where task_id = $2"
+	getUsage                     = "select (select count(*) from tasks t where t.user_id = $1), (select count(*) from tags tg where tg.user_id = $1), (select coalesce(sum(octet_length(a.content)), 0) from addendums a where a.user_id = $1)"
+	hasTaskRole                  = "select exists (select 1 from task_access a where a.task_id = $1 and a.user_id = $2 and a.role >= $3)"
+	getWebhookOwner              = "select w.user_id from webhooks w where w.webhook_id = $1"
+	insertWebhook                = "insert into webhooks (webhook_id, user_id, url, secret, filters, write_time) values (nextval('webhook_ids'), $1, $2, $3, $4, now()) returning webhook_id"
+	getWebhooks                  = "select w.webhook_id, w.url, w.filters, w.write_time from webhooks w where w.user_id = $1 order by w.webhook_id"
+	deleteWebhook                = "delete from webhooks where webhook_id = $1 and user_id = $2"
+	insertDelivery               = "insert into webhook_deliveries (delivery_id, webhook_id, user_id, payload, state, attempts, next_attempt, last_error, last_response_code, write_time) values (nextval('delivery_ids'), $1, $2, $3, $4, 0, now(), '', 0, now())"
+	claimWebhookEvents           = "select e.event_id, e.type, e.task_id, e.previous_status, e.user_ids, e.fields, e.priority, e.status, e.assignee_id, e.tags, e.number_of_addendums, e.addendum_author, e.addendum_content, e.addendum_time from webhook_events e order by e.event_id limit $1 for update skip locked"
+	getWebhooksForUsers          = "select w.webhook_id, w.user_id, w.filters from webhooks w where w.user_id = any($1)"
+	deleteWebhookEvents          = "delete from webhook_events where event_id = any($1)"
+	claimDeliveries              = "update webhook_deliveries d set next_attempt = $2 from webhooks w where w.webhook_id = d.webhook_id and d.delivery_id in (select delivery_id from webhook_deliveries where state = $3 and next_attempt <= now() order by next_attempt limit $1 for update skip locked) returning d.delivery_id, w.url, w.secret, d.payload, d.attempts"
+	finishDeliveryAttempt        = "update webhook_deliveries set state = $1, attempts = attempts + 1, next_attempt = $2, last_response_code = $3, last_error = $4 where delivery_id = $5"
+	getDeliveries                = "select d.delivery_id, d.webhook_id, d.state, d.payload, d.attempts, d.next_attempt, d.last_error, d.last_response_code, d.write_time from webhook_deliveries d where d.user_id = $1 and ($2::bigint is null or d.webhook_id = $2) order by d.delivery_id desc limit $3"
+	getAccessTokenOwner          = "select a.user_id from access_tokens a where a.token_id = $1"
+	insertAccessToken            = "insert into access_tokens (token_id, user_id, name, token_hash, scopes, expires, write_time) values (nextval('access_token_ids'), $1, $2, $3, $4, $5, now()) returning token_id"
+	getAccessTokens              = "select a.token_id, a.name, a.scopes, a.expires, a.write_time from access_tokens a where a.user_id = $1 order by a.token_id"
+	deleteAccessToken            = "delete from access_tokens where token_id = $1 and user_id = $2"
+	lookupAccessToken            = "select a.user_id, a.scopes from access_tokens a where a.token_hash = $1 and (a.expires is null or a.expires > now())"
+	insertTaskShare              = "insert into task_shares (task_id, user_id, role, write_time) select $1, $2, $3, now() where exists (select 1 from task_access a where a.task_id = $1 and a.user_id = $4 and a.role >= $5) on conflict (task_id, user_id) do update set role = excluded.role"
+	insertTagShare               = "insert into tag_shares (tag_id, owner_id, user_id, role, write_time) select tg.tag_id, $2, $3, $4, now() from tags tg where tg.name = $1 and tg.user_id = $2 and tg.workspace_id is null on conflict (tag_id, owner_id, user_id) do update set role = excluded.role"
+	getShares                    = "select ts.task_id, null::varchar, ts.user_id, ts.role, ts.write_time from task_shares ts join tasks t on t.task_id = ts.task_id where t.user_id = $1 union all select null::bigint, tg.name, s.user_id, s.role, s.write_time from tag_shares s join tags tg on tg.tag_id = s.tag_id where s.owner_id = $1 order by 5"
+	deleteTaskShare              = "delete from task_shares ts where ts.task_id = $1 and ts.user_id = $2 and exists (select 1 from task_access a where a.task_id = $1 and a.user_id = $3 and a.role >= $4)"
+	deleteTagShare               = "delete from tag_shares s using tags tg where tg.tag_id = s.tag_id and tg.name = $1 and tg.workspace_id is null and s.user_id = $2 and s.owner_id = $3"
+	insertWorkspace              = "insert into workspaces (workspace_id, name, invite_code, write_time) values (nextval('workspace_ids'), $1, $2, now()) returning workspace_id"
+	insertWorkspaceMember        = "insert into workspace_members (workspace_id, user_id, role, write_time) values ($1, $2, $3, now()) on conflict (workspace_id, user_id) do nothing"
+	getWorkspaces                = "select w.workspace_id, w.name, w.invite_code, m.role, m.write_time from workspaces w join workspace_members m on m.workspace_id = w.workspace_id where m.user_id = $1 order by w.workspace_id"
+	getWorkspaceByInvite         = "select w.workspace_id from workspaces w where w.invite_code = $1"
+	getWorkspace                 = "select w.workspace_id from workspaces w where w.workspace_id = $1"
+	lockWorkspace                = "select w.workspace_id from workspaces w where w.workspace_id = $1 for update"
+	getWorkspaceRole             = "select m.role from workspace_members m where m.workspace_id = $1 and m.user_id = $2"
+	countOtherOwners             = "select count(*) from workspace_members m where m.workspace_id = $1 and m.user_id != $2 and m.role = $3"
+	getWorkspaceMembers          = "select m.user_id, m.role, m.write_time from workspace_members m where m.workspace_id = $1 order by m.write_time"
+	updateWorkspaceRole          = "update workspace_members set role = $1 where workspace_id = $2 and user_id = $3"
+	deleteWorkspaceMember        = "delete from workspace_members where workspace_id = $1 and user_id = $2"
 )
 
+// times every query under the name of its constant
+func init() {
+	store.NameQueries(map[string]string{
+		insertTaskQuery:              "insertTaskQuery",
+		getTasksWithTags:             "getTasksWithTags",
+		getTasksWithIgnoringTags:     "getTasksWithIgnoringTags",
+		describeTask:                 "describeTask",
+		getTagsForTasksQuery:         "getTagsForTasksQuery",
+		getNumberOfAddendumsForTasks: "getNumberOfAddendumsForTasks",
+		getAddendumsForTasksQuery:    "getAddendumsForTasksQuery",
+		getTagsFromString:            "getTagsFromString",
+		insertTag:                    "insertTag",
+		insertTagsToTasks:            "insertTagsToTasks",
+		insertAddundum:               "insertAddundum",
+		getTags:                      "getTags",
+		getWorkspaceTags:             "getWorkspaceTags",
+		setStatus:                    "setStatus",
+		getAllTasks:                  "getAllTasks",
+		reserveTaskIds:               "reserveTaskIds",
+		insertTaskWithIdQuery:        "insertTaskWithIdQuery",
+		insertAddundumWithTime:       "insertAddundumWithTime",
+		getTaskFieldsForUpdate:       "getTaskFieldsForUpdate",
+		updateTask:                   "updateTask",
+		deleteTagsToTasks:            "deleteTagsToTasks",
+		getTaskOwner:                 "getTaskOwner",
+		getAssigneeForUpdate:         "getAssigneeForUpdate",
+		updateAssignee:               "updateAssignee",
+		getUsage:                     "getUsage",
+		hasTaskRole:                  "hasTaskRole",
+		getWebhookOwner:              "getWebhookOwner",
+		insertWebhook:                "insertWebhook",
+		getWebhooks:                  "getWebhooks",
+		deleteWebhook:                "deleteWebhook",
+		insertDelivery:               "insertDelivery",
+		claimWebhookEvents:           "claimWebhookEvents",
+		getWebhooksForUsers:          "getWebhooksForUsers",
+		deleteWebhookEvents:          "deleteWebhookEvents",
+		claimDeliveries:              "claimDeliveries",
+		finishDeliveryAttempt:        "finishDeliveryAttempt",
+		getDeliveries:                "getDeliveries",
+		getAccessTokenOwner:          "getAccessTokenOwner",
+		insertAccessToken:            "insertAccessToken",
+		getAccessTokens:              "getAccessTokens",
+		deleteAccessToken:            "deleteAccessToken",
+		lookupAccessToken:            "lookupAccessToken",
+		insertTaskShare:              "insertTaskShare",
+		insertTagShare:               "insertTagShare",
+		getShares:                    "getShares",
+		deleteTaskShare:              "deleteTaskShare",
+		deleteTagShare:               "deleteTagShare",
+		insertWorkspace:              "insertWorkspace",
+		insertWorkspaceMember:        "insertWorkspaceMember",
+		getWorkspaces:                "getWorkspaces",
+		getWorkspaceByInvite:         "getWorkspaceByInvite",
+		getWorkspace:                 "getWorkspace",
+		lockWorkspace:                "lockWorkspace",
+		getWorkspaceRole:             "getWorkspaceRole",
+		countOtherOwners:             "countOtherOwners",
+		getWorkspaceMembers:          "getWorkspaceMembers",
+		updateWorkspaceRole:          "updateWorkspaceRole",
+		deleteWorkspaceMember:        "deleteWorkspaceMember",
+	})
+}
+
+// satisfied by both pgx.Conn and pgx.Tx
+type querier interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults
+}
+
 type Database struct {
 	psqlUrl string
+	quotas  Quotas
 }
 
 type TaskAttributes struct {
 	Name           string        `json:"name"`
 	TimeToComplete time.Duration `json:"timeToComplete"`
 	CreatedTime    time.Time     `json:"createdTime"`
+	Prerequisites  []TaskId      `json:"prerequisites,omitempty"`
+	DueTime        time.Time     `json:"dueTime,omitzero"`
 }
 
 func NewDatabase(psqlUrl string) *Database {
@@ -43,6 +166,19 @@ func NewDatabase(psqlUrl string) *Database {
 	}
 }
 
+// Refuses writes that would take a user past quotas
+func (e *Database) EnforceQuotas(quotas Quotas) *Database {
+	e.quotas = quotas
+	return e
+}
+
+// Fails when postgres cannot be reached
+func (e *Database) Ping(ctx context.Context) error {
+	return store.Call(ctx, e.psqlUrl, func(conn *pgx.Conn) error {
+		return conn.Ping(ctx)
+	})
+}
+
 func (e *Database) Describe(
 	ctx context.Context,
 	userId auth.UserId,
@@ -52,12 +188,20 @@ func (e *Database) Describe(
 		var fields TaskAttributes
 		var priority int
 		var status int
-		err := c.QueryRow(ctx, describeTask, taskId, userId).Scan(&fields, &priority, &status)
+		var owner uint64
+		var role int
+		var assignee *uint64
+		err := c.QueryRow(ctx, describeTask, taskId, userId).Scan(&fields, &priority, &status, &owner, &role, &assignee)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, missingTask(ctx, c, userId, taskId)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("getting task row for describe: %w", err)
 		}
 
 		describedTask := TaskFromDb(fields, Priority(priority), Status(status))
+		describedTask.access = Access{Owner: auth.UserId(owner), Role: Role(role)}
+		describedTask.assignee = assigneeFromRow(assignee)
 		if err := getTagsForTasks(ctx, c, map[TaskId]*Task{taskId: &describedTask}); err != nil {
 			return nil, fmt.Errorf("getting tags for tasks: %w", err)
 		}
@@ -76,20 +220,28 @@ func (e *Database) Describe(
 	return *res, nil
 }
 
+// Returns the tasks in a workspace, or when workspace is None the tasks in the
+// user's personal workspace along with the tasks that have been shared with them.
+// Tasks are further narrowed down by who created or is assigned them.
 func (e *Database) Get(
 	ctx context.Context,
 	userId auth.UserId,
+	workspace types.Option[WorkspaceId],
+	assignment Assignment,
 	status Status,
 	tags ...Tag,
 ) ([]types.Pair[TaskId, Task], error) {
 	var res []types.Pair[TaskId, Task]
 	if err := store.Call(ctx, e.psqlUrl, func(c *pgx.Conn) error {
+		if err := requireWorkspaceRole(ctx, c, userId, workspace, WorkspaceViewer); err != nil {
+			return err
+		}
 		var rows pgx.Rows
 		var err error
 		if len(tags) > 0 {
-			rows, err = c.Query(ctx, getTasksWithTags, userId, tags, status)
+			rows, err = c.Query(ctx, getTasksWithTags, userId, tags, status, workspaceToRow(workspace), assignment)
 		} else {
-			rows, err = c.Query(ctx, getTasksWithIgnoringTags, userId, status)
+			rows, err = c.Query(ctx, getTasksWithIgnoringTags, userId, status, workspaceToRow(workspace), assignment)
 		}
 
 		if err != nil {
@@ -103,10 +255,15 @@ func (e *Database) Get(
 			var fields TaskAttributes
 			var priority int
 			var status int
-			if err := rows.Scan(&taskId, &fields, &priority, &status); err != nil {
+			var owner uint64
+			var role int
+			var assignee *uint64
+			if err := rows.Scan(&taskId, &fields, &priority, &status, &owner, &role, &assignee); err != nil {
 				return fmt.Errorf("scanning next tag: %w", err)
 			}
 			task := TaskFromDb(fields, Priority(priority), Status(status))
+			task.access = Access{Owner: auth.UserId(owner), Role: Role(role)}
+			task.assignee = assigneeFromRow(assignee)
 			res = append(res, types.Of(TaskId(taskId), task))
 			lookup[TaskId(taskId)] = &task
 		}
@@ -124,6 +281,8 @@ func (e *Database) Get(
 	return res, nil
 }
 
+// Editors of a task can mark it as well as its owner, the addendum records
+// which of them wrote it.
 func (e *Database) Mark(
 	ctx context.Context,
 	userId auth.UserId,
@@ -131,9 +290,16 @@ func (e *Database) Mark(
 	content string,
 ) error {
 	if err := store.Call(ctx, e.psqlUrl, func(c *pgx.Conn) error {
-		if _, err := c.Exec(ctx, insertAddundum, userId, taskId, content); err != nil {
+		if err := e.quotas.check(ctx, c, userId, Usage{AddendumBytes: uint64(len(content))}); err != nil {
+			return err
+		}
+		tag, err := c.Exec(ctx, insertAddundum, userId, taskId, content, Editor)
+		if err != nil {
 			return fmt.Errorf("putting new addendum into db: %w", err)
 		}
+		if tag.RowsAffected() == 0 {
+			return missingTask(ctx, c, userId, taskId)
+		}
 		return nil
 	}); err != nil {
 		return fmt.Errorf("writing addendum: %w", err)
@@ -141,77 +307,112 @@ func (e *Database) Mark(
 	return nil
 }
 
+// Editors of a task can assign it to anyone who can see it. The change is
+// recorded as an addendum written by userId, assigning a task to whoever it
+// is already assigned to does nothing.
+func (e *Database) Assign(
+	ctx context.Context,
+	userId auth.UserId,
+	taskId TaskId,
+	assignee types.Option[auth.UserId],
+) error {
+	if err := store.Call(ctx, e.psqlUrl, func(c *pgx.Conn) error {
+		tx, err := c.Begin(ctx)
+		if err != nil {
+			return fmt.Errorf("starting transaction: %w", err)
+		}
+		defer tx.Rollback(ctx)
+
+		var previous *uint64
+		if err := tx.QueryRow(ctx, getAssigneeForUpdate, taskId, userId, Editor).Scan(&previous); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return missingTask(ctx, tx, userId, taskId)
+			}
+			return fmt.Errorf("getting existing assignee: %w", err)
+		}
+		if assigneeFromRow(previous) == assignee {
+			return nil
+		}
+		if assigneeId, exists := assignee.Unwrap(); exists {
+			var canView bool
+			if err := tx.QueryRow(ctx, hasTaskRole, taskId, assigneeId, Viewer).Scan(&canView); err != nil {
+				return fmt.Errorf("checking that the assignee can see the task: %w", err)
+			}
+			if !canView {
+				return NewInvalidArgumentError("assignee_user_id", fmt.Sprintf("user %d cannot see task %d", assigneeId, taskId))
+			}
+		}
+
+		if _, err := tx.Exec(ctx, updateAssignee, assigneeToRow(assignee), taskId); err != nil {
+			return fmt.Errorf("updating assignee: %w", err)
+		}
+		if _, err := tx.Exec(ctx, insertAddundumWithTime, userId, taskId, assignmentNote(assigneeFromRow(previous), assignee), time.Now()); err != nil {
+			return fmt.Errorf("recording assignment: %w", err)
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return fmt.Errorf("committing assignment: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("assigning task: %w", err)
+	}
+	return nil
+}
+
+// Creates a new task in workspace, or replaces the task with taskId if one is
+// provided. A replaced task keeps its creation time, addendums and workspace.
 func (e *Database) Put(
 	ctx context.Context,
 	userId auth.UserId,
+	workspace types.Option[WorkspaceId],
+	taskId types.Option[TaskId],
 	task Task,
 ) (TaskId, error) {
+	if existingId, exists := taskId.Unwrap(); exists {
+		if err := e.update(ctx, userId, existingId, task); err != nil {
+			return 0, fmt.Errorf("updating task %d: %w", existingId, err)
+		}
+		return existingId, nil
+	}
+
 	newTaskId, err := store.CallAndReturn(ctx, e.psqlUrl, func(c *pgx.Conn) (*uint64, error) {
-		rows, err := c.Query(ctx, getTagsFromString, task.tags)
+		// watchers are notified when the task is inserted, so its tags need to
+		// be committed at the same time
+		tx, err := c.Begin(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("getting tags from names on task: %w", err)
-		}
-		seen := map[Tag]uint64{}
-		for rows.Next() {
-			var tagId uint64
-			var name string
-			if err := rows.Scan(&tagId, &name); err != nil {
-				rows.Close()
-				return nil, fmt.Errorf("scanning next tag: %w", err)
-			}
-			seen[Tag(name)] = tagId
+			return nil, fmt.Errorf("starting transaction: %w", err)
 		}
-		rows.Close()
+		defer tx.Rollback(ctx)
 
-		batch := &pgx.Batch{}
-		for _, t := range task.tags {
-			if _, exists := seen[t]; exists {
-				continue
-			}
-			batch.Queue(insertTag, userId, t)
+		if err := requireWorkspaceRole(ctx, tx, userId, workspace, WorkspaceMember); err != nil {
+			return nil, err
 		}
-		if batch.Len() > 0 {
-			batchResult := c.SendBatch(ctx, batch)
-			for i := 0; i < batch.Len(); i++ {
-				var tagId uint64
-				var name string
-				err := batchResult.QueryRow().Scan(&tagId, &name)
-				if err != nil {
-					batchResult.Close()
-					return nil, fmt.Errorf("executing batch tag insert: %w", err)
-				}
-				seen[Tag(name)] = tagId
-			}
-			batchResult.Close()
+		if err := e.quotas.check(ctx, tx, userId, Usage{Tasks: 1}); err != nil {
+			return nil, err
+		}
+		tagIds, err := getOrCreateTags(ctx, tx, e.quotas, userId, workspace, task.tags)
+		if err != nil {
+			return nil, fmt.Errorf("getting tag ids for task: %w", err)
 		}
 
 		var newTaskId uint64
-		if err := c.QueryRow(ctx, insertTaskQuery, userId, TaskAttributes{
+		if err := tx.QueryRow(ctx, insertTaskQuery, userId, TaskAttributes{
 			TimeToComplete: task.timeToComplete,
 			Name:           task.name,
 			CreatedTime:    time.Now(),
+			Prerequisites:  task.prerequisites,
+			DueTime:        task.dueTime,
 		},
-			task.priority, task.status).Scan(&newTaskId); err != nil {
+			task.priority, task.status, workspaceToRow(workspace)).Scan(&newTaskId); err != nil {
 			return nil, fmt.Errorf("putting task into db: %w", err)
 		}
 
-		if len(task.tags) == 0 {
-			return &newTaskId, nil
-		}
-
-		batch = &pgx.Batch{}
-		for _, t := range task.tags {
-			batch.Queue(insertTagsToTasks, newTaskId, seen[t])
+		if err := linkTags(ctx, tx, TaskId(newTaskId), task.tags, tagIds); err != nil {
+			return nil, fmt.Errorf("linking tags to new task: %w", err)
 		}
-
-		batchResult := c.SendBatch(ctx, batch)
-		defer batchResult.Close()
-		for i := 0; i < batch.Len(); i++ {
-			if _, err := batchResult.Exec(); err != nil {
-				return nil, fmt.Errorf("executing batch tag insert: %w", err)
-			}
+		if err := tx.Commit(ctx); err != nil {
+			return nil, fmt.Errorf("committing new task: %w", err)
 		}
-
 		return &newTaskId, nil
 	})
 	if err != nil {
@@ -220,13 +421,78 @@ func (e *Database) Put(
 	return TaskId(*newTaskId), nil
 }
 
+func (e *Database) update(
+	ctx context.Context,
+	userId auth.UserId,
+	taskId TaskId,
+	task Task,
+) error {
+	if err := store.Call(ctx, e.psqlUrl, func(c *pgx.Conn) error {
+		tx, err := c.Begin(ctx)
+		if err != nil {
+			return fmt.Errorf("starting transaction: %w", err)
+		}
+		defer tx.Rollback(ctx)
+
+		// members of a workspace can replace its tasks, so that they can work
+		// on a shared backlog without owning it
+		var fields TaskAttributes
+		var workspace *uint64
+		if err := tx.QueryRow(ctx, getTaskFieldsForUpdate, taskId, userId, Owner, Editor).Scan(&fields, &workspace); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return missingTask(ctx, tx, userId, taskId)
+			}
+			return fmt.Errorf("getting existing task: %w", err)
+		}
+
+		if _, err := tx.Exec(ctx, updateTask, TaskAttributes{
+			TimeToComplete: task.timeToComplete,
+			Name:           task.name,
+			CreatedTime:    fields.CreatedTime,
+			Prerequisites:  task.prerequisites,
+			DueTime:        task.dueTime,
+		}, task.priority, task.status, taskId); err != nil {
+			return fmt.Errorf("updating task in db: %w", err)
+		}
+
+		if _, err := tx.Exec(ctx, deleteTagsToTasks, taskId); err != nil {
+			return fmt.Errorf("removing old tags from task: %w", err)
+		}
+		tagIds, err := getOrCreateTags(ctx, tx, e.quotas, userId, workspaceFromRow(workspace), task.tags)
+		if err != nil {
+			return fmt.Errorf("getting tag ids for task: %w", err)
+		}
+		if err := linkTags(ctx, tx, taskId, task.tags, tagIds); err != nil {
+			return fmt.Errorf("linking tags to task: %w", err)
+		}
+
+		if err := tx.Commit(ctx); err != nil {
+			return fmt.Errorf("committing update: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("calling db for update task request: %w", err)
+	}
+	return nil
+}
+
 func (e *Database) GetTags(
 	ctx context.Context,
 	userId auth.UserId,
+	workspace types.Option[WorkspaceId],
 ) ([]FullTag, error) {
 	var res []FullTag
 	if err := store.Call(ctx, e.psqlUrl, func(c *pgx.Conn) error {
-		rows, err := c.Query(ctx, getTags, userId)
+		if err := requireWorkspaceRole(ctx, c, userId, workspace, WorkspaceViewer); err != nil {
+			return err
+		}
+		var rows pgx.Rows
+		var err error
+		if workspaceId, exists := workspace.Unwrap(); exists {
+			rows, err = c.Query(ctx, getWorkspaceTags, workspaceId)
+		} else {
+			rows, err = c.Query(ctx, getTags, userId)
+		}
 		if err != nil {
 			return fmt.Errorf("getting tags rows: %w", err)
 		}
@@ -247,6 +513,7 @@ func (e *Database) GetTags(
 	return res, nil
 }
 
+// Editors of a task can change its status as well as its owner.
 func (e *Database) SetStatus(
 	ctx context.Context,
 	newStatus Status,
@@ -254,9 +521,13 @@ func (e *Database) SetStatus(
 	userId auth.UserId,
 ) error {
 	if err := store.Call(ctx, e.psqlUrl, func(c *pgx.Conn) error {
-		if _, err := c.Exec(ctx, setStatus, newStatus, taskId, userId); err != nil {
+		tag, err := c.Exec(ctx, setStatus, newStatus, taskId, userId, Editor)
+		if err != nil {
 			return fmt.Errorf("setting status in postgres: %w", err)
 		}
+		if tag.RowsAffected() == 0 {
+			return missingTask(ctx, c, userId, taskId)
+		}
 		return nil
 	}); err != nil {
 		return fmt.Errorf("calling store to update status: %w", err)
@@ -264,81 +535,1253 @@ func (e *Database) SetStatus(
 	return nil
 }
 
-func getTagsForTasks(
+// Returns every task, tag and addendum in this user's personal workspace,
+// regardless of status.
+func (e *Database) Export(
 	ctx context.Context,
-	conn *pgx.Conn,
-	tasks map[TaskId]*Task,
-) error {
-	taskIds := make([]uint64, len(tasks))
-	for taskId := range tasks {
-		taskIds = append(taskIds, uint64(taskId))
-	}
+	userId auth.UserId,
+) (UserData, error) {
+	res, err := store.CallAndReturn(ctx, e.psqlUrl, func(c *pgx.Conn) (*UserData, error) {
+		rows, err := c.Query(ctx, getAllTasks, userId)
+		if err != nil {
+			return nil, fmt.Errorf("getting all tasks for user: %w", err)
+		}
+		defer rows.Close()
 
-	rows, err := conn.Query(ctx, getTagsForTasksQuery, taskIds)
+		var taskIds []TaskId
+		lookup := map[TaskId]*Task{}
+		for rows.Next() {
+			var taskId uint64
+			var fields TaskAttributes
+			var priority int
+			var status int
+			if err := rows.Scan(&taskId, &fields, &priority, &status); err != nil {
+				return nil, fmt.Errorf("scanning next task: %w", err)
+			}
+			task := TaskFromDb(fields, Priority(priority), Status(status))
+			taskIds = append(taskIds, TaskId(taskId))
+			lookup[TaskId(taskId)] = &task
+		}
+		rows.Close()
+
+		if err := getTagsForTasks(ctx, c, lookup); err != nil {
+			return nil, fmt.Errorf("getting tags for tasks: %w", err)
+		}
+		addendums, err := getAddendumsForTasks(ctx, c, taskIds)
+		if err != nil {
+			return nil, fmt.Errorf("getting addendums for tasks: %w", err)
+		}
+
+		data := UserData{Addendums: addendums}
+		for _, taskId := range taskIds {
+			task := lookup[taskId]
+			task.numberOfAddendums = uint64(len(addendums[taskId]))
+			data.Tasks = append(data.Tasks, types.Of(taskId, *task))
+		}
+		return &data, nil
+	})
 	if err != nil {
-		return fmt.Errorf("getting tags for tasks: %w", err)
+		return UserData{}, fmt.Errorf("calling db for export: %w", err)
 	}
-	defer rows.Close()
-	for rows.Next() {
-		var taskId uint64
-		var tagId uint64
-		var name string
-		if err := rows.Scan(&tagId, &taskId, &name); err != nil {
-			return fmt.Errorf("scanning next tag: %w", err)
+	return *res, nil
+}
+
+// Recreates all tasks and addendums in data under new task ids. Prerequisites
+// are rewritten to point at the new ids, prerequisites that reference a task
+// that is not part of data are dropped. Returns a mapping from the ids in data
+// to the newly created ids. Either everything is imported or nothing is.
+func (e *Database) Import(
+	ctx context.Context,
+	userId auth.UserId,
+	data UserData,
+) (map[TaskId]TaskId, error) {
+	res, err := store.CallAndReturn(ctx, e.psqlUrl, func(c *pgx.Conn) (*map[TaskId]TaskId, error) {
+		tx, err := c.Begin(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("starting transaction: %w", err)
 		}
-		t := tasks[TaskId(taskId)]
-		t.tags = append(t.tags, Tag(name))
+		defer tx.Rollback(ctx)
+
+		importing := Usage{Tasks: uint64(len(data.Tasks))}
+		for _, addendums := range data.Addendums {
+			for _, a := range addendums {
+				importing.AddendumBytes += uint64(len(a.content))
+			}
+		}
+		if err := e.quotas.check(ctx, tx, userId, importing); err != nil {
+			return nil, err
+		}
+
+		newIds := map[TaskId]TaskId{}
+		rows, err := tx.Query(ctx, reserveTaskIds, len(data.Tasks))
+		if err != nil {
+			return nil, fmt.Errorf("reserving task ids: %w", err)
+		}
+		for i := 0; rows.Next(); i++ {
+			var newTaskId uint64
+			if err := rows.Scan(&newTaskId); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("scanning next reserved task id: %w", err)
+			}
+			newIds[data.Tasks[i].First] = TaskId(newTaskId)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("reading reserved task ids: %w", err)
+		}
+
+		var allTags []Tag
+		for _, t := range data.Tasks {
+			allTags = append(allTags, t.Second.tags...)
+		}
+		tagIds, err := getOrCreateTags(ctx, tx, e.quotas, userId, types.None[WorkspaceId](), allTags)
+		if err != nil {
+			return nil, fmt.Errorf("getting tag ids for imported tasks: %w", err)
+		}
+
+		for _, t := range data.Tasks {
+			task := t.Second
+			var prereqs []TaskId
+			for _, p := range task.prerequisites {
+				if newId, exists := newIds[p]; exists {
+					prereqs = append(prereqs, newId)
+				}
+			}
+			createdTime := task.createdTime
+			if createdTime.IsZero() {
+				createdTime = time.Now()
+			}
+			newTaskId := newIds[t.First]
+			if _, err := tx.Exec(ctx, insertTaskWithIdQuery, newTaskId, userId, TaskAttributes{
+				TimeToComplete: task.timeToComplete,
+				Name:           task.name,
+				CreatedTime:    createdTime,
+				Prerequisites:  prereqs,
+				DueTime:        task.dueTime,
+			}, task.priority, task.status); err != nil {
+				return nil, fmt.Errorf("putting imported task into db: %w", err)
+			}
+			if err := linkTags(ctx, tx, newTaskId, task.tags, tagIds); err != nil {
+				return nil, fmt.Errorf("linking tags to imported task: %w", err)
+			}
+		}
+
+		batch := &pgx.Batch{}
+		for taskId, addendums := range data.Addendums {
+			newTaskId, exists := newIds[taskId]
+			if !exists {
+				return nil, NewInvalidArgumentError("addendums", fmt.Sprintf("addendum references unknown task %d", taskId))
+			}
+			for _, a := range addendums {
+				batch.Queue(insertAddundumWithTime, userId, newTaskId, a.content, a.created)
+			}
+		}
+		if batch.Len() > 0 {
+			batchResult := tx.SendBatch(ctx, batch)
+			for i := 0; i < batch.Len(); i++ {
+				if _, err := batchResult.Exec(); err != nil {
+					batchResult.Close()
+					return nil, fmt.Errorf("executing batch addendum insert: %w", err)
+				}
+			}
+			batchResult.Close()
+		}
+
+		if err := tx.Commit(ctx); err != nil {
+			return nil, fmt.Errorf("committing import: %w", err)
+		}
+		return &newIds, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("calling db for import: %w", err)
 	}
-	return nil
+	return *res, nil
 }
 
-func getNumberOfAddendums(
+// secret is stored alongside the webhook so that payloads can be signed when
+// they are delivered.
+func (e *Database) CreateWebhook(
 	ctx context.Context,
-	conn *pgx.Conn,
-	tasks map[TaskId]*Task,
-) error {
-	taskIds := make([]uint64, len(tasks))
-	for taskId := range tasks {
-		taskIds = append(taskIds, uint64(taskId))
+	userId auth.UserId,
+	webhook Webhook,
+	secret string,
+) (WebhookId, error) {
+	filters := webhook.filters
+	if filters == nil {
+		filters = []WebhookFilter{}
 	}
-
-	rows, err := conn.Query(ctx, getNumberOfAddendumsForTasks, taskIds)
+	res, err := store.CallAndReturn(ctx, e.psqlUrl, func(c *pgx.Conn) (*uint64, error) {
+		var webhookId uint64
+		if err := c.QueryRow(ctx, insertWebhook, userId, webhook.url, secret, filters).Scan(&webhookId); err != nil {
+			return nil, fmt.Errorf("putting webhook into db: %w", err)
+		}
+		return &webhookId, nil
+	})
 	if err != nil {
-		return fmt.Errorf("getting number of addendums for tasks: %w", err)
+		return 0, fmt.Errorf("calling db for create webhook: %w", err)
 	}
-	defer rows.Close()
-	for rows.Next() {
-		var taskId uint64
-		var count uint64
-		if err := rows.Scan(&taskId, &count); err != nil {
-			return fmt.Errorf("scanning next addendum count: %w", err)
+	return WebhookId(*res), nil
+}
+
+func (e *Database) GetWebhooks(
+	ctx context.Context,
+	userId auth.UserId,
+) ([]types.Pair[WebhookId, Webhook], error) {
+	var res []types.Pair[WebhookId, Webhook]
+	if err := store.Call(ctx, e.psqlUrl, func(c *pgx.Conn) error {
+		rows, err := c.Query(ctx, getWebhooks, userId)
+		if err != nil {
+			return fmt.Errorf("getting webhook rows: %w", err)
 		}
-		t := tasks[TaskId(taskId)]
-		t.numberOfAddendums = count
+		defer rows.Close()
+		for rows.Next() {
+			var webhookId uint64
+			var webhook Webhook
+			if err := rows.Scan(&webhookId, &webhook.url, &webhook.filters, &webhook.createdTime); err != nil {
+				return fmt.Errorf("scanning next webhook: %w", err)
+			}
+			res = append(res, types.Of(WebhookId(webhookId), webhook))
+		}
+		return rows.Err()
+	}); err != nil {
+		return nil, fmt.Errorf("calling db for webhooks: %w", err)
 	}
-	return nil
+	return res, nil
 }
 
-func getAddendumsForTasks(
+func (e *Database) DeleteWebhook(
 	ctx context.Context,
-	conn *pgx.Conn,
-	taskIds []TaskId,
-) (map[TaskId][]Addendum, error) {
-	rows, err := conn.Query(ctx, getAddendumsForTasksQuery, taskIds)
-	if err != nil {
-		return nil, fmt.Errorf("getting addendums for tasks: %w", err)
+	userId auth.UserId,
+	webhookId WebhookId,
+) error {
+	if err := store.Call(ctx, e.psqlUrl, func(c *pgx.Conn) error {
+		tag, err := c.Exec(ctx, deleteWebhook, webhookId, userId)
+		if err != nil {
+			return fmt.Errorf("deleting webhook from db: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			var owner uint64
+			err := c.QueryRow(ctx, getWebhookOwner, webhookId).Scan(&owner)
+			if errors.Is(err, pgx.ErrNoRows) {
+				return &NotFoundError{Resource: "webhook", Id: uint64(webhookId)}
+			}
+			if err != nil {
+				return fmt.Errorf("getting owner of webhook: %w", err)
+			}
+			return &PermissionDeniedError{Resource: "webhook", Id: uint64(webhookId)}
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("calling db for delete webhook: %w", err)
 	}
-	defer rows.Close()
+	return nil
+}
 
-	addendums := map[TaskId][]Addendum{}
-	for rows.Next() {
-		var taskId uint64
-		var content string
-		var writeTime time.Time
-		if err := rows.Scan(&taskId, &content, &writeTime); err != nil {
-			return nil, fmt.Errorf("scanning next tag: %w", err)
+// Turns up to limit recorded task events into pending deliveries, one for each
+// webhook of the event's users that matches it, with toPayload of the event as
+// the payload. Events are removed in the same transaction, and concurrent
+// callers never claim the same events. Returns how many events were handled.
+func (e *Database) EnqueueDeliveries(
+	ctx context.Context,
+	limit int,
+	toPayload func(TaskEvent) (string, error),
+) (int, error) {
+	res, err := store.CallAndReturn(ctx, e.psqlUrl, func(c *pgx.Conn) (*int, error) {
+		tx, err := c.Begin(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("starting transaction: %w", err)
 		}
-		addendums[TaskId(taskId)] = append(addendums[TaskId(taskId)], NewAddendum(writeTime, content))
+		defer tx.Rollback(ctx)
+
+		eventIds, taskEvents, err := claimWebhookEventRows(ctx, tx, limit)
+		if err != nil {
+			return nil, err
+		}
+		if len(taskEvents) == 0 {
+			none := 0
+			return &none, nil
+		}
+		var userIds []uint64
+		for _, event := range taskEvents {
+			for _, userId := range event.UserIds {
+				userIds = append(userIds, uint64(userId))
+			}
+		}
+		webhooks, err := getWebhooksForUserRows(ctx, tx, userIds)
+		if err != nil {
+			return nil, err
+		}
+
+		batch := &pgx.Batch{}
+		for _, event := range taskEvents {
+			var payload string
+			for _, userId := range event.UserIds {
+				for _, w := range webhooks[userId] {
+					if !w.Second.Matches(event.Type, &event.Task) {
+						continue
+					}
+					if payload == "" {
+						if payload, err = toPayload(event); err != nil {
+							return nil, fmt.Errorf("making payload for task %d: %w", event.TaskId, err)
+						}
+					}
+					batch.Queue(insertDelivery, w.First, userId, payload, Pending)
+				}
+			}
+		}
+		batch.Queue(deleteWebhookEvents, eventIds)
+		batchResult := tx.SendBatch(ctx, batch)
+		for i := 0; i < batch.Len(); i++ {
+			if _, err := batchResult.Exec(); err != nil {
+				batchResult.Close()
+				return nil, fmt.Errorf("writing deliveries to outbox: %w", err)
+			}
+		}
+		if err := batchResult.Close(); err != nil {
+			return nil, fmt.Errorf("closing delivery batch: %w", err)
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return nil, fmt.Errorf("committing deliveries: %w", err)
+		}
+		handled := len(taskEvents)
+		return &handled, nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("calling db for enqueue deliveries: %w", err)
+	}
+	return *res, nil
+}
+
+// Locks up to limit recorded task events, oldest first, skipping events that
+// another caller has locked.
+func claimWebhookEventRows(
+	ctx context.Context,
+	conn querier,
+	limit int,
+) ([]uint64, []TaskEvent, error) {
+	rows, err := conn.Query(ctx, claimWebhookEvents, limit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("claiming webhook events: %w", err)
+	}
+	defer rows.Close()
+	var eventIds []uint64
+	var taskEvents []TaskEvent
+	for rows.Next() {
+		var eventId uint64
+		var eventType string
+		var taskId uint64
+		var previousStatus *int
+		var userIds []uint64
+		var fields TaskAttributes
+		var priority int
+		var status int
+		var assignee *uint64
+		var tags []string
+		var numberOfAddendums uint64
+		var addendumAuthor *uint64
+		var addendumContent *string
+		var addendumTime *time.Time
+		if err := rows.Scan(
+			&eventId,
+			&eventType,
+			&taskId,
+			&previousStatus,
+			&userIds,
+			&fields,
+			&priority,
+			&status,
+			&assignee,
+			&tags,
+			&numberOfAddendums,
+			&addendumAuthor,
+			&addendumContent,
+			&addendumTime,
+		); err != nil {
+			return nil, nil, fmt.Errorf("scanning next webhook event: %w", err)
+		}
+
+		task := TaskFromDb(fields, Priority(priority), Status(status))
+		task.assignee = assigneeFromRow(assignee)
+		task.numberOfAddendums = numberOfAddendums
+		for _, t := range tags {
+			task.tags = append(task.tags, Tag(t))
+		}
+		event := TaskEvent{
+			Type:           taskspb.TaskEventType(taskspb.TaskEventType_value[eventType]),
+			TaskId:         TaskId(taskId),
+			PreviousStatus: types.None[Status](),
+			Task:           task,
+			Addendum:       types.None[Addendum](),
+		}
+		if previousStatus != nil {
+			event.PreviousStatus = types.Some(Status(*previousStatus))
+		}
+		if addendumAuthor != nil && addendumContent != nil && addendumTime != nil {
+			event.Addendum = types.Some(NewAddendum(*addendumTime, *addendumContent, auth.UserId(*addendumAuthor)))
+		}
+		for _, userId := range userIds {
+			event.UserIds = append(event.UserIds, auth.UserId(userId))
+		}
+		eventIds = append(eventIds, eventId)
+		taskEvents = append(taskEvents, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("reading webhook events: %w", err)
+	}
+	return eventIds, taskEvents, nil
+}
+
+// Returns the webhooks of each of userIds that has any
+func getWebhooksForUserRows(
+	ctx context.Context,
+	conn querier,
+	userIds []uint64,
+) (map[auth.UserId][]types.Pair[WebhookId, Webhook], error) {
+	rows, err := conn.Query(ctx, getWebhooksForUsers, userIds)
+	if err != nil {
+		return nil, fmt.Errorf("getting webhooks for users: %w", err)
+	}
+	defer rows.Close()
+	res := map[auth.UserId][]types.Pair[WebhookId, Webhook]{}
+	for rows.Next() {
+		var webhookId uint64
+		var userId uint64
+		var webhook Webhook
+		if err := rows.Scan(&webhookId, &userId, &webhook.filters); err != nil {
+			return nil, fmt.Errorf("scanning next webhook: %w", err)
+		}
+		res[auth.UserId(userId)] = append(res[auth.UserId(userId)], types.Of(WebhookId(webhookId), webhook))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reading webhooks: %w", err)
+	}
+	return res, nil
+}
+
+// Claims up to limit pending deliveries that are due. Claimed deliveries are
+// not due again until leaseUntil, so concurrent senders never claim the same
+// delivery, and a sender that dies mid attempt only delays it.
+func (e *Database) ClaimDeliveries(
+	ctx context.Context,
+	limit int,
+	leaseUntil time.Time,
+) ([]PendingDelivery, error) {
+	var res []PendingDelivery
+	if err := store.Call(ctx, e.psqlUrl, func(c *pgx.Conn) error {
+		rows, err := c.Query(ctx, claimDeliveries, limit, leaseUntil, Pending)
+		if err != nil {
+			return fmt.Errorf("claiming deliveries: %w", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var delivery PendingDelivery
+			if err := rows.Scan(&delivery.Id, &delivery.Url, &delivery.Secret, &delivery.Payload, &delivery.Attempts); err != nil {
+				return fmt.Errorf("scanning next delivery: %w", err)
+			}
+			res = append(res, delivery)
+		}
+		return rows.Err()
+	}); err != nil {
+		return nil, fmt.Errorf("calling db for claim deliveries: %w", err)
+	}
+	return res, nil
+}
+
+func (e *Database) FinishDeliveryAttempt(
+	ctx context.Context,
+	deliveryId DeliveryId,
+	attempt DeliveryAttempt,
+) error {
+	if err := store.Call(ctx, e.psqlUrl, func(c *pgx.Conn) error {
+		if _, err := c.Exec(
+			ctx,
+			finishDeliveryAttempt,
+			attempt.State,
+			attempt.NextAttempt,
+			attempt.ResponseCode,
+			attempt.Error,
+			deliveryId,
+		); err != nil {
+			return fmt.Errorf("recording delivery attempt: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("calling db for finish delivery attempt: %w", err)
+	}
+	return nil
+}
+
+// Returns the most recent deliveries first, for all webhooks when webhookId is None.
+func (e *Database) GetDeliveries(
+	ctx context.Context,
+	userId auth.UserId,
+	webhookId types.Option[WebhookId],
+	limit int,
+) ([]Delivery, error) {
+	var filter *uint64
+	if id, exists := webhookId.Unwrap(); exists {
+		asUint := uint64(id)
+		filter = &asUint
+	}
+	var res []Delivery
+	if err := store.Call(ctx, e.psqlUrl, func(c *pgx.Conn) error {
+		rows, err := c.Query(ctx, getDeliveries, userId, filter, limit)
+		if err != nil {
+			return fmt.Errorf("getting delivery rows: %w", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var d Delivery
+			if err := rows.Scan(
+				&d.id,
+				&d.webhookId,
+				&d.state,
+				&d.payload,
+				&d.attempts,
+				&d.nextAttempt,
+				&d.lastError,
+				&d.lastResponseCode,
+				&d.createdTime,
+			); err != nil {
+				return fmt.Errorf("scanning next delivery: %w", err)
+			}
+			res = append(res, d)
+		}
+		return rows.Err()
+	}); err != nil {
+		return nil, fmt.Errorf("calling db for deliveries: %w", err)
+	}
+	return res, nil
+}
+
+// Works out why taskId could not be found for userId.
+func missingTask(
+	ctx context.Context,
+	q querier,
+	userId auth.UserId,
+	taskId TaskId,
+) error {
+	var owner uint64
+	err := q.QueryRow(ctx, getTaskOwner, taskId).Scan(&owner)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &NotFoundError{Resource: "task", Id: uint64(taskId)}
+	}
+	if err != nil {
+		return fmt.Errorf("getting owner of task: %w", err)
+	}
+	if auth.UserId(owner) != userId {
+		return &PermissionDeniedError{Resource: "task", Id: uint64(taskId)}
+	}
+	// it was created between our two queries
+	return &NotFoundError{Resource: "task", Id: uint64(taskId)}
+}
+
+// Looks up the ids of the given tags in a workspace, or in userId's personal
+// workspace, creating any tags that do not exist yet. The tags that are created
+// count towards userId's quota.
+func getOrCreateTags(
+	ctx context.Context,
+	q querier,
+	quotas Quotas,
+	userId auth.UserId,
+	workspace types.Option[WorkspaceId],
+	tags []Tag,
+) (map[Tag]uint64, error) {
+	rows, err := q.Query(ctx, getTagsFromString, tags, workspaceToRow(workspace), userId)
+	if err != nil {
+		return nil, fmt.Errorf("getting tags from names on task: %w", err)
+	}
+	seen := map[Tag]uint64{}
+	for rows.Next() {
+		var tagId uint64
+		var name string
+		if err := rows.Scan(&tagId, &name); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scanning next tag: %w", err)
+		}
+		seen[Tag(name)] = tagId
+	}
+	rows.Close()
+
+	batch := &pgx.Batch{}
+	queued := map[Tag]struct{}{}
+	for _, t := range tags {
+		if _, exists := seen[t]; exists {
+			continue
+		}
+		if _, exists := queued[t]; exists {
+			continue
+		}
+		queued[t] = struct{}{}
+		batch.Queue(insertTag, userId, t, workspaceToRow(workspace))
+	}
+	if batch.Len() > 0 {
+		if err := quotas.check(ctx, q, userId, Usage{Tags: uint64(batch.Len())}); err != nil {
+			return nil, err
+		}
+		batchResult := q.SendBatch(ctx, batch)
+		for i := 0; i < batch.Len(); i++ {
+			var tagId uint64
+			var name string
+			err := batchResult.QueryRow().Scan(&tagId, &name)
+			if err != nil {
+				batchResult.Close()
+				return nil, fmt.Errorf("executing batch tag insert: %w", err)
+			}
+			seen[Tag(name)] = tagId
+		}
+		batchResult.Close()
+	}
+	return seen, nil
+}
+
+func linkTags(
+	ctx context.Context,
+	q querier,
+	taskId TaskId,
+	tags []Tag,
+	tagIds map[Tag]uint64,
+) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	batch := &pgx.Batch{}
+	for _, t := range tags {
+		batch.Queue(insertTagsToTasks, taskId, tagIds[t])
+	}
+
+	batchResult := q.SendBatch(ctx, batch)
+	defer batchResult.Close()
+	for i := 0; i < batch.Len(); i++ {
+		if _, err := batchResult.Exec(); err != nil {
+			return fmt.Errorf("executing batch tag insert: %w", err)
+		}
+	}
+	return nil
+}
+
+func getTagsForTasks(
+	ctx context.Context,
+	conn querier,
+	tasks map[TaskId]*Task,
+) error {
+	taskIds := make([]uint64, len(tasks))
+	for taskId := range tasks {
+		taskIds = append(taskIds, uint64(taskId))
+	}
+
+	rows, err := conn.Query(ctx, getTagsForTasksQuery, taskIds)
+	if err != nil {
+		return fmt.Errorf("getting tags for tasks: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var taskId uint64
+		var tagId uint64
+		var name string
+		if err := rows.Scan(&tagId, &taskId, &name); err != nil {
+			return fmt.Errorf("scanning next tag: %w", err)
+		}
+		t := tasks[TaskId(taskId)]
+		t.tags = append(t.tags, Tag(name))
+	}
+	return nil
+}
+
+func getNumberOfAddendums(
+	ctx context.Context,
+	conn querier,
+	tasks map[TaskId]*Task,
+) error {
+	taskIds := make([]uint64, len(tasks))
+	for taskId := range tasks {
+		taskIds = append(taskIds, uint64(taskId))
+	}
+
+	rows, err := conn.Query(ctx, getNumberOfAddendumsForTasks, taskIds)
+	if err != nil {
+		return fmt.Errorf("getting number of addendums for tasks: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var taskId uint64
+		var count uint64
+		if err := rows.Scan(&taskId, &count); err != nil {
+			return fmt.Errorf("scanning next addendum count: %w", err)
+		}
+		t := tasks[TaskId(taskId)]
+		t.numberOfAddendums = count
+	}
+	return nil
+}
+
+func getAddendumsForTasks(
+	ctx context.Context,
+	conn querier,
+	taskIds []TaskId,
+) (map[TaskId][]Addendum, error) {
+	rows, err := conn.Query(ctx, getAddendumsForTasksQuery, taskIds)
+	if err != nil {
+		return nil, fmt.Errorf("getting addendums for tasks: %w", err)
+	}
+	defer rows.Close()
+
+	addendums := map[TaskId][]Addendum{}
+	for rows.Next() {
+		var taskId uint64
+		var content string
+		var writeTime time.Time
+		var author uint64
+		if err := rows.Scan(&taskId, &content, &writeTime, &author); err != nil {
+			return nil, fmt.Errorf("scanning next tag: %w", err)
+		}
+		addendums[TaskId(taskId)] = append(
+			addendums[TaskId(taskId)],
+			NewAddendum(writeTime, content, auth.UserId(author)),
+		)
 	}
 	return addendums, nil
 }
+
+// Only hash is stored, the token itself cannot be recovered from the database.
+func (e *Database) CreateAccessToken(
+	ctx context.Context,
+	userId auth.UserId,
+	token AccessToken,
+	hash []byte,
+) (AccessTokenId, error) {
+	res, err := store.CallAndReturn(ctx, e.psqlUrl, func(c *pgx.Conn) (*uint64, error) {
+		var tokenId uint64
+		if err := c.QueryRow(
+			ctx,
+			insertAccessToken,
+			userId,
+			token.name,
+			hash,
+			scopesToRow(token.scopes),
+			expiresToRow(token.expires),
+		).Scan(&tokenId); err != nil {
+			return nil, fmt.Errorf("putting access token into db: %w", err)
+		}
+		return &tokenId, nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("calling db for create access token: %w", err)
+	}
+	return AccessTokenId(*res), nil
+}
+
+// Includes expired tokens, so that users can see why a script stopped working
+func (e *Database) GetAccessTokens(
+	ctx context.Context,
+	userId auth.UserId,
+) ([]types.Pair[AccessTokenId, AccessToken], error) {
+	var res []types.Pair[AccessTokenId, AccessToken]
+	if err := store.Call(ctx, e.psqlUrl, func(c *pgx.Conn) error {
+		rows, err := c.Query(ctx, getAccessTokens, userId)
+		if err != nil {
+			return fmt.Errorf("getting access token rows: %w", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var tokenId uint64
+			var token AccessToken
+			var scopes []int32
+			var expires *time.Time
+			if err := rows.Scan(&tokenId, &token.name, &scopes, &expires, &token.createdTime); err != nil {
+				return fmt.Errorf("scanning next access token: %w", err)
+			}
+			token.scopes = scopesFromRow(scopes)
+			token.expires = expiresFromRow(expires)
+			res = append(res, types.Of(AccessTokenId(tokenId), token))
+		}
+		return rows.Err()
+	}); err != nil {
+		return nil, fmt.Errorf("calling db for access tokens: %w", err)
+	}
+	return res, nil
+}
+
+func (e *Database) RevokeAccessToken(
+	ctx context.Context,
+	userId auth.UserId,
+	tokenId AccessTokenId,
+) error {
+	if err := store.Call(ctx, e.psqlUrl, func(c *pgx.Conn) error {
+		tag, err := c.Exec(ctx, deleteAccessToken, tokenId, userId)
+		if err != nil {
+			return fmt.Errorf("deleting access token from db: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			var owner uint64
+			err := c.QueryRow(ctx, getAccessTokenOwner, tokenId).Scan(&owner)
+			if errors.Is(err, pgx.ErrNoRows) {
+				return &NotFoundError{Resource: "access token", Id: uint64(tokenId)}
+			}
+			if err != nil {
+				return fmt.Errorf("getting owner of access token: %w", err)
+			}
+			return &PermissionDeniedError{Resource: "access token", Id: uint64(tokenId)}
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("calling db for revoke access token: %w", err)
+	}
+	return nil
+}
+
+// Satisfies auth.AccessTokenStore
+func (e *Database) LookupAccessToken(
+	ctx context.Context,
+	hash []byte,
+) (types.Option[auth.Caller], error) {
+	res, err := store.CallAndReturn(ctx, e.psqlUrl, func(c *pgx.Conn) (*types.Option[auth.Caller], error) {
+		var userId uint64
+		var scopes []int32
+		err := c.QueryRow(ctx, lookupAccessToken, hash).Scan(&userId, &scopes)
+		if errors.Is(err, pgx.ErrNoRows) {
+			none := types.None[auth.Caller]()
+			return &none, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("looking up access token: %w", err)
+		}
+		caller := types.Some(auth.Caller{
+			UserId: auth.UserId(userId),
+			Scopes: scopesFromRow(scopes),
+		})
+		return &caller, nil
+	})
+	if err != nil {
+		return types.None[auth.Caller](), fmt.Errorf("calling db for access token lookup: %w", err)
+	}
+	return *res, nil
+}
+
+// Grants share.userId access to the target, or changes their role if they
+// already have it. Only the owners of a task can share it. Sharing a tag
+// shares the owner's personal tasks with that tag, never the tasks of other
+// users or of workspaces.
+func (e *Database) Share(
+	ctx context.Context,
+	owner auth.UserId,
+	share Share,
+) error {
+	if err := store.Call(ctx, e.psqlUrl, func(c *pgx.Conn) error {
+		if taskId, exists := share.target.taskId.Unwrap(); exists {
+			tag, err := c.Exec(ctx, insertTaskShare, taskId, share.userId, share.role, owner, Owner)
+			if err != nil {
+				return fmt.Errorf("putting task share into db: %w", err)
+			}
+			if tag.RowsAffected() == 0 {
+				return missingTask(ctx, c, owner, taskId)
+			}
+			return nil
+		}
+
+		name, _ := share.target.tag.Unwrap()
+		tag, err := c.Exec(ctx, insertTagShare, name, owner, share.userId, share.role)
+		if err != nil {
+			return fmt.Errorf("putting tag share into db: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return NewInvalidArgumentError("target.tag", fmt.Sprintf("tag %s does not exist", name))
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("calling db for share: %w", err)
+	}
+	return nil
+}
+
+// Returns the shares that owner has granted, oldest first
+func (e *Database) GetShares(
+	ctx context.Context,
+	owner auth.UserId,
+) ([]Share, error) {
+	var res []Share
+	if err := store.Call(ctx, e.psqlUrl, func(c *pgx.Conn) error {
+		rows, err := c.Query(ctx, getShares, owner)
+		if err != nil {
+			return fmt.Errorf("getting share rows: %w", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var taskId *uint64
+			var tag *string
+			var share Share
+			if err := rows.Scan(&taskId, &tag, &share.userId, &share.role, &share.createdTime); err != nil {
+				return fmt.Errorf("scanning next share: %w", err)
+			}
+			if taskId != nil {
+				share.target.taskId = types.Some(TaskId(*taskId))
+			} else {
+				share.target.tag = types.Some(Tag(*tag))
+			}
+			res = append(res, share)
+		}
+		return rows.Err()
+	}); err != nil {
+		return nil, fmt.Errorf("calling db for shares: %w", err)
+	}
+	return res, nil
+}
+
+func (e *Database) Unshare(
+	ctx context.Context,
+	owner auth.UserId,
+	target ShareTarget,
+	userId auth.UserId,
+) error {
+	if err := store.Call(ctx, e.psqlUrl, func(c *pgx.Conn) error {
+		taskId, isTask := target.taskId.Unwrap()
+		var tag pgconn.CommandTag
+		var err error
+		if isTask {
+			tag, err = c.Exec(ctx, deleteTaskShare, taskId, userId, owner, Owner)
+		} else {
+			name, _ := target.tag.Unwrap()
+			tag, err = c.Exec(ctx, deleteTagShare, name, userId, owner)
+		}
+		if err != nil {
+			return fmt.Errorf("deleting share from db: %w", err)
+		}
+		if tag.RowsAffected() > 0 {
+			return nil
+		}
+		if isTask {
+			var isOwner bool
+			if err := c.QueryRow(ctx, hasTaskRole, taskId, owner, Owner).Scan(&isOwner); err != nil {
+				return fmt.Errorf("checking role on task: %w", err)
+			}
+			if !isOwner {
+				return missingTask(ctx, c, owner, taskId)
+			}
+		}
+		return &NotFoundError{Resource: "share with user", Id: uint64(userId)}
+	}); err != nil {
+		return fmt.Errorf("calling db for unshare: %w", err)
+	}
+	return nil
+}
+
+// Creates a workspace with userId as its only owner
+func (e *Database) CreateWorkspace(
+	ctx context.Context,
+	userId auth.UserId,
+	name string,
+	inviteCode string,
+) (WorkspaceId, error) {
+	res, err := store.CallAndReturn(ctx, e.psqlUrl, func(c *pgx.Conn) (*uint64, error) {
+		tx, err := c.Begin(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("starting transaction: %w", err)
+		}
+		defer tx.Rollback(ctx)
+
+		var workspaceId uint64
+		if err := tx.QueryRow(ctx, insertWorkspace, name, inviteCode).Scan(&workspaceId); err != nil {
+			return nil, fmt.Errorf("putting workspace into db: %w", err)
+		}
+		if _, err := tx.Exec(ctx, insertWorkspaceMember, workspaceId, userId, WorkspaceOwner); err != nil {
+			return nil, fmt.Errorf("adding owner to workspace: %w", err)
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return nil, fmt.Errorf("committing workspace: %w", err)
+		}
+		return &workspaceId, nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("calling db for create workspace: %w", err)
+	}
+	return WorkspaceId(*res), nil
+}
+
+// Returns the workspaces that userId is in, along with their role in each
+func (e *Database) GetWorkspaces(
+	ctx context.Context,
+	userId auth.UserId,
+) ([]types.Pair[WorkspaceId, Workspace], error) {
+	var res []types.Pair[WorkspaceId, Workspace]
+	if err := store.Call(ctx, e.psqlUrl, func(c *pgx.Conn) error {
+		rows, err := c.Query(ctx, getWorkspaces, userId)
+		if err != nil {
+			return fmt.Errorf("getting workspace rows: %w", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var workspaceId uint64
+			var workspace Workspace
+			if err := rows.Scan(
+				&workspaceId,
+				&workspace.name,
+				&workspace.inviteCode,
+				&workspace.role,
+				&workspace.joinedTime,
+			); err != nil {
+				return fmt.Errorf("scanning next workspace: %w", err)
+			}
+			res = append(res, types.Of(WorkspaceId(workspaceId), workspace))
+		}
+		return rows.Err()
+	}); err != nil {
+		return nil, fmt.Errorf("calling db for workspaces: %w", err)
+	}
+	return res, nil
+}
+
+// Adds userId to the workspace with inviteCode as a member, users that are
+// already in it keep their role.
+func (e *Database) JoinWorkspace(
+	ctx context.Context,
+	userId auth.UserId,
+	inviteCode string,
+) (WorkspaceId, error) {
+	res, err := store.CallAndReturn(ctx, e.psqlUrl, func(c *pgx.Conn) (*uint64, error) {
+		var workspaceId uint64
+		err := c.QueryRow(ctx, getWorkspaceByInvite, inviteCode).Scan(&workspaceId)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, NewInvalidArgumentError("invite_code", "no workspace has this invite code")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("finding workspace for invite code: %w", err)
+		}
+		if _, err := c.Exec(ctx, insertWorkspaceMember, workspaceId, userId, WorkspaceMember); err != nil {
+			return nil, fmt.Errorf("adding member to workspace: %w", err)
+		}
+		return &workspaceId, nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("calling db for join workspace: %w", err)
+	}
+	return WorkspaceId(*res), nil
+}
+
+// The tasks that userId created in the workspace stay in it after they leave
+func (e *Database) LeaveWorkspace(
+	ctx context.Context,
+	userId auth.UserId,
+	workspaceId WorkspaceId,
+) error {
+	if err := store.Call(ctx, e.psqlUrl, func(c *pgx.Conn) error {
+		tx, err := c.Begin(ctx)
+		if err != nil {
+			return fmt.Errorf("starting transaction: %w", err)
+		}
+		defer tx.Rollback(ctx)
+
+		role, err := lockWorkspaceRole(ctx, tx, userId, workspaceId)
+		if err != nil {
+			return err
+		}
+		if role == WorkspaceOwner {
+			if err := requireAnotherOwner(ctx, tx, userId, workspaceId); err != nil {
+				return err
+			}
+		}
+		if _, err := tx.Exec(ctx, deleteWorkspaceMember, workspaceId, userId); err != nil {
+			return fmt.Errorf("removing member from workspace: %w", err)
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return fmt.Errorf("committing leave: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("calling db for leave workspace: %w", err)
+	}
+	return nil
+}
+
+// Any member of a workspace can see who else is in it
+func (e *Database) GetWorkspaceMembers(
+	ctx context.Context,
+	userId auth.UserId,
+	workspaceId WorkspaceId,
+) ([]Member, error) {
+	var res []Member
+	if err := store.Call(ctx, e.psqlUrl, func(c *pgx.Conn) error {
+		if err := requireWorkspaceRole(ctx, c, userId, types.Some(workspaceId), WorkspaceViewer); err != nil {
+			return err
+		}
+		rows, err := c.Query(ctx, getWorkspaceMembers, workspaceId)
+		if err != nil {
+			return fmt.Errorf("getting workspace member rows: %w", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var member Member
+			if err := rows.Scan(&member.userId, &member.role, &member.joinedTime); err != nil {
+				return fmt.Errorf("scanning next workspace member: %w", err)
+			}
+			res = append(res, member)
+		}
+		return rows.Err()
+	}); err != nil {
+		return nil, fmt.Errorf("calling db for workspace members: %w", err)
+	}
+	return res, nil
+}
+
+// Fails unless userId can see the tasks in workspace. Every user can see their
+// personal workspace.
+func (e *Database) RequireWorkspaceViewer(
+	ctx context.Context,
+	userId auth.UserId,
+	workspace types.Option[WorkspaceId],
+) error {
+	if err := store.Call(ctx, e.psqlUrl, func(c *pgx.Conn) error {
+		return requireWorkspaceRole(ctx, c, userId, workspace, WorkspaceViewer)
+	}); err != nil {
+		return fmt.Errorf("calling db for workspace role: %w", err)
+	}
+	return nil
+}
+
+// Only owners can change roles. Owners can demote themselves as long as
+// another owner remains.
+func (e *Database) SetWorkspaceRole(
+	ctx context.Context,
+	userId auth.UserId,
+	workspaceId WorkspaceId,
+	memberId auth.UserId,
+	role WorkspaceRole,
+) error {
+	if err := store.Call(ctx, e.psqlUrl, func(c *pgx.Conn) error {
+		tx, err := c.Begin(ctx)
+		if err != nil {
+			return fmt.Errorf("starting transaction: %w", err)
+		}
+		defer tx.Rollback(ctx)
+
+		callerRole, err := lockWorkspaceRole(ctx, tx, userId, workspaceId)
+		if err != nil {
+			return err
+		}
+		if callerRole != WorkspaceOwner {
+			return notAllowedInWorkspace(workspaceId)
+		}
+		if memberId == userId && role != WorkspaceOwner {
+			if err := requireAnotherOwner(ctx, tx, userId, workspaceId); err != nil {
+				return err
+			}
+		}
+		tag, err := tx.Exec(ctx, updateWorkspaceRole, role, workspaceId, memberId)
+		if err != nil {
+			return fmt.Errorf("updating workspace role: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return &NotFoundError{Resource: "workspace member", Id: uint64(memberId)}
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return fmt.Errorf("committing workspace role: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("calling db for set workspace role: %w", err)
+	}
+	return nil
+}
+
+// Checks that userId has at least role in workspace. Everyone owns their
+// personal workspace, so None always passes.
+func requireWorkspaceRole(
+	ctx context.Context,
+	q querier,
+	userId auth.UserId,
+	workspace types.Option[WorkspaceId],
+	role WorkspaceRole,
+) error {
+	workspaceId, exists := workspace.Unwrap()
+	if !exists {
+		return nil
+	}
+	var actual WorkspaceRole
+	err := q.QueryRow(ctx, getWorkspaceRole, workspaceId, userId).Scan(&actual)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return missingWorkspace(ctx, q, workspaceId)
+	}
+	if err != nil {
+		return fmt.Errorf("getting role in workspace: %w", err)
+	}
+	if actual < role {
+		return notAllowedInWorkspace(workspaceId)
+	}
+	return nil
+}
+
+// Locks the workspace so that membership changes to it are serialized, and
+// returns the role that userId has in it.
+func lockWorkspaceRole(
+	ctx context.Context,
+	q querier,
+	userId auth.UserId,
+	workspaceId WorkspaceId,
+) (WorkspaceRole, error) {
+	var locked uint64
+	err := q.QueryRow(ctx, lockWorkspace, workspaceId).Scan(&locked)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, &NotFoundError{Resource: "workspace", Id: uint64(workspaceId)}
+	}
+	if err != nil {
+		return 0, fmt.Errorf("locking workspace: %w", err)
+	}
+	var role WorkspaceRole
+	err = q.QueryRow(ctx, getWorkspaceRole, workspaceId, userId).Scan(&role)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, notInWorkspace(workspaceId)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("getting role in workspace: %w", err)
+	}
+	return role, nil
+}
+
+func requireAnotherOwner(
+	ctx context.Context,
+	q querier,
+	userId auth.UserId,
+	workspaceId WorkspaceId,
+) error {
+	var owners int
+	if err := q.QueryRow(ctx, countOtherOwners, workspaceId, userId, WorkspaceOwner).Scan(&owners); err != nil {
+		return fmt.Errorf("counting owners of workspace: %w", err)
+	}
+	if owners == 0 {
+		return &FailedPreconditionError{Reason: "workspaces need at least one owner, make someone else an owner first"}
+	}
+	return nil
+}
+
+// Works out why userId could not be found in workspaceId.
+func missingWorkspace(
+	ctx context.Context,
+	q querier,
+	workspaceId WorkspaceId,
+) error {
+	var existing uint64
+	err := q.QueryRow(ctx, getWorkspace, workspaceId).Scan(&existing)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &NotFoundError{Resource: "workspace", Id: uint64(workspaceId)}
+	}
+	if err != nil {
+		return fmt.Errorf("getting workspace: %w", err)
+	}
+	return notInWorkspace(workspaceId)
+}
+
+func notInWorkspace(workspaceId WorkspaceId) error {
+	return &PermissionDeniedError{
+		Resource: "workspace",
+		Id:       uint64(workspaceId),
+		Reason:   fmt.Sprintf("you are not in workspace %d", workspaceId),
+	}
+}
+
+func notAllowedInWorkspace(workspaceId WorkspaceId) error {
+	return &PermissionDeniedError{
+		Resource: "workspace",
+		Id:       uint64(workspaceId),
+		Reason:   fmt.Sprintf("your role in workspace %d does not allow this", workspaceId),
+	}
+}
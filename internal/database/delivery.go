@@ -0,0 +1,77 @@
+package database
+
+import (
+	"time"
+
+	"github.com/WadeCappa/taskmaster/internal/auth"
+	"github.com/WadeCappa/taskmaster/internal/types"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type DeliveryId uint64
+
+type DeliveryState int
+
+const (
+	Pending DeliveryState = iota
+	Delivered
+	Failed
+)
+
+type Delivery struct {
+	id               DeliveryId
+	webhookId        WebhookId
+	state            DeliveryState
+	payload          string
+	attempts         uint32
+	nextAttempt      time.Time
+	lastError        string
+	lastResponseCode uint32
+	createdTime      time.Time
+}
+
+func (d *Delivery) ToWireType() *taskspb.ListWebhookDeliveriesResponse {
+	return &taskspb.ListWebhookDeliveriesResponse{
+		DeliveryId:       uint64(d.id),
+		WebhookId:        uint64(d.webhookId),
+		State:            taskspb.DeliveryState(d.state),
+		Payload:          d.payload,
+		Attempts:         d.attempts,
+		TimeCreated:      timestamppb.New(d.createdTime),
+		NextAttempt:      timestamppb.New(d.nextAttempt),
+		LastError:        d.lastError,
+		LastResponseCode: d.lastResponseCode,
+	}
+}
+
+// A delivery that has been claimed by a sender, along with where to send it.
+type PendingDelivery struct {
+	Id       DeliveryId
+	Url      string
+	Secret   string
+	Payload  string
+	Attempts uint32
+}
+
+// The result of one attempt at sending a PendingDelivery. A zero ResponseCode
+// means no response was received.
+type DeliveryAttempt struct {
+	State        DeliveryState
+	NextAttempt  time.Time
+	ResponseCode uint32
+	Error        string
+}
+
+// A write to a task as it was recorded for webhooks, with the task as it was
+// once the write committed.
+type TaskEvent struct {
+	Type           taskspb.TaskEventType
+	TaskId         TaskId
+	PreviousStatus types.Option[Status]
+	Task           Task
+	// only set for ADDENDUM_ADDED
+	Addendum types.Option[Addendum]
+	// everyone who could see the task and had a webhook
+	UserIds []auth.UserId
+}
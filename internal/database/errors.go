@@ -0,0 +1,96 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Returned when something that a request names does not exist for the caller.
+type NotFoundError struct {
+	Resource string
+	Id       uint64
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s %d does not exist", e.Resource, e.Id)
+}
+
+// Returned when something that a request names exists, but belongs to another user.
+type PermissionDeniedError struct {
+	Resource string
+	Id       uint64
+	// replaces the default message when set
+	Reason string
+}
+
+func (e *PermissionDeniedError) Error() string {
+	if e.Reason != "" {
+		return e.Reason
+	}
+	return fmt.Sprintf("%s %d belongs to another user", e.Resource, e.Id)
+}
+
+// Returned when a request is well formed, but cannot be carried out in the
+// current state, like the last owner of a workspace leaving it.
+type FailedPreconditionError struct {
+	Reason string
+}
+
+func (e *FailedPreconditionError) Error() string {
+	return e.Reason
+}
+
+// Returned when a write would take a user past one of their quotas.
+type QuotaExceededError struct {
+	// what the quota counts, e.g. tasks or addendum bytes
+	Resource string
+	Limit    uint64
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("quota of %d %s exceeded", e.Limit, e.Resource)
+}
+
+type FieldViolation struct {
+	// the path to the field within its message, e.g. minutes_to_complete or filters[1].type
+	Field       string
+	Description string
+}
+
+// Returned when a request is malformed, each violation names the field at fault.
+type InvalidArgumentError struct {
+	Violations []FieldViolation
+}
+
+func NewInvalidArgumentError(field string, description string) *InvalidArgumentError {
+	return &InvalidArgumentError{
+		Violations: []FieldViolation{{Field: field, Description: description}},
+	}
+}
+
+func (e *InvalidArgumentError) Error() string {
+	descriptions := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		descriptions[i] = v.Description
+	}
+	return strings.Join(descriptions, "\n")
+}
+
+// Nests the fields of an InvalidArgumentError under prefix, so that they are
+// relative to the request the message was part of. Any other error is
+// returned as it is.
+func WithFieldPrefix(err error, prefix string) error {
+	var invalid *InvalidArgumentError
+	if !errors.As(err, &invalid) {
+		return err
+	}
+	prefixed := &InvalidArgumentError{Violations: make([]FieldViolation, len(invalid.Violations))}
+	for i, v := range invalid.Violations {
+		prefixed.Violations[i] = FieldViolation{
+			Field:       prefix + "." + v.Field,
+			Description: v.Description,
+		}
+	}
+	return prefixed
+}
@@ -0,0 +1,58 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/WadeCappa/taskmaster/internal/auth"
+)
+
+// How much a single user may store. Tasks and tags count against whoever
+// created them, including those in workspaces, and addendums against whoever
+// wrote them. A quota of zero is unlimited.
+type Quotas struct {
+	MaxTasks         uint64
+	MaxTags          uint64
+	MaxAddendumBytes uint64
+}
+
+// What a user has stored, or is about to store
+type Usage struct {
+	Tasks         uint64
+	Tags          uint64
+	AddendumBytes uint64
+}
+
+// Checks that adding would not take userId past any of the quotas. Concurrent
+// writes by the same user are not serialized, so they can overshoot a quota
+// by a little.
+func (q Quotas) check(ctx context.Context, querier querier, userId auth.UserId, adding Usage) error {
+	if !q.limits(adding) {
+		return nil
+	}
+	var used Usage
+	if err := querier.QueryRow(ctx, getUsage, userId).Scan(&used.Tasks, &used.Tags, &used.AddendumBytes); err != nil {
+		return fmt.Errorf("getting usage for quotas: %w", err)
+	}
+	return q.exceeded(used, adding)
+}
+
+// Whether any quota applies to adding
+func (q Quotas) limits(adding Usage) bool {
+	return (q.MaxTasks > 0 && adding.Tasks > 0) ||
+		(q.MaxTags > 0 && adding.Tags > 0) ||
+		(q.MaxAddendumBytes > 0 && adding.AddendumBytes > 0)
+}
+
+func (q Quotas) exceeded(used Usage, adding Usage) error {
+	if q.MaxTasks > 0 && used.Tasks+adding.Tasks > q.MaxTasks {
+		return &QuotaExceededError{Resource: "tasks", Limit: q.MaxTasks}
+	}
+	if q.MaxTags > 0 && used.Tags+adding.Tags > q.MaxTags {
+		return &QuotaExceededError{Resource: "tags", Limit: q.MaxTags}
+	}
+	if q.MaxAddendumBytes > 0 && used.AddendumBytes+adding.AddendumBytes > q.MaxAddendumBytes {
+		return &QuotaExceededError{Resource: "addendum bytes", Limit: q.MaxAddendumBytes}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/WadeCappa/taskmaster/internal/auth"
+	"github.com/WadeCappa/taskmaster/internal/types"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Ordered, so that a higher role can do everything a lower role can
+type Role int
+
+const (
+	Viewer Role = iota
+	Editor
+	Owner
+)
+
+// Who a task belongs to and what the caller may do with it
+type Access struct {
+	Owner auth.UserId
+	Role  Role
+}
+
+// Either a single task, or every task that the owner has with a tag
+type ShareTarget struct {
+	taskId types.Option[TaskId]
+	tag    types.Option[Tag]
+}
+
+type Share struct {
+	target      ShareTarget
+	userId      auth.UserId
+	role        Role
+	createdTime time.Time
+}
+
+// Violations name fields relative to the request that holds the target
+func ShareTargetFromWireType(wire *taskspb.ShareTarget) (ShareTarget, error) {
+	target, violations := shareTargetFromWire(wire)
+	if len(violations) > 0 {
+		return ShareTarget{}, &InvalidArgumentError{Violations: violations}
+	}
+	return target, nil
+}
+
+func shareTargetFromWire(wire *taskspb.ShareTarget) (ShareTarget, []FieldViolation) {
+	switch target := wire.GetTarget().(type) {
+	case *taskspb.ShareTarget_TaskId:
+		return ShareTarget{taskId: types.Some(TaskId(target.TaskId))}, nil
+	case *taskspb.ShareTarget_Tag:
+		if target.Tag == "" {
+			return ShareTarget{}, []FieldViolation{{"target.tag", "shared tags need a name"}}
+		}
+		return ShareTarget{tag: types.Some(Tag(target.Tag))}, nil
+	}
+	return ShareTarget{}, []FieldViolation{{"target", "shares need either a task_id or a tag"}}
+}
+
+func (t ShareTarget) ToWireType() *taskspb.ShareTarget {
+	if taskId, exists := t.taskId.Unwrap(); exists {
+		return &taskspb.ShareTarget{Target: &taskspb.ShareTarget_TaskId{TaskId: uint64(taskId)}}
+	}
+	tag, _ := t.tag.Unwrap()
+	return &taskspb.ShareTarget{Target: &taskspb.ShareTarget_Tag{Tag: string(tag)}}
+}
+
+func ShareFromWireType(request *taskspb.ShareRequest, owner auth.UserId) (Share, error) {
+	target, violations := shareTargetFromWire(request.GetTarget())
+	if request.GetUserId() == 0 {
+		violations = append(violations, FieldViolation{"user_id", "shares need a user to share with"})
+	}
+	if auth.UserId(request.GetUserId()) == owner {
+		violations = append(violations, FieldViolation{"user_id", "tasks cannot be shared with their owner"})
+	}
+	role, err := RoleFromWire(request.GetRole())
+	if err != nil {
+		violations = append(violations, FieldViolation{"role", err.Error()})
+	} else if role == Owner {
+		violations = append(violations, FieldViolation{"role", "shares can only grant viewer or editor"})
+	}
+
+	if len(violations) > 0 {
+		return Share{}, &InvalidArgumentError{Violations: violations}
+	}
+	return Share{
+		target: target,
+		userId: auth.UserId(request.GetUserId()),
+		role:   role,
+	}, nil
+}
+
+func (s *Share) ToWireType() *taskspb.ListSharesResponse {
+	return &taskspb.ListSharesResponse{
+		Target:      s.target.ToWireType(),
+		UserId:      uint64(s.userId),
+		Role:        taskspb.Role(s.role),
+		TimeCreated: timestamppb.New(s.createdTime),
+	}
+}
+
+func RoleFromWire(role taskspb.Role) (Role, error) {
+	switch role {
+	case taskspb.Role_VIEWER:
+		return Viewer, nil
+	case taskspb.Role_EDITOR:
+		return Editor, nil
+	case taskspb.Role_OWNER:
+		return Owner, nil
+	}
+	return Viewer, fmt.Errorf("unrecognized role of %d", role.Number())
+}
@@ -1,10 +1,12 @@
 package database
 
 import (
-	"errors"
 	"time"
 
+	"github.com/WadeCappa/taskmaster/internal/auth"
+	"github.com/WadeCappa/taskmaster/internal/types"
 	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type Task struct {
@@ -15,40 +17,54 @@ type Task struct {
 	tags              []Tag
 	prerequisites     []TaskId
 	numberOfAddendums uint64
+	createdTime       time.Time
+	dueTime           time.Time
+	// only set on tasks read from the database, and never read from the wire
+	// since tasks are only assigned through Assign
+	access   Access
+	assignee types.Option[auth.UserId]
 }
 
 func FromWireType(wire *taskspb.Task) (Task, error) {
-	var errs []error
+	var violations []FieldViolation
 
 	tags := make([]Tag, len(wire.GetTags()))
 	for i, t := range wire.GetTags() {
 		tags[i] = Tag(t)
 	}
 	if len(tags) == 0 {
-		errs = append(errs, errors.New("task must have at least one tag"))
+		violations = append(violations, FieldViolation{"tags", "task must have at least one tag"})
 	}
 
 	if wire.GetName() == "" {
-		errs = append(errs, errors.New("task must have name"))
+		violations = append(violations, FieldViolation{"name", "task must have name"})
 	}
 
 	if wire.GetMinutesToComplete() == 0 {
-		errs = append(errs, errors.New("task must have time to complete"))
+		violations = append(violations, FieldViolation{"minutes_to_complete", "task must have time to complete"})
 	}
 
 	status, err := StatusFromWire(wire.GetStatus())
 	if err != nil {
-		errs = append(errs, errors.New("task must have time to complete"))
+		violations = append(violations, FieldViolation{"status", err.Error()})
 	}
 
-	if len(errs) > 0 {
-		return Task{}, errors.Join(errs...)
+	if len(violations) > 0 {
+		return Task{}, &InvalidArgumentError{Violations: violations}
 	}
 
 	prereqs := make([]TaskId, len(wire.GetPrerequisites()))
 	for i, p := range wire.GetPrerequisites() {
 		prereqs[i] = TaskId(p)
 	}
+	var createdTime time.Time
+	if wire.GetTimeCreated() != nil {
+		createdTime = wire.GetTimeCreated().AsTime()
+	}
+	var dueTime time.Time
+	if wire.GetDueTime() != nil {
+		dueTime = wire.GetDueTime().AsTime()
+	}
 	return Task{
 		name:           wire.GetName(),
 		timeToComplete: time.Duration(wire.MinutesToComplete * uint64(time.Minute)),
@@ -56,6 +72,8 @@ func FromWireType(wire *taskspb.Task) (Task, error) {
 		status:         status,
 		tags:           tags,
 		prerequisites:  prereqs,
+		createdTime:    createdTime,
+		dueTime:        dueTime,
 	}, nil
 }
 
@@ -69,6 +87,9 @@ func TaskFromDb(
 		timeToComplete: attributes.TimeToComplete,
 		priority:       priority,
 		status:         status,
+		prerequisites:  attributes.Prerequisites,
+		createdTime:    attributes.CreatedTime,
+		dueTime:        attributes.DueTime,
 	}
 }
 
@@ -81,6 +102,19 @@ func (t *Task) ToWireType() *taskspb.Task {
 	for i, p := range t.prerequisites {
 		prereqs[i] = uint64(p)
 	}
+	var createdTime *timestamppb.Timestamp
+	if !t.createdTime.IsZero() {
+		createdTime = timestamppb.New(t.createdTime)
+	}
+	var dueTime *timestamppb.Timestamp
+	if !t.dueTime.IsZero() {
+		dueTime = timestamppb.New(t.dueTime)
+	}
+	var assignee *uint64
+	if userId, exists := t.assignee.Unwrap(); exists {
+		wireId := uint64(userId)
+		assignee = &wireId
+	}
 	return &taskspb.Task{
 		Name:              t.name,
 		MinutesToComplete: uint64(t.timeToComplete.Minutes()),
@@ -89,9 +123,20 @@ func (t *Task) ToWireType() *taskspb.Task {
 		Tags:              tags,
 		Prerequisites:     prereqs,
 		NumberOfAddendums: t.numberOfAddendums,
+		TimeCreated:       createdTime,
+		DueTime:           dueTime,
+		AssigneeUserId:    assignee,
 	}
 }
 
+func (t *Task) Access() Access {
+	return t.access
+}
+
+func (t *Task) Assignee() types.Option[auth.UserId] {
+	return t.assignee
+}
+
 func (t *Task) HasStatus(status Status) bool {
 	return t.status == status
 }
@@ -0,0 +1,10 @@
+package database
+
+import "github.com/WadeCappa/taskmaster/internal/types"
+
+// UserData is everything that a single user owns, keyed by the ids that the
+// tasks had when the data was read.
+type UserData struct {
+	Tasks     []types.Pair[TaskId, Task]
+	Addendums map[TaskId][]Addendum
+}
@@ -0,0 +1,112 @@
+package database
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+)
+
+type WebhookId uint64
+
+// Stored as json on the webhook row, unset fields match everything.
+type WebhookFilter struct {
+	Type   *taskspb.TaskEventType `json:"type,omitempty"`
+	Status *Status                `json:"status,omitempty"`
+	Tags   []Tag                  `json:"tags,omitempty"`
+}
+
+type Webhook struct {
+	url         string
+	filters     []WebhookFilter
+	createdTime time.Time
+}
+
+func WebhookFromWireType(wire *taskspb.Webhook) (Webhook, error) {
+	var violations []FieldViolation
+
+	parsed, err := url.Parse(wire.GetUrl())
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		violations = append(violations, FieldViolation{"url", "webhook url must be an absolute http or https url"})
+	}
+
+	filters := make([]WebhookFilter, len(wire.GetFilters()))
+	for i, f := range wire.GetFilters() {
+		if f.Type != nil {
+			eventType := f.GetType()
+			if _, exists := taskspb.TaskEventType_name[int32(eventType)]; !exists {
+				violations = append(violations, FieldViolation{
+					fmt.Sprintf("filters[%d].type", i),
+					fmt.Sprintf("unrecognized event type of %d", eventType),
+				})
+			}
+			filters[i].Type = &eventType
+		}
+		if f.Status != nil {
+			status, err := StatusFromWire(f.GetStatus())
+			if err != nil {
+				violations = append(violations, FieldViolation{fmt.Sprintf("filters[%d].status", i), err.Error()})
+			}
+			filters[i].Status = &status
+		}
+		for _, t := range f.GetTags() {
+			filters[i].Tags = append(filters[i].Tags, Tag(t))
+		}
+	}
+
+	if len(violations) > 0 {
+		return Webhook{}, &InvalidArgumentError{Violations: violations}
+	}
+	return Webhook{
+		url:     wire.GetUrl(),
+		filters: filters,
+	}, nil
+}
+
+func (w *Webhook) ToWireType() *taskspb.Webhook {
+	filters := make([]*taskspb.WebhookFilter, len(w.filters))
+	for i, f := range w.filters {
+		tags := make([]string, len(f.Tags))
+		for j, t := range f.Tags {
+			tags[j] = string(t)
+		}
+		filters[i] = &taskspb.WebhookFilter{
+			Type: f.Type,
+			Tags: tags,
+		}
+		if f.Status != nil {
+			status := taskspb.Status(*f.Status)
+			filters[i].Status = &status
+		}
+	}
+	return &taskspb.Webhook{
+		Url:     w.url,
+		Filters: filters,
+	}
+}
+
+func (w *Webhook) CreatedTime() time.Time {
+	return w.createdTime
+}
+
+// Whether an event of eventType, which left task as it is now, should be sent
+// to this webhook.
+func (w *Webhook) Matches(eventType taskspb.TaskEventType, task *Task) bool {
+	if len(w.filters) == 0 {
+		return true
+	}
+	for _, f := range w.filters {
+		if f.Type != nil && *f.Type != eventType {
+			continue
+		}
+		if f.Status != nil && !task.HasStatus(*f.Status) {
+			continue
+		}
+		if !task.HasAllTags(f.Tags...) {
+			continue
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/WadeCappa/taskmaster/internal/auth"
+	"github.com/WadeCappa/taskmaster/internal/types"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type WorkspaceId uint64
+
+// Ordered, so that a higher role can do everything a lower role can
+type WorkspaceRole int
+
+const (
+	WorkspaceViewer WorkspaceRole = iota
+	WorkspaceMember
+	WorkspaceOwner
+)
+
+type Workspace struct {
+	name       string
+	role       WorkspaceRole
+	inviteCode string
+	joinedTime time.Time
+}
+
+type Member struct {
+	userId     auth.UserId
+	role       WorkspaceRole
+	joinedTime time.Time
+}
+
+// The workspace a request names, or the caller's personal workspace when it
+// names none
+func WorkspaceFromWire(workspaceId *uint64) types.Option[WorkspaceId] {
+	if workspaceId == nil {
+		return types.None[WorkspaceId]()
+	}
+	return types.Some(WorkspaceId(*workspaceId))
+}
+
+func (w *Workspace) ToWireType(workspaceId WorkspaceId) *taskspb.ListWorkspacesResponse {
+	response := &taskspb.ListWorkspacesResponse{
+		WorkspaceId: uint64(workspaceId),
+		Name:        w.name,
+		Role:        taskspb.WorkspaceRole(w.role),
+		TimeJoined:  timestamppb.New(w.joinedTime),
+	}
+	if w.role == WorkspaceOwner {
+		response.InviteCode = w.inviteCode
+	}
+	return response
+}
+
+func (m *Member) ToWireType() *taskspb.ListWorkspaceMembersResponse {
+	return &taskspb.ListWorkspaceMembersResponse{
+		UserId:     uint64(m.userId),
+		Role:       taskspb.WorkspaceRole(m.role),
+		TimeJoined: timestamppb.New(m.joinedTime),
+	}
+}
+
+func WorkspaceRoleFromWire(role taskspb.WorkspaceRole) (WorkspaceRole, error) {
+	switch role {
+	case taskspb.WorkspaceRole_WORKSPACE_VIEWER:
+		return WorkspaceViewer, nil
+	case taskspb.WorkspaceRole_WORKSPACE_MEMBER:
+		return WorkspaceMember, nil
+	case taskspb.WorkspaceRole_WORKSPACE_OWNER:
+		return WorkspaceOwner, nil
+	}
+	return WorkspaceViewer, fmt.Errorf("unrecognized workspace role of %d", role.Number())
+}
+
+func workspaceToRow(workspace types.Option[WorkspaceId]) *uint64 {
+	if workspaceId, exists := workspace.Unwrap(); exists {
+		asUint := uint64(workspaceId)
+		return &asUint
+	}
+	return nil
+}
+
+func workspaceFromRow(row *uint64) types.Option[WorkspaceId] {
+	if row == nil {
+		return types.None[WorkspaceId]()
+	}
+	return types.Some(WorkspaceId(*row))
+}
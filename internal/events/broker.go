@@ -0,0 +1,114 @@
+package events
+
+import (
+	"sync"
+
+	"github.com/WadeCappa/taskmaster/internal/auth"
+	"github.com/WadeCappa/taskmaster/internal/database"
+	"github.com/WadeCappa/taskmaster/internal/types"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+)
+
+const (
+	SUBSCRIBER_BUFFER_SIZE = 64
+)
+
+type Event struct {
+	Type taskspb.TaskEventType
+	// everyone who can see the task outside of a workspace
+	UserIds []auth.UserId
+	// the workspace the task is in, whose watchers also receive the event
+	WorkspaceId    types.Option[database.WorkspaceId]
+	TaskId         database.TaskId
+	PreviousStatus types.Option[database.Status]
+}
+
+// Fans events out to every subscriber of the users that can see the task, and
+// to every subscriber of the task's workspace. Events from postgres are
+// published by ListenPostgres, stores that cannot notify should publish their
+// own events after each write.
+type Broker struct {
+	lock       sync.Mutex
+	users      subscribers[auth.UserId]
+	workspaces subscribers[database.WorkspaceId]
+}
+
+// Subscribers by whatever they subscribed to, and whether each one has fallen
+// behind
+type subscribers[K comparable] map[K]map[chan Event]bool
+
+func NewBroker() *Broker {
+	return &Broker{
+		users:      subscribers[auth.UserId]{},
+		workspaces: subscribers[database.WorkspaceId]{},
+	}
+}
+
+// A subscriber that is too slow to keep up is sent a RESYNC event rather than
+// hold up everyone else, and misses every event until it has read it.
+func (b *Broker) Publish(event Event) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for _, userId := range event.UserIds {
+		b.users.send(userId, event)
+	}
+	if workspaceId, exists := event.WorkspaceId.Unwrap(); exists {
+		b.workspaces.send(workspaceId, event)
+	}
+}
+
+// Receives the events of tasks that userId can see outside of a workspace. The
+// returned func must be called once the subscriber is done.
+func (b *Broker) Subscribe(userId auth.UserId) (<-chan Event, func()) {
+	return subscribe(b, b.users, userId)
+}
+
+// Receives the events of every task in a workspace, see Subscribe
+func (b *Broker) SubscribeWorkspace(workspaceId database.WorkspaceId) (<-chan Event, func()) {
+	return subscribe(b, b.workspaces, workspaceId)
+}
+
+func subscribe[K comparable](b *Broker, s subscribers[K], key K) (<-chan Event, func()) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	subscriber := make(chan Event, SUBSCRIBER_BUFFER_SIZE)
+	if s[key] == nil {
+		s[key] = map[chan Event]bool{}
+	}
+	s[key][subscriber] = false
+	return subscriber, func() {
+		b.lock.Lock()
+		defer b.lock.Unlock()
+		s.remove(key, subscriber)
+	}
+}
+
+// Must hold the lock. Only the broker sends to subscribers, so a channel with
+// room now still has room once we send to it.
+func (s subscribers[K]) send(key K, event Event) {
+	for subscriber, behind := range s[key] {
+		switch {
+		case behind && len(subscriber) > 0:
+			// still reading up to the RESYNC
+		case len(subscriber) == cap(subscriber)-1:
+			// the last slot is kept for telling the subscriber what it missed
+			subscriber <- Event{Type: taskspb.TaskEventType_RESYNC}
+			s[key][subscriber] = true
+		default:
+			subscriber <- event
+			s[key][subscriber] = false
+		}
+	}
+}
+
+// Must hold the lock
+func (s subscribers[K]) remove(key K, subscriber chan Event) {
+	if _, exists := s[key][subscriber]; !exists {
+		return
+	}
+	delete(s[key], subscriber)
+	if len(s[key]) == 0 {
+		delete(s, key)
+	}
+	close(subscriber)
+}
@@ -0,0 +1,71 @@
+package events_test
+
+import (
+	"testing"
+
+	"github.com/WadeCappa/taskmaster/internal/auth"
+	"github.com/WadeCappa/taskmaster/internal/database"
+	"github.com/WadeCappa/taskmaster/internal/events"
+	"github.com/WadeCappa/taskmaster/internal/types"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBrokerOnlyDeliversToTheEventsUsers(t *testing.T) {
+	broker := events.NewBroker()
+	mine, cancelMine := broker.Subscribe(101)
+	defer cancelMine()
+	shared, cancelShared := broker.Subscribe(102)
+	defer cancelShared()
+	theirs, cancelTheirs := broker.Subscribe(103)
+	defer cancelTheirs()
+
+	event := events.Event{UserIds: []auth.UserId{101, 102}, TaskId: 7}
+	broker.Publish(event)
+
+	require.Len(t, mine, 1)
+	require.Equal(t, event, <-mine)
+	require.Len(t, shared, 1)
+	require.Equal(t, event, <-shared)
+	require.Empty(t, theirs)
+}
+
+func TestBrokerDeliversWorkspaceEventsToTheWorkspacesWatchers(t *testing.T) {
+	broker := events.NewBroker()
+	team, cancelTeam := broker.SubscribeWorkspace(5)
+	defer cancelTeam()
+	otherTeam, cancelOtherTeam := broker.SubscribeWorkspace(6)
+	defer cancelOtherTeam()
+	personal, cancelPersonal := broker.Subscribe(101)
+	defer cancelPersonal()
+
+	event := events.Event{WorkspaceId: types.Some[database.WorkspaceId](5), TaskId: 7}
+	broker.Publish(event)
+
+	require.Len(t, team, 1)
+	require.Equal(t, event, <-team)
+	require.Empty(t, otherTeam)
+	// workspace tasks are not part of anyone's personal workspace
+	require.Empty(t, personal)
+}
+
+func TestBrokerSendsResyncToSlowSubscribers(t *testing.T) {
+	broker := events.NewBroker()
+	subscription, cancel := broker.Subscribe(101)
+	defer cancel()
+
+	for i := 0; i <= events.SUBSCRIBER_BUFFER_SIZE; i++ {
+		broker.Publish(events.Event{UserIds: []auth.UserId{101}, TaskId: database.TaskId(i)})
+	}
+
+	require.Len(t, subscription, events.SUBSCRIBER_BUFFER_SIZE)
+	for i := 0; i < events.SUBSCRIBER_BUFFER_SIZE-1; i++ {
+		require.Equal(t, database.TaskId(i), (<-subscription).TaskId)
+	}
+	require.Equal(t, taskspb.TaskEventType_RESYNC, (<-subscription).Type)
+
+	// once the subscriber has caught up it gets events again
+	broker.Publish(events.Event{UserIds: []auth.UserId{101}, TaskId: 7})
+	require.Len(t, subscription, 1)
+	require.Equal(t, database.TaskId(7), (<-subscription).TaskId)
+}
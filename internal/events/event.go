@@ -0,0 +1,24 @@
+package events
+
+import (
+	"github.com/WadeCappa/taskmaster/internal/database"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+)
+
+// Describes the event using the task and its addendums as they are now, see
+// database.Describe.
+func (e Event) ToWireType(task database.Task, addendums []database.Addendum) *taskspb.WatchTasksResponse {
+	response := &taskspb.WatchTasksResponse{
+		Type:   e.Type,
+		TaskId: uint64(e.TaskId),
+		Task:   task.ToWireType(),
+	}
+	if previousStatus, exists := e.PreviousStatus.Unwrap(); exists {
+		wireStatus := taskspb.Status(previousStatus)
+		response.PreviousStatus = &wireStatus
+	}
+	if e.Type == taskspb.TaskEventType_ADDENDUM_ADDED && len(addendums) > 0 {
+		response.Addendum = addendums[len(addendums)-1].ToWireType()
+	}
+	return response
+}
@@ -0,0 +1,90 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/WadeCappa/taskmaster/internal/auth"
+	"github.com/WadeCappa/taskmaster/internal/database"
+	"github.com/WadeCappa/taskmaster/internal/store"
+	"github.com/WadeCappa/taskmaster/internal/types"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+)
+
+const (
+	// written to by the notify_task_event trigger in schema.sql
+	POSTGRES_CHANNEL    = "task_events"
+	MIN_RECONNECT_DELAY = 100 * time.Millisecond
+	MAX_RECONNECT_DELAY = 30 * time.Second
+)
+
+type notification struct {
+	Type           string   `json:"type"`
+	UserIds        []uint64 `json:"user_ids"`
+	WorkspaceId    *uint64  `json:"workspace_id"`
+	TaskId         uint64   `json:"task_id"`
+	PreviousStatus *int     `json:"previous_status"`
+}
+
+// Publishes every task event from postgres to broker until ctx is done,
+// reconnecting with backoff whenever the connection is lost.
+func ListenPostgres(ctx context.Context, postgresUrl string, broker *Broker) {
+	delay := MIN_RECONNECT_DELAY
+	for {
+		connected := time.Now()
+		err := store.Listen(ctx, postgresUrl, POSTGRES_CHANNEL, func(payload string) {
+			event, err := parseNotification(payload)
+			if err != nil {
+				slog.Warn("dropping task event", "error", err.Error())
+				return
+			}
+			broker.Publish(event)
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		if time.Since(connected) > MAX_RECONNECT_DELAY {
+			delay = MIN_RECONNECT_DELAY
+		}
+		slog.Warn("listening for task events failed", "retry_in", delay, "error", err.Error())
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, MAX_RECONNECT_DELAY)
+	}
+}
+
+func parseNotification(payload string) (Event, error) {
+	var n notification
+	if err := json.Unmarshal([]byte(payload), &n); err != nil {
+		return Event{}, fmt.Errorf("parsing notification %q: %w", payload, err)
+	}
+	eventType, exists := taskspb.TaskEventType_value[n.Type]
+	if !exists {
+		return Event{}, fmt.Errorf("unrecognized event type %q", n.Type)
+	}
+	previousStatus := types.None[database.Status]()
+	if n.PreviousStatus != nil {
+		previousStatus = types.Some(database.Status(*n.PreviousStatus))
+	}
+	userIds := make([]auth.UserId, len(n.UserIds))
+	for i, userId := range n.UserIds {
+		userIds[i] = auth.UserId(userId)
+	}
+	workspaceId := types.None[database.WorkspaceId]()
+	if n.WorkspaceId != nil {
+		workspaceId = types.Some(database.WorkspaceId(*n.WorkspaceId))
+	}
+	return Event{
+		Type:           taskspb.TaskEventType(eventType),
+		UserIds:        userIds,
+		WorkspaceId:    workspaceId,
+		TaskId:         database.TaskId(n.TaskId),
+		PreviousStatus: previousStatus,
+	}, nil
+}
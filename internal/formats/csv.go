@@ -0,0 +1,270 @@
+package formats
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/WadeCappa/taskmaster/internal/database"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	CSV_ID        = "id"
+	CSV_NAME      = "name"
+	CSV_MINUTES   = "minutes"
+	CSV_PRIORITY  = "priority"
+	CSV_STATUS    = "status"
+	CSV_TAGS      = "tags"
+	CSV_CREATED   = "created"
+	CSV_ADDENDUMS = "addendums"
+
+	CSV_TAG_SEPARATOR = ";"
+)
+
+var csvHeader = []string{CSV_ID, CSV_NAME, CSV_MINUTES, CSV_PRIORITY, CSV_STATUS, CSV_TAGS, CSV_CREATED, CSV_ADDENDUMS}
+
+// Writes one row per task. Addendums are reduced to a count and prerequisites
+// are not written at all.
+func WriteCsv(w io.Writer, records []*taskspb.DataRecord) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return fmt.Errorf("writing header: %w", err)
+	}
+	for _, r := range records {
+		record := r.GetTask()
+		if record == nil {
+			continue
+		}
+		task := record.GetTask()
+		var created string
+		if task.GetTimeCreated() != nil {
+			created = task.GetTimeCreated().AsTime().Format(time.RFC3339)
+		}
+		if err := writer.Write([]string{
+			strconv.FormatUint(record.GetTaskId(), 10),
+			task.GetName(),
+			strconv.FormatUint(task.GetMinutesToComplete(), 10),
+			task.GetPriority().String(),
+			task.GetStatus().String(),
+			strings.Join(task.GetTags(), CSV_TAG_SEPARATOR),
+			created,
+			strconv.FormatUint(task.GetNumberOfAddendums(), 10),
+		}); err != nil {
+			return fmt.Errorf("writing row for task %d: %w", record.GetTaskId(), err)
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// Reads tasks from a csv with a header row. Columns are matched by name so they
+// can be reordered, the addendums column is ignored. Rows without an id are
+// given one that does not collide with any other row. Every invalid row is
+// reported, not just the first.
+func ReadCsv(r io.Reader) ([]*taskspb.DataRecord, error) {
+	records, maxId, err := readCsvRows(r)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*taskspb.DataRecord, len(records))
+	for i, r := range records {
+		if r.GetTaskId() == 0 {
+			maxId++
+			r.TaskId = maxId
+		}
+		res[i] = &taskspb.DataRecord{Record: &taskspb.DataRecord_Task{Task: r}}
+	}
+	return res, nil
+}
+
+// What importing a csv does to the tasks that already exist
+type CsvImport struct {
+	// rows that changed the existing task with their id. Fields that a csv
+	// does not hold, such as prerequisites and due times, are kept.
+	Updates map[uint64]*taskspb.Task
+	// rows without an id, or with an id that is not one of the existing tasks
+	Creates []*taskspb.DataRecord
+}
+
+// Reads a csv like ReadCsv, but rows whose id is one of existing update that
+// task instead of being created again, so that an exported csv can be edited
+// and imported without copying every row. Rows that match their task are left
+// out entirely.
+func ReadCsvImport(r io.Reader, existing map[uint64]*taskspb.Task) (CsvImport, error) {
+	records, maxId, err := readCsvRows(r)
+	if err != nil {
+		return CsvImport{}, err
+	}
+	res := CsvImport{Updates: map[uint64]*taskspb.Task{}}
+	for _, r := range records {
+		if r.GetTaskId() == 0 {
+			maxId++
+			r.TaskId = maxId
+		}
+		current, exists := existing[r.GetTaskId()]
+		if !exists {
+			res.Creates = append(res.Creates, &taskspb.DataRecord{Record: &taskspb.DataRecord_Task{Task: r}})
+			continue
+		}
+		if proto.Equal(csvColumns(r.GetTask()), csvColumns(current)) {
+			continue
+		}
+		updated := proto.Clone(current).(*taskspb.Task)
+		updated.Name = r.GetTask().GetName()
+		updated.MinutesToComplete = r.GetTask().GetMinutesToComplete()
+		updated.Priority = r.GetTask().GetPriority()
+		updated.Status = r.GetTask().GetStatus()
+		updated.Tags = r.GetTask().GetTags()
+		res.Updates[r.GetTaskId()] = updated
+	}
+	return res, nil
+}
+
+// The parts of a task that a csv row can change, with tags in a canonical order
+func csvColumns(task *taskspb.Task) *taskspb.Task {
+	tags := slices.Clone(task.GetTags())
+	slices.Sort(tags)
+	return &taskspb.Task{
+		Name:              task.GetName(),
+		MinutesToComplete: task.GetMinutesToComplete(),
+		Priority:          task.GetPriority(),
+		Status:            task.GetStatus(),
+		Tags:              tags,
+	}
+}
+
+// Rows without an id are returned with an id of 0, along with the largest id
+// that was used.
+func readCsvRows(r io.Reader) ([]*taskspb.TaskRecord, uint64, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("reading header: %w", err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{CSV_NAME, CSV_MINUTES, CSV_PRIORITY, CSV_STATUS, CSV_TAGS} {
+		if _, exists := columns[required]; !exists {
+			return nil, 0, fmt.Errorf("missing required column %q", required)
+		}
+	}
+
+	var records []*taskspb.TaskRecord
+	seen := map[uint64]int{}
+	maxId := uint64(0)
+	var errs []error
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("reading csv: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		record, err := csvRowToRecord(columns, row)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("row %d: %w", line, err))
+			continue
+		}
+		if record.GetTaskId() != 0 {
+			if previous, exists := seen[record.GetTaskId()]; exists {
+				errs = append(errs, fmt.Errorf("row %d: id %d already used on row %d", line, record.GetTaskId(), previous))
+				continue
+			}
+			seen[record.GetTaskId()] = line
+			maxId = max(maxId, record.GetTaskId())
+		}
+		records = append(records, record)
+	}
+	if len(errs) > 0 {
+		return nil, 0, errors.Join(errs...)
+	}
+	return records, maxId, nil
+}
+
+func csvRowToRecord(columns map[string]int, row []string) (*taskspb.TaskRecord, error) {
+	get := func(column string) string {
+		i, exists := columns[column]
+		if !exists || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	var errs []error
+	var taskId uint64
+	if id := get(CSV_ID); id != "" {
+		parsed, err := strconv.ParseUint(id, 10, 64)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid id %q", id))
+		}
+		taskId = parsed
+	}
+
+	var minutes uint64
+	if m := get(CSV_MINUTES); m != "" {
+		parsed, err := strconv.ParseUint(m, 10, 64)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid minutes %q", m))
+		}
+		minutes = parsed
+	}
+
+	priority, exists := taskspb.Priority_value[strings.ToUpper(get(CSV_PRIORITY))]
+	if !exists {
+		errs = append(errs, fmt.Errorf("invalid priority %q", get(CSV_PRIORITY)))
+	}
+	status, exists := taskspb.Status_value[strings.ToUpper(get(CSV_STATUS))]
+	if !exists {
+		errs = append(errs, fmt.Errorf("invalid status %q", get(CSV_STATUS)))
+	}
+
+	var tags []string
+	for _, t := range strings.Split(get(CSV_TAGS), CSV_TAG_SEPARATOR) {
+		if t := strings.TrimSpace(t); t != "" {
+			tags = append(tags, t)
+		}
+	}
+
+	var created *timestamppb.Timestamp
+	if c := get(CSV_CREATED); c != "" {
+		parsed, err := time.Parse(time.RFC3339, c)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid created time %q, expected RFC 3339", c))
+		}
+		created = timestamppb.New(parsed)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	task := &taskspb.Task{
+		Name:              get(CSV_NAME),
+		MinutesToComplete: minutes,
+		Priority:          taskspb.Priority(priority),
+		Status:            taskspb.Status(status),
+		Tags:              tags,
+		TimeCreated:       created,
+	}
+	if _, err := database.FromWireType(task); err != nil {
+		return nil, err
+	}
+	return &taskspb.TaskRecord{TaskId: taskId, Task: task}, nil
+}
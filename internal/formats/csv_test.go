@@ -0,0 +1,95 @@
+package formats_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/WadeCappa/taskmaster/internal/formats"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestCsvRoundTrip(t *testing.T) {
+	records := []*taskspb.DataRecord{
+		taskRecord(101, &taskspb.Task{
+			Name:              "write, the report",
+			MinutesToComplete: 90,
+			Priority:          taskspb.Priority_SHOULD_DO,
+			Status:            taskspb.Status_COMPLETED,
+			Tags:              []string{"work", "writing"},
+			TimeCreated:       timestamppb.New(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)),
+		}),
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, formats.WriteCsv(&buf, records))
+
+	res, err := formats.ReadCsv(&buf)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	require.Equal(t, records[0].GetTask().GetTaskId(), res[0].GetTask().GetTaskId())
+	require.Equal(t, records[0].GetTask().GetTask(), res[0].GetTask().GetTask())
+}
+
+func TestCsvReportsEveryInvalidRow(t *testing.T) {
+	input := strings.Join([]string{
+		"name,minutes,priority,status,tags",
+		"good,10,SHOULD_DO,TRACKING,a;b",
+		"bad priority,10,SOMETIME,TRACKING,a",
+		",10,SHOULD_DO,TRACKING,a",
+	}, "\n")
+
+	_, err := formats.ReadCsv(strings.NewReader(input))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "row 3")
+	require.Contains(t, err.Error(), "row 4")
+	require.NotContains(t, err.Error(), "row 2")
+}
+
+func TestCsvAssignsMissingIds(t *testing.T) {
+	input := strings.Join([]string{
+		"id,name,minutes,priority,status,tags",
+		",first,10,SHOULD_DO,TRACKING,a",
+		"7,second,10,SHOULD_DO,TRACKING,a",
+	}, "\n")
+
+	res, err := formats.ReadCsv(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	require.Equal(t, uint64(8), res[0].GetTask().GetTaskId())
+	require.Equal(t, uint64(7), res[1].GetTask().GetTaskId())
+}
+
+func TestCsvReimportUpdatesExportedTasks(t *testing.T) {
+	existing := map[uint64]*taskspb.Task{
+		101: {Name: "write the report", MinutesToComplete: 90, Tags: []string{"work"}, Prerequisites: []uint64{102}},
+		102: {Name: "gather numbers", MinutesToComplete: 30, Tags: []string{"work", "data"}},
+	}
+	records := []*taskspb.DataRecord{taskRecord(101, existing[101]), taskRecord(102, existing[102])}
+	var buf bytes.Buffer
+	require.NoError(t, formats.WriteCsv(&buf, records))
+
+	edited := strings.Replace(buf.String(), "write the report", "write the final report", 1)
+	plan, err := formats.ReadCsvImport(strings.NewReader(edited), existing)
+	require.NoError(t, err)
+	require.Empty(t, plan.Creates)
+	require.Len(t, plan.Updates, 1)
+	for taskId, task := range plan.Updates {
+		existing[taskId] = task
+	}
+	require.Len(t, existing, 2)
+	require.Equal(t, "write the final report", existing[101].GetName())
+	require.Equal(t, []uint64{102}, existing[101].GetPrerequisites())
+
+	// rows without an id, or from somewhere else, are new tasks
+	edited += ",review the report,15,SHOULD_DO,TRACKING,work,,0\n" + "7,from another account,5,SHOULD_DO,TRACKING,work,,0\n"
+	plan, err = formats.ReadCsvImport(strings.NewReader(edited), existing)
+	require.NoError(t, err)
+	require.Empty(t, plan.Updates)
+	require.Len(t, plan.Creates, 2)
+	require.Equal(t, uint64(103), plan.Creates[0].GetTask().GetTaskId())
+	require.Equal(t, uint64(7), plan.Creates[1].GetTask().GetTaskId())
+}
@@ -0,0 +1,436 @@
+package formats
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/WadeCappa/taskmaster/internal/database"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Tasks are written as RFC 5545 VTODO components:
+//
+//	SUMMARY                    <-> name
+//	PRIORITY 1/3/5/9           <-> DO_BEFORE_SLEEP/DO_IMMEDIATELY/SHOULD_DO/EVENTUALLY_DO,
+//	                               read as 1-2/3-4/5/anything else
+//	STATUS NEEDS-ACTION        <-> TRACKING, IN-PROCESS is also read as TRACKING
+//	STATUS COMPLETED           <-> COMPLETED
+//	X-TASKMASTER-STATUS        <-> BACKLOG, written alongside STATUS:NEEDS-ACTION
+//	STATUS CANCELLED            -> not imported
+//	CATEGORIES                 <-> tags, DEFAULT_ICAL_TAG when missing
+//	DUE                        <-> due time
+//	CREATED                    <-> time created
+//	COMMENT                    <-> addendums, comments carry no time so imported addendums are created now
+//	RELATED-TO;RELTYPE=DEPENDS-ON <-> prerequisites
+//	X-TASKMASTER-MINUTES       <-> minutes to complete, DEFAULT_ICAL_MINUTES when missing
+const (
+	ICAL_TIME_FORMAT     = "20060102T150405Z"
+	ICAL_LOCAL_FORMAT    = "20060102T150405"
+	ICAL_DATE_FORMAT     = "20060102"
+	ICAL_PRODUCT_ID      = "-//taskmaster//taskmaster//EN"
+	ICAL_UID_DOMAIN      = "taskmaster"
+	ICAL_STATUS_PROPERTY = "X-TASKMASTER-STATUS"
+	ICAL_MINUTES         = "X-TASKMASTER-MINUTES"
+	ICAL_MAX_LINE_LENGTH = 75
+	DEFAULT_ICAL_TAG     = "ical"
+	DEFAULT_ICAL_MINUTES = 30
+)
+
+var icalEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+
+func WriteICalendar(w io.Writer, records []*taskspb.DataRecord) error {
+	comments := map[uint64][]string{}
+	for _, r := range records {
+		if a := r.GetAddendum(); a != nil {
+			comments[a.GetTaskId()] = append(comments[a.GetTaskId()], a.GetAddendum().GetContent())
+		}
+	}
+
+	writer := bufio.NewWriter(w)
+	line := func(name string, value string) {
+		writeICalLine(writer, name+":"+value)
+	}
+	now := time.Now().UTC().Format(ICAL_TIME_FORMAT)
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", ICAL_PRODUCT_ID)
+	for _, r := range records {
+		record := r.GetTask()
+		if record == nil {
+			continue
+		}
+		task := record.GetTask()
+		line("BEGIN", "VTODO")
+		line("UID", icalUid(record.GetTaskId()))
+		line("DTSTAMP", now)
+		if task.GetTimeCreated() != nil {
+			line("CREATED", task.GetTimeCreated().AsTime().UTC().Format(ICAL_TIME_FORMAT))
+		}
+		line("SUMMARY", icalEscaper.Replace(task.GetName()))
+		line("PRIORITY", strconv.Itoa(icalPriority(task.GetPriority())))
+		switch task.GetStatus() {
+		case taskspb.Status_COMPLETED:
+			line("STATUS", "COMPLETED")
+		case taskspb.Status_BACKLOG:
+			line("STATUS", "NEEDS-ACTION")
+			line(ICAL_STATUS_PROPERTY, taskspb.Status_BACKLOG.String())
+		default:
+			line("STATUS", "NEEDS-ACTION")
+		}
+		if len(task.GetTags()) > 0 {
+			escaped := make([]string, len(task.GetTags()))
+			for i, t := range task.GetTags() {
+				escaped[i] = icalEscaper.Replace(t)
+			}
+			line("CATEGORIES", strings.Join(escaped, ","))
+		}
+		if task.GetDueTime() != nil {
+			line("DUE", task.GetDueTime().AsTime().UTC().Format(ICAL_TIME_FORMAT))
+		}
+		line(ICAL_MINUTES, strconv.FormatUint(task.GetMinutesToComplete(), 10))
+		for _, p := range task.GetPrerequisites() {
+			line("RELATED-TO;RELTYPE=DEPENDS-ON", icalUid(p))
+		}
+		for _, c := range comments[record.GetTaskId()] {
+			line("COMMENT", icalEscaper.Replace(c))
+		}
+		line("END", "VTODO")
+	}
+	line("END", "VCALENDAR")
+	return writer.Flush()
+}
+
+// Reads every VTODO in a calendar, all other components are ignored.
+func ReadICalendar(r io.Reader) ([]*taskspb.DataRecord, error) {
+	lines, err := unfoldICal(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading calendar: %w", err)
+	}
+
+	var todos [][]icalProperty
+	var current []icalProperty
+	depth := 0
+	inTodo := false
+	for i, l := range lines {
+		property, err := parseICalLine(l)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		switch {
+		case property.name == "BEGIN" && strings.EqualFold(property.value, "VTODO") && !inTodo:
+			inTodo = true
+			depth = 0
+			current = nil
+		case inTodo && property.name == "BEGIN":
+			// nested components like VALARM are skipped
+			depth++
+		case inTodo && property.name == "END" && depth > 0:
+			depth--
+		case inTodo && property.name == "END":
+			inTodo = false
+			todos = append(todos, current)
+		case inTodo && depth == 0:
+			current = append(current, property)
+		}
+	}
+
+	ids := map[string]uint64{}
+	for _, todo := range todos {
+		uid := icalFirst(todo, "UID")
+		if uid == "" {
+			uid = fmt.Sprintf("missing-uid-%d", len(ids))
+		}
+		if _, exists := ids[uid]; exists {
+			return nil, fmt.Errorf("found duplicate VTODO %s", uid)
+		}
+		ids[uid] = uint64(len(ids) + 1)
+	}
+
+	var records []*taskspb.DataRecord
+	var errs []error
+	for i, todo := range todos {
+		taskId := uint64(i + 1)
+		if strings.EqualFold(icalFirst(todo, "STATUS"), "CANCELLED") {
+			continue
+		}
+		task, err := icalToWire(todo, ids)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("VTODO %d: %w", i+1, err))
+			continue
+		}
+		records = append(records, &taskspb.DataRecord{
+			Record: &taskspb.DataRecord_Task{
+				Task: &taskspb.TaskRecord{TaskId: taskId, Task: task},
+			},
+		})
+		for _, p := range todo {
+			if p.name != "COMMENT" {
+				continue
+			}
+			records = append(records, &taskspb.DataRecord{
+				Record: &taskspb.DataRecord_Addendum{
+					Addendum: &taskspb.AddendumRecord{
+						TaskId:   taskId,
+						Addendum: &taskspb.Addendum{Content: unescapeICal(p.value)},
+					},
+				},
+			})
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return records, nil
+}
+
+type icalProperty struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+func icalToWire(todo []icalProperty, ids map[string]uint64) (*taskspb.Task, error) {
+	var errs []error
+	task := &taskspb.Task{
+		Name:              unescapeICal(icalFirst(todo, "SUMMARY")),
+		Status:            taskspb.Status_TRACKING,
+		Priority:          taskspb.Priority_EVENTUALLY_DO,
+		MinutesToComplete: DEFAULT_ICAL_MINUTES,
+	}
+
+	switch strings.ToUpper(icalFirst(todo, "STATUS")) {
+	case "", "NEEDS-ACTION", "IN-PROCESS":
+	case "COMPLETED":
+		task.Status = taskspb.Status_COMPLETED
+	default:
+		errs = append(errs, fmt.Errorf("unrecognized status %q", icalFirst(todo, "STATUS")))
+	}
+	if strings.EqualFold(icalFirst(todo, ICAL_STATUS_PROPERTY), taskspb.Status_BACKLOG.String()) &&
+		task.Status == taskspb.Status_TRACKING {
+		task.Status = taskspb.Status_BACKLOG
+	}
+
+	if p := icalFirst(todo, "PRIORITY"); p != "" {
+		priority, err := strconv.Atoi(p)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid priority %q", p))
+		}
+		switch {
+		case priority >= 1 && priority <= 2:
+			task.Priority = taskspb.Priority_DO_BEFORE_SLEEP
+		case priority >= 3 && priority <= 4:
+			task.Priority = taskspb.Priority_DO_IMMEDIATELY
+		case priority == 5:
+			task.Priority = taskspb.Priority_SHOULD_DO
+		}
+	}
+
+	if m := icalFirst(todo, ICAL_MINUTES); m != "" {
+		minutes, err := strconv.ParseUint(m, 10, 64)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid minutes %q", m))
+		}
+		task.MinutesToComplete = minutes
+	}
+
+	for _, p := range todo {
+		switch p.name {
+		case "CATEGORIES":
+			for _, t := range splitICalList(p.value) {
+				if t = strings.TrimSpace(t); t != "" {
+					task.Tags = append(task.Tags, t)
+				}
+			}
+		case "DUE":
+			due, err := parseICalTime(p)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("invalid due: %w", err))
+			}
+			task.DueTime = due
+		case "CREATED":
+			created, err := parseICalTime(p)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("invalid created: %w", err))
+			}
+			task.TimeCreated = created
+		case "RELATED-TO":
+			if !strings.EqualFold(p.params["RELTYPE"], "DEPENDS-ON") {
+				continue
+			}
+			if id, exists := ids[p.value]; exists {
+				task.Prerequisites = append(task.Prerequisites, id)
+			}
+		}
+	}
+	if len(task.Tags) == 0 {
+		task.Tags = []string{DEFAULT_ICAL_TAG}
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	if _, err := database.FromWireType(task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// Content lines longer than 75 octets are folded onto continuation lines that
+// begin with a space. The space counts towards the 75 octets, so continuation
+// lines carry at most 74 octets of content.
+func writeICalLine(w *bufio.Writer, l string) {
+	limit := ICAL_MAX_LINE_LENGTH
+	for len(l) > limit {
+		cut := limit
+		// never split a multi-byte character
+		for cut > 0 && l[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		w.WriteString(l[:cut])
+		w.WriteString("\r\n ")
+		l = l[cut:]
+		limit = ICAL_MAX_LINE_LENGTH - 1
+	}
+	w.WriteString(l)
+	w.WriteString("\r\n")
+}
+
+func unfoldICal(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, MAX_RECORD_SIZE)
+	for scanner.Scan() {
+		l := strings.TrimRight(scanner.Text(), "\r")
+		if len(l) > 0 && (l[0] == ' ' || l[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += l[1:]
+			continue
+		}
+		if l == "" {
+			continue
+		}
+		lines = append(lines, l)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+func parseICalLine(l string) (icalProperty, error) {
+	inQuotes := false
+	colon := -1
+	for i, c := range l {
+		if c == '"' {
+			inQuotes = !inQuotes
+		}
+		if c == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return icalProperty{}, fmt.Errorf("content line without a value: %q", l)
+	}
+
+	parts := strings.Split(l[:colon], ";")
+	property := icalProperty{
+		name:   strings.ToUpper(parts[0]),
+		params: map[string]string{},
+		value:  l[colon+1:],
+	}
+	for _, p := range parts[1:] {
+		key, value, _ := strings.Cut(p, "=")
+		property.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+	}
+	return property, nil
+}
+
+func parseICalTime(p icalProperty) (*timestamppb.Timestamp, error) {
+	if t, err := time.Parse(ICAL_TIME_FORMAT, p.value); err == nil {
+		return timestamppb.New(t), nil
+	}
+	location := time.Local
+	if tzid := p.params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			location = l
+		}
+	}
+	if t, err := time.ParseInLocation(ICAL_LOCAL_FORMAT, p.value, location); err == nil {
+		return timestamppb.New(t), nil
+	}
+	if t, err := time.ParseInLocation(ICAL_DATE_FORMAT, p.value, location); err == nil {
+		return timestamppb.New(t), nil
+	}
+	return nil, fmt.Errorf("unrecognized time %q", p.value)
+}
+
+func icalFirst(todo []icalProperty, name string) string {
+	for _, p := range todo {
+		if p.name == name {
+			return p.value
+		}
+	}
+	return ""
+}
+
+// Splits a comma separated list, respecting escaped commas
+func splitICalList(value string) []string {
+	var res []string
+	var current strings.Builder
+	for i := 0; i < len(value); i++ {
+		switch {
+		case value[i] == '\\' && i+1 < len(value):
+			current.WriteByte(value[i])
+			current.WriteByte(value[i+1])
+			i++
+		case value[i] == ',':
+			res = append(res, unescapeICal(current.String()))
+			current.Reset()
+		default:
+			current.WriteByte(value[i])
+		}
+	}
+	return append(res, unescapeICal(current.String()))
+}
+
+func unescapeICal(value string) string {
+	var res strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i+1 == len(value) {
+			res.WriteByte(value[i])
+			continue
+		}
+		i++
+		switch value[i] {
+		case 'n', 'N':
+			res.WriteByte('\n')
+		default:
+			res.WriteByte(value[i])
+		}
+	}
+	return res.String()
+}
+
+func icalUid(taskId uint64) string {
+	return fmt.Sprintf("%d@%s", taskId, ICAL_UID_DOMAIN)
+}
+
+func icalPriority(p taskspb.Priority) int {
+	switch p {
+	case taskspb.Priority_DO_BEFORE_SLEEP:
+		return 1
+	case taskspb.Priority_DO_IMMEDIATELY:
+		return 3
+	case taskspb.Priority_SHOULD_DO:
+		return 5
+	default:
+		return 9
+	}
+}
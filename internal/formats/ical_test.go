@@ -0,0 +1,94 @@
+package formats_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/WadeCappa/taskmaster/internal/formats"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestICalendarRoundTrip(t *testing.T) {
+	records := []*taskspb.DataRecord{
+		taskRecord(1, &taskspb.Task{
+			Name:              "book flights; hotel, and a car",
+			MinutesToComplete: 45,
+			Priority:          taskspb.Priority_DO_IMMEDIATELY,
+			Status:            taskspb.Status_BACKLOG,
+			Tags:              []string{"travel", "a,b"},
+			TimeCreated:       timestamppb.New(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)),
+			DueTime:           timestamppb.New(time.Date(2025, 4, 1, 9, 30, 0, 0, time.UTC)),
+		}),
+		taskRecord(2, &taskspb.Task{
+			Name:              strings.Repeat("a very long name ", 10),
+			MinutesToComplete: 5,
+			Priority:          taskspb.Priority_EVENTUALLY_DO,
+			Status:            taskspb.Status_COMPLETED,
+			Tags:              []string{"travel"},
+			Prerequisites:     []uint64{1},
+		}),
+		{
+			Record: &taskspb.DataRecord_Addendum{
+				Addendum: &taskspb.AddendumRecord{
+					TaskId:   2,
+					Addendum: &taskspb.Addendum{Content: "first line\nsecond line"},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, formats.WriteICalendar(&buf, records))
+	for _, l := range strings.Split(buf.String(), "\r\n") {
+		require.LessOrEqual(t, len(l), formats.ICAL_MAX_LINE_LENGTH)
+	}
+
+	res, err := formats.ReadICalendar(&buf)
+	require.NoError(t, err)
+	require.Len(t, res, len(records))
+	for i := range records {
+		require.Equal(t, records[i].String(), res[i].String())
+	}
+}
+
+func TestICalendarImport(t *testing.T) {
+	calendar := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"SUMMARY:a meeting",
+		"END:VEVENT",
+		"BEGIN:VTODO",
+		"UID:abc",
+		"SUMMARY:review the",
+		"  design doc",
+		"PRIORITY:2",
+		"DUE;VALUE=DATE:20250401",
+		"BEGIN:VALARM",
+		"ACTION:DISPLAY",
+		"END:VALARM",
+		"END:VTODO",
+		"BEGIN:VTODO",
+		"UID:def",
+		"SUMMARY:never mind",
+		"STATUS:CANCELLED",
+		"END:VTODO",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	res, err := formats.ReadICalendar(strings.NewReader(calendar))
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+
+	task := res[0].GetTask().GetTask()
+	require.Equal(t, "review the design doc", task.GetName())
+	require.Equal(t, taskspb.Priority_DO_BEFORE_SLEEP, task.GetPriority())
+	require.Equal(t, taskspb.Status_TRACKING, task.GetStatus())
+	require.Equal(t, []string{formats.DEFAULT_ICAL_TAG}, task.GetTags())
+	require.Equal(t, uint64(formats.DEFAULT_ICAL_MINUTES), task.GetMinutesToComplete())
+	require.NotNil(t, task.GetDueTime())
+}
@@ -0,0 +1,53 @@
+package formats
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	MAX_RECORD_SIZE = 16 * 1024 * 1024
+)
+
+// Writes one protojson encoded record per line
+func WriteJsonLines(w io.Writer, records []*taskspb.DataRecord) error {
+	writer := bufio.NewWriter(w)
+	for _, r := range records {
+		jsonBytes, err := protojson.Marshal(r)
+		if err != nil {
+			return fmt.Errorf("converting to json: %w", err)
+		}
+		if _, err := writer.Write(jsonBytes); err != nil {
+			return fmt.Errorf("writing record: %w", err)
+		}
+		if err := writer.WriteByte('\n'); err != nil {
+			return fmt.Errorf("writing record: %w", err)
+		}
+	}
+	return writer.Flush()
+}
+
+func ReadJsonLines(r io.Reader) ([]*taskspb.DataRecord, error) {
+	var records []*taskspb.DataRecord
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, MAX_RECORD_SIZE)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		record := &taskspb.DataRecord{}
+		if err := protojson.Unmarshal(scanner.Bytes(), record); err != nil {
+			return nil, fmt.Errorf("parsing record on line %d: %w", line, err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading records: %w", err)
+	}
+	return records, nil
+}
@@ -0,0 +1,61 @@
+package formats_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/WadeCappa/taskmaster/internal/formats"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestJsonLinesRoundTrip(t *testing.T) {
+	records := []*taskspb.DataRecord{
+		taskRecord(101, &taskspb.Task{
+			Name:              "write the report",
+			MinutesToComplete: 90,
+			Priority:          taskspb.Priority_SHOULD_DO,
+			Status:            taskspb.Status_TRACKING,
+			Tags:              []string{"work", "writing"},
+			Prerequisites:     []uint64{102},
+			TimeCreated:       timestamppb.New(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)),
+		}),
+		taskRecord(102, &taskspb.Task{Name: "gather numbers", MinutesToComplete: 30}),
+		{Record: &taskspb.DataRecord_Addendum{Addendum: &taskspb.AddendumRecord{
+			TaskId:   101,
+			Addendum: &taskspb.Addendum{Content: "first draft is done\nsecond line"},
+		}}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, formats.WriteJsonLines(&buf, records))
+	require.Equal(t, len(records), strings.Count(buf.String(), "\n"))
+
+	res, err := formats.ReadJsonLines(&buf)
+	require.NoError(t, err)
+	require.Len(t, res, len(records))
+	for i := range records {
+		require.True(t, proto.Equal(records[i], res[i]), "record %d: %v", i, res[i])
+	}
+}
+
+func TestJsonLinesSkipsBlankLinesAndReportsBadOnes(t *testing.T) {
+	input := strings.Join([]string{
+		`{"task":{"taskId":"1","task":{"name":"first"}}}`,
+		"",
+		`{"task":{"taskId":"2","task":{"name":"second"}}}`,
+		`{"task":`,
+	}, "\n")
+
+	_, err := formats.ReadJsonLines(strings.NewReader(input))
+	require.ErrorContains(t, err, "line 4")
+
+	res, err := formats.ReadJsonLines(strings.NewReader(strings.Join(strings.Split(input, "\n")[:3], "\n")))
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	require.Equal(t, "second", res[1].GetTask().GetTask().GetName())
+}
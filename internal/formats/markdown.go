@@ -0,0 +1,138 @@
+package formats
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/WadeCappa/taskmaster/internal/database"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+)
+
+// Tasks are written as markdown checklist items grouped under a heading per
+// status. When reading, any "- [ ]" or "- [x]" item is a task:
+//
+//	[x]                        <-> COMPLETED
+//	[ ] under a Backlog heading <-> BACKLOG
+//	[ ] anywhere else          <-> TRACKING
+//	#tag                       <-> tags, DEFAULT_MARKDOWN_TAG when missing
+//
+// Markdown has no place for priorities, durations, prerequisites or addendums,
+// so imported tasks are EVENTUALLY_DO and take DEFAULT_MARKDOWN_MINUTES.
+const (
+	DEFAULT_MARKDOWN_TAG     = "markdown"
+	DEFAULT_MARKDOWN_MINUTES = 30
+)
+
+var (
+	markdownItem    = regexp.MustCompile(`^\s*[-*+]\s+\[([ xX])\]\s+(.*)$`)
+	markdownHeading = regexp.MustCompile(`^#{1,6}\s+(.*?)\s*#*\s*$`)
+	markdownTag     = regexp.MustCompile(`^#[^\s#\d][^\s#]*$`)
+)
+
+var markdownSections = []taskspb.Status{
+	taskspb.Status_TRACKING,
+	taskspb.Status_BACKLOG,
+	taskspb.Status_COMPLETED,
+}
+
+func WriteMarkdown(w io.Writer, records []*taskspb.DataRecord) error {
+	writer := bufio.NewWriter(w)
+	first := true
+	for _, status := range markdownSections {
+		var items []string
+		for _, r := range records {
+			task := r.GetTask().GetTask()
+			if task == nil || task.GetStatus() != status {
+				continue
+			}
+			check := " "
+			if status == taskspb.Status_COMPLETED {
+				check = "x"
+			}
+			item := []string{"- [" + check + "]", task.GetName()}
+			for _, t := range task.GetTags() {
+				item = append(item, "#"+t)
+			}
+			items = append(items, strings.Join(item, " "))
+		}
+		if len(items) == 0 {
+			continue
+		}
+		if !first {
+			fmt.Fprintln(writer)
+		}
+		first = false
+		fmt.Fprintf(writer, "## %s\n\n", markdownSectionName(status))
+		for _, item := range items {
+			fmt.Fprintln(writer, item)
+		}
+	}
+	return writer.Flush()
+}
+
+func ReadMarkdown(r io.Reader) ([]*taskspb.DataRecord, error) {
+	var records []*taskspb.DataRecord
+	var errs []error
+	inBacklog := false
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, MAX_RECORD_SIZE)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		l := scanner.Text()
+		if match := markdownHeading.FindStringSubmatch(l); match != nil {
+			inBacklog = strings.EqualFold(match[1], markdownSectionName(taskspb.Status_BACKLOG))
+			continue
+		}
+		match := markdownItem.FindStringSubmatch(l)
+		if match == nil {
+			continue
+		}
+
+		task := &taskspb.Task{
+			Status:            taskspb.Status_TRACKING,
+			Priority:          taskspb.Priority_EVENTUALLY_DO,
+			MinutesToComplete: DEFAULT_MARKDOWN_MINUTES,
+		}
+		if match[1] != " " {
+			task.Status = taskspb.Status_COMPLETED
+		} else if inBacklog {
+			task.Status = taskspb.Status_BACKLOG
+		}
+		var name []string
+		for _, token := range strings.Fields(match[2]) {
+			if markdownTag.MatchString(token) {
+				task.Tags = append(task.Tags, token[1:])
+			} else {
+				name = append(name, token)
+			}
+		}
+		task.Name = strings.Join(name, " ")
+		if len(task.Tags) == 0 {
+			task.Tags = []string{DEFAULT_MARKDOWN_TAG}
+		}
+		if _, err := database.FromWireType(task); err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", lineNumber, err))
+			continue
+		}
+		records = append(records, &taskspb.DataRecord{
+			Record: &taskspb.DataRecord_Task{
+				Task: &taskspb.TaskRecord{TaskId: uint64(len(records) + 1), Task: task},
+			},
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading markdown: %w", err)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return records, nil
+}
+
+func markdownSectionName(status taskspb.Status) string {
+	name := strings.ToLower(status.String())
+	return strings.ToUpper(name[:1]) + name[1:]
+}
@@ -0,0 +1,70 @@
+package formats_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/WadeCappa/taskmaster/internal/formats"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkdownImport(t *testing.T) {
+	doc := strings.Join([]string{
+		"# Launch plan",
+		"",
+		"- [ ] write the blog post #marketing #launch",
+		"- [x] fix issue #123",
+		"  * [X] nested item #launch",
+		"- a plain bullet",
+		"",
+		"## Backlog",
+		"- [ ] translate the docs",
+	}, "\n")
+
+	res, err := formats.ReadMarkdown(strings.NewReader(doc))
+	require.NoError(t, err)
+	require.Len(t, res, 4)
+
+	post := res[0].GetTask().GetTask()
+	require.Equal(t, "write the blog post", post.GetName())
+	require.Equal(t, []string{"marketing", "launch"}, post.GetTags())
+	require.Equal(t, taskspb.Status_TRACKING, post.GetStatus())
+
+	issue := res[1].GetTask().GetTask()
+	require.Equal(t, "fix issue #123", issue.GetName())
+	require.Equal(t, taskspb.Status_COMPLETED, issue.GetStatus())
+	require.Equal(t, []string{formats.DEFAULT_MARKDOWN_TAG}, issue.GetTags())
+
+	require.Equal(t, taskspb.Status_COMPLETED, res[2].GetTask().GetTask().GetStatus())
+	require.Equal(t, taskspb.Status_BACKLOG, res[3].GetTask().GetTask().GetStatus())
+}
+
+func TestMarkdownRoundTrip(t *testing.T) {
+	records := []*taskspb.DataRecord{
+		taskRecord(1, &taskspb.Task{
+			Name:              "first",
+			MinutesToComplete: formats.DEFAULT_MARKDOWN_MINUTES,
+			Priority:          taskspb.Priority_EVENTUALLY_DO,
+			Status:            taskspb.Status_TRACKING,
+			Tags:              []string{"a", "b"},
+		}),
+		taskRecord(2, &taskspb.Task{
+			Name:              "second",
+			MinutesToComplete: formats.DEFAULT_MARKDOWN_MINUTES,
+			Priority:          taskspb.Priority_EVENTUALLY_DO,
+			Status:            taskspb.Status_BACKLOG,
+			Tags:              []string{"a"},
+		}),
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, formats.WriteMarkdown(&buf, records))
+	res, err := formats.ReadMarkdown(&buf)
+	require.NoError(t, err)
+	require.Len(t, res, len(records))
+	for i := range records {
+		require.Equal(t, records[i].String(), res[i].String())
+	}
+}
@@ -0,0 +1,351 @@
+package formats
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/WadeCappa/taskmaster/internal/database"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Tasks are written as org-mode headlines:
+//
+//	TODO/BACKLOG/DONE          <-> TRACKING/BACKLOG/COMPLETED, headlines without one of these are not tasks
+//	[#A]/[#B]/[#C]             <-> DO_BEFORE_SLEEP/DO_IMMEDIATELY/SHOULD_DO, anything else is EVENTUALLY_DO
+//	:tag:tag:                  <-> tags, DEFAULT_ORG_TAG when missing
+//	DEADLINE                   <-> due time
+//	TASKMASTER_ID property     <-> the id of the task in taskmaster
+//	MINUTES property           <-> minutes to complete, DEFAULT_ORG_MINUTES when missing
+//	CREATED property           <-> time created
+//	PREREQUISITES property     <-> prerequisites, as space separated ids
+//	body paragraphs            <-> addendums, starting with an inactive timestamp of when they were written
+//
+// Org timestamps have minute precision and are written in local time. Tags
+// that contain whitespace or colons cannot be represented.
+const (
+	ORG_TIMESTAMP_FORMAT = "2006-01-02 Mon 15:04"
+	ORG_DATE_FORMAT      = "2006-01-02 Mon"
+	ORG_TODO_KEYWORDS    = "#+TODO: TODO BACKLOG | DONE"
+	ORG_ID_PROPERTY      = "TASKMASTER_ID"
+	ORG_MINUTES_PROPERTY = "MINUTES"
+	ORG_CREATED_PROPERTY = "CREATED"
+	ORG_PREREQS_PROPERTY = "PREREQUISITES"
+	ORG_DEADLINE         = "DEADLINE:"
+	DEFAULT_ORG_TAG      = "org"
+	DEFAULT_ORG_MINUTES  = 30
+)
+
+var (
+	orgHeadline = regexp.MustCompile(`^(\*+)\s+(.*)$`)
+	orgPriority = regexp.MustCompile(`^\[#([A-Z])\]\s*`)
+	orgTags     = regexp.MustCompile(`\s+:([^\s]+):\s*$`)
+	orgProperty = regexp.MustCompile(`^:([^:\s]+):\s*(.*)$`)
+	orgDrawer   = regexp.MustCompile(`^:([\w-]+):$`)
+	orgDeadline = regexp.MustCompile(`DEADLINE:\s*<([^>]+)>`)
+	orgInactive = regexp.MustCompile(`^\[(\d{4}-\d{2}-\d{2} \w+(?: \d{2}:\d{2})?)\]\s*`)
+	orgKeywords = map[string]taskspb.Status{
+		"TODO":    taskspb.Status_TRACKING,
+		"BACKLOG": taskspb.Status_BACKLOG,
+		"DONE":    taskspb.Status_COMPLETED,
+	}
+)
+
+func WriteOrg(w io.Writer, records []*taskspb.DataRecord) error {
+	addendums := map[uint64][]*taskspb.Addendum{}
+	for _, r := range records {
+		if a := r.GetAddendum(); a != nil {
+			addendums[a.GetTaskId()] = append(addendums[a.GetTaskId()], a.GetAddendum())
+		}
+	}
+
+	writer := bufio.NewWriter(w)
+	fmt.Fprintln(writer, ORG_TODO_KEYWORDS)
+	for _, r := range records {
+		record := r.GetTask()
+		if record == nil {
+			continue
+		}
+		task := record.GetTask()
+
+		headline := []string{"*", orgKeyword(task.GetStatus())}
+		if letter := letterFromPriority(task.GetPriority()); letter != "" {
+			headline = append(headline, "[#"+letter+"]")
+		}
+		headline = append(headline, task.GetName())
+		if len(task.GetTags()) > 0 {
+			headline = append(headline, ":"+strings.Join(task.GetTags(), ":")+":")
+		}
+		fmt.Fprintln(writer, strings.Join(headline, " "))
+
+		if task.GetDueTime() != nil {
+			fmt.Fprintf(writer, "%s <%s>\n", ORG_DEADLINE, formatOrgTime(task.GetDueTime()))
+		}
+		fmt.Fprintln(writer, ":PROPERTIES:")
+		fmt.Fprintf(writer, ":%s: %d\n", ORG_ID_PROPERTY, record.GetTaskId())
+		fmt.Fprintf(writer, ":%s: %d\n", ORG_MINUTES_PROPERTY, task.GetMinutesToComplete())
+		if task.GetTimeCreated() != nil {
+			fmt.Fprintf(writer, ":%s: [%s]\n", ORG_CREATED_PROPERTY, formatOrgTime(task.GetTimeCreated()))
+		}
+		if len(task.GetPrerequisites()) > 0 {
+			prereqs := make([]string, len(task.GetPrerequisites()))
+			for i, p := range task.GetPrerequisites() {
+				prereqs[i] = strconv.FormatUint(p, 10)
+			}
+			fmt.Fprintf(writer, ":%s: %s\n", ORG_PREREQS_PROPERTY, strings.Join(prereqs, " "))
+		}
+		fmt.Fprintln(writer, ":END:")
+		for i, a := range addendums[record.GetTaskId()] {
+			if i > 0 {
+				fmt.Fprintln(writer)
+			}
+			if a.GetTimeCreated() != nil {
+				fmt.Fprintf(writer, "[%s] ", formatOrgTime(a.GetTimeCreated()))
+			}
+			fmt.Fprintln(writer, a.GetContent())
+		}
+	}
+	return writer.Flush()
+}
+
+// Reads every headline with a TODO, BACKLOG or DONE keyword as a task. Other
+// headlines and their bodies are ignored.
+func ReadOrg(r io.Reader) ([]*taskspb.DataRecord, error) {
+	var headlines []*orgHeadlineEntry
+	var current *orgHeadlineEntry
+	inDrawer := ""
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, MAX_RECORD_SIZE)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		l := scanner.Text()
+		if match := orgHeadline.FindStringSubmatch(l); match != nil {
+			current = nil
+			inDrawer = ""
+			if entry := parseOrgHeadline(match[2], lineNumber); entry != nil {
+				current = entry
+				headlines = append(headlines, entry)
+			}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+
+		trimmed := strings.TrimSpace(l)
+		switch {
+		case inDrawer != "" && strings.EqualFold(trimmed, ":END:"):
+			inDrawer = ""
+		case inDrawer == "PROPERTIES":
+			if match := orgProperty.FindStringSubmatch(trimmed); match != nil {
+				current.properties[strings.ToUpper(match[1])] = strings.TrimSpace(match[2])
+			}
+		case inDrawer != "":
+			// LOGBOOK and other drawers are not kept
+		case orgDrawer.MatchString(trimmed):
+			inDrawer = strings.ToUpper(orgDrawer.FindStringSubmatch(trimmed)[1])
+		case strings.HasPrefix(trimmed, ORG_DEADLINE) || strings.HasPrefix(trimmed, "SCHEDULED:") || strings.HasPrefix(trimmed, "CLOSED:"):
+			if match := orgDeadline.FindStringSubmatch(trimmed); match != nil {
+				current.deadline = match[1]
+			}
+		case trimmed == "":
+			current.paragraphs = append(current.paragraphs, "")
+		default:
+			if len(current.paragraphs) == 0 || current.paragraphs[len(current.paragraphs)-1] == "" {
+				current.paragraphs = append(current.paragraphs, trimmed)
+			} else {
+				current.paragraphs[len(current.paragraphs)-1] += "\n" + trimmed
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading org file: %w", err)
+	}
+
+	ids := map[uint64]int{}
+	var next uint64
+	for _, h := range headlines {
+		if id, err := strconv.ParseUint(h.properties[ORG_ID_PROPERTY], 10, 64); err == nil {
+			if previous, exists := ids[id]; exists {
+				return nil, fmt.Errorf("line %d: %s %d is already used on line %d", h.line, ORG_ID_PROPERTY, id, previous)
+			}
+			ids[id] = h.line
+			h.taskId = id
+			next = max(next, id)
+		}
+	}
+	for _, h := range headlines {
+		if h.taskId == 0 {
+			next++
+			h.taskId = next
+		}
+	}
+
+	var records []*taskspb.DataRecord
+	var errs []error
+	for _, h := range headlines {
+		task, addendums, err := h.toWire()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", h.line, err))
+			continue
+		}
+		records = append(records, &taskspb.DataRecord{
+			Record: &taskspb.DataRecord_Task{
+				Task: &taskspb.TaskRecord{TaskId: h.taskId, Task: task},
+			},
+		})
+		for _, a := range addendums {
+			records = append(records, &taskspb.DataRecord{
+				Record: &taskspb.DataRecord_Addendum{
+					Addendum: &taskspb.AddendumRecord{TaskId: h.taskId, Addendum: a},
+				},
+			})
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return records, nil
+}
+
+type orgHeadlineEntry struct {
+	line       int
+	taskId     uint64
+	status     taskspb.Status
+	priority   taskspb.Priority
+	name       string
+	tags       []string
+	deadline   string
+	properties map[string]string
+	// an empty paragraph separates the ones around it
+	paragraphs []string
+}
+
+// Returns nil for headlines that are not tasks
+func parseOrgHeadline(text string, line int) *orgHeadlineEntry {
+	keyword, rest, _ := strings.Cut(text, " ")
+	status, isTask := orgKeywords[keyword]
+	if !isTask {
+		return nil
+	}
+	entry := &orgHeadlineEntry{
+		line:       line,
+		status:     status,
+		priority:   taskspb.Priority_EVENTUALLY_DO,
+		properties: map[string]string{},
+	}
+	rest = strings.TrimSpace(rest)
+	if match := orgPriority.FindStringSubmatch(rest); match != nil {
+		entry.priority = priorityFromLetter(match[1])
+		rest = rest[len(match[0]):]
+	}
+	if match := orgTags.FindStringSubmatchIndex(rest); match != nil {
+		for _, t := range strings.Split(rest[match[2]:match[3]], ":") {
+			if t != "" {
+				entry.tags = append(entry.tags, t)
+			}
+		}
+		rest = rest[:match[0]]
+	}
+	entry.name = strings.TrimSpace(rest)
+	return entry
+}
+
+func (h *orgHeadlineEntry) toWire() (*taskspb.Task, []*taskspb.Addendum, error) {
+	var errs []error
+	task := &taskspb.Task{
+		Name:              h.name,
+		Status:            h.status,
+		Priority:          h.priority,
+		Tags:              h.tags,
+		MinutesToComplete: DEFAULT_ORG_MINUTES,
+	}
+	if len(task.Tags) == 0 {
+		task.Tags = []string{DEFAULT_ORG_TAG}
+	}
+	if m := h.properties[ORG_MINUTES_PROPERTY]; m != "" {
+		minutes, err := strconv.ParseUint(m, 10, 64)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s %q", ORG_MINUTES_PROPERTY, m))
+		}
+		task.MinutesToComplete = minutes
+	}
+	if c := h.properties[ORG_CREATED_PROPERTY]; c != "" {
+		created, err := parseOrgTime(strings.Trim(c, "[]<>"))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s: %w", ORG_CREATED_PROPERTY, err))
+		}
+		task.TimeCreated = created
+	}
+	for _, p := range strings.Fields(h.properties[ORG_PREREQS_PROPERTY]) {
+		prereq, err := strconv.ParseUint(p, 10, 64)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid prerequisite %q", p))
+			continue
+		}
+		task.Prerequisites = append(task.Prerequisites, prereq)
+	}
+	if h.deadline != "" {
+		due, err := parseOrgTime(h.deadline)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid deadline: %w", err))
+		}
+		task.DueTime = due
+	}
+	if len(errs) > 0 {
+		return nil, nil, errors.Join(errs...)
+	}
+	if _, err := database.FromWireType(task); err != nil {
+		return nil, nil, err
+	}
+
+	var addendums []*taskspb.Addendum
+	for _, p := range h.paragraphs {
+		if p == "" {
+			continue
+		}
+		addendum := &taskspb.Addendum{Content: p}
+		if match := orgInactive.FindStringSubmatch(p); match != nil {
+			if created, err := parseOrgTime(match[1]); err == nil {
+				addendum.TimeCreated = created
+				addendum.Content = p[len(match[0]):]
+			}
+		}
+		addendums = append(addendums, addendum)
+	}
+	return task, addendums, nil
+}
+
+func orgKeyword(status taskspb.Status) string {
+	for keyword, s := range orgKeywords {
+		if s == status {
+			return keyword
+		}
+	}
+	return "TODO"
+}
+
+func formatOrgTime(t *timestamppb.Timestamp) string {
+	return t.AsTime().In(time.Local).Format(ORG_TIMESTAMP_FORMAT)
+}
+
+// Accepts timestamps with or without a time of day, ignoring any repeaters
+func parseOrgTime(s string) (*timestamppb.Timestamp, error) {
+	fields := strings.Fields(s)
+	if len(fields) >= 3 {
+		if t, err := time.ParseInLocation(ORG_TIMESTAMP_FORMAT, strings.Join(fields[:3], " "), time.Local); err == nil {
+			return timestamppb.New(t), nil
+		}
+	}
+	if len(fields) >= 2 {
+		if t, err := time.ParseInLocation(ORG_DATE_FORMAT, strings.Join(fields[:2], " "), time.Local); err == nil {
+			return timestamppb.New(t), nil
+		}
+	}
+	return nil, fmt.Errorf("unrecognized timestamp %q", s)
+}
@@ -0,0 +1,84 @@
+package formats_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/WadeCappa/taskmaster/internal/formats"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestOrgRoundTrip(t *testing.T) {
+	records := []*taskspb.DataRecord{
+		taskRecord(4, &taskspb.Task{
+			Name:              "write the report",
+			MinutesToComplete: 90,
+			Priority:          taskspb.Priority_DO_BEFORE_SLEEP,
+			Status:            taskspb.Status_BACKLOG,
+			Tags:              []string{"work", "writing"},
+			TimeCreated:       timestamppb.New(time.Date(2025, 3, 1, 12, 0, 0, 0, time.Local)),
+			DueTime:           timestamppb.New(time.Date(2025, 4, 1, 9, 30, 0, 0, time.Local)),
+		}),
+		taskRecord(9, &taskspb.Task{
+			Name:              "send it",
+			MinutesToComplete: 5,
+			Priority:          taskspb.Priority_EVENTUALLY_DO,
+			Status:            taskspb.Status_COMPLETED,
+			Tags:              []string{"work"},
+			Prerequisites:     []uint64{4},
+		}),
+		{
+			Record: &taskspb.DataRecord_Addendum{
+				Addendum: &taskspb.AddendumRecord{
+					TaskId: 9,
+					Addendum: &taskspb.Addendum{
+						Content:     "sent to the team\nand to the manager",
+						TimeCreated: timestamppb.New(time.Date(2025, 4, 2, 8, 15, 0, 0, time.Local)),
+					},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, formats.WriteOrg(&buf, records))
+	res, err := formats.ReadOrg(&buf)
+	require.NoError(t, err)
+	require.Len(t, res, len(records))
+	for i := range records {
+		require.Equal(t, records[i].String(), res[i].String())
+	}
+}
+
+func TestOrgImportSkipsHeadlinesWithoutKeywords(t *testing.T) {
+	doc := strings.Join([]string{
+		"* Design notes",
+		"Some prose that is not a task.",
+		"** TODO [#B] Pick a database :backend:",
+		"   SCHEDULED: <2025-04-01 Tue>",
+		"   :LOGBOOK:",
+		"   - State \"TODO\" from \"\" [2025-03-01 Sat 10:00]",
+		"   :END:",
+		"   Compare postgres and sqlite.",
+		"** DONE Write the intro",
+	}, "\n")
+
+	res, err := formats.ReadOrg(strings.NewReader(doc))
+	require.NoError(t, err)
+	require.Len(t, res, 3)
+
+	first := res[0].GetTask().GetTask()
+	require.Equal(t, "Pick a database", first.GetName())
+	require.Equal(t, taskspb.Priority_DO_IMMEDIATELY, first.GetPriority())
+	require.Equal(t, []string{"backend"}, first.GetTags())
+	require.Nil(t, first.GetDueTime())
+	require.Equal(t, "Compare postgres and sqlite.", res[1].GetAddendum().GetAddendum().GetContent())
+
+	second := res[2].GetTask().GetTask()
+	require.Equal(t, taskspb.Status_COMPLETED, second.GetStatus())
+	require.Equal(t, []string{formats.DEFAULT_ORG_TAG}, second.GetTags())
+}
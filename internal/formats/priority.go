@@ -0,0 +1,28 @@
+package formats
+
+import taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+
+// Plain text formats like todo.txt and org-mode rank priorities by letter
+var letterPriorities = map[string]taskspb.Priority{
+	"A": taskspb.Priority_DO_BEFORE_SLEEP,
+	"B": taskspb.Priority_DO_IMMEDIATELY,
+	"C": taskspb.Priority_SHOULD_DO,
+}
+
+// Any letter past C is EVENTUALLY_DO
+func priorityFromLetter(letter string) taskspb.Priority {
+	if p, exists := letterPriorities[letter]; exists {
+		return p
+	}
+	return taskspb.Priority_EVENTUALLY_DO
+}
+
+// EVENTUALLY_DO has no letter
+func letterFromPriority(p taskspb.Priority) string {
+	for letter, priority := range letterPriorities {
+		if priority == p {
+			return letter
+		}
+	}
+	return ""
+}
@@ -0,0 +1,358 @@
+package formats
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/WadeCappa/taskmaster/internal/database"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Taskwarrior has no notion of some of our required fields, and we have no
+// notion of some of theirs. The mapping between the two is:
+//
+//	description        <-> name
+//	tags               <-> tags, a task without tags or a project is tagged DEFAULT_TASKWARRIOR_TAG
+//	project            <-> a tag prefixed with TASKWARRIOR_PROJECT_PREFIX
+//	annotations        <-> addendums
+//	depends            <-> prerequisites
+//	entry              <-> time created
+//	estimate (UDA)     <-> minutes to complete, DEFAULT_TASKWARRIOR_MINUTES when missing
+//	priority H/M/L/""  <-> DO_BEFORE_SLEEP/DO_IMMEDIATELY/SHOULD_DO/EVENTUALLY_DO
+//	status pending     <-> TRACKING
+//	status completed   <-> COMPLETED
+//	status waiting     <-> BACKLOG, exported with a wait date of TASKWARRIOR_SOMEDAY
+//	status recurring    -> TRACKING
+//	status deleted      -> not imported
+//
+// Taskmaster does not store uuids, exported uuids are derived from the task id.
+const (
+	TASKWARRIOR_TIME_FORMAT     = "20060102T150405Z"
+	TASKWARRIOR_PROJECT_PREFIX  = "project:"
+	DEFAULT_TASKWARRIOR_TAG     = "taskwarrior"
+	DEFAULT_TASKWARRIOR_MINUTES = 30
+)
+
+var TASKWARRIOR_SOMEDAY = time.Date(9999, 12, 30, 0, 0, 0, 0, time.UTC)
+
+type taskwarriorTask struct {
+	Uuid        string                  `json:"uuid"`
+	Description string                  `json:"description"`
+	Status      string                  `json:"status"`
+	Entry       string                  `json:"entry,omitempty"`
+	Wait        string                  `json:"wait,omitempty"`
+	Priority    string                  `json:"priority,omitempty"`
+	Project     string                  `json:"project,omitempty"`
+	Tags        []string                `json:"tags,omitempty"`
+	Annotations []taskwarriorAnnotation `json:"annotations,omitempty"`
+	Depends     taskwarriorDepends      `json:"depends,omitempty"`
+	Estimate    uint64                  `json:"estimate,omitempty"`
+}
+
+type taskwarriorAnnotation struct {
+	Entry       string `json:"entry"`
+	Description string `json:"description"`
+}
+
+// Taskwarrior 2.5 writes depends as a comma separated string, 2.6 onwards
+// writes it as an array. We read both and always write an array.
+type taskwarriorDepends []string
+
+func (d *taskwarriorDepends) UnmarshalJSON(b []byte) error {
+	var asString string
+	if err := json.Unmarshal(b, &asString); err == nil {
+		*d = nil
+		for _, u := range strings.Split(asString, ",") {
+			if u = strings.TrimSpace(u); u != "" {
+				*d = append(*d, u)
+			}
+		}
+		return nil
+	}
+	var asArray []string
+	if err := json.Unmarshal(b, &asArray); err != nil {
+		return fmt.Errorf("depends is neither a string nor an array: %w", err)
+	}
+	*d = asArray
+	return nil
+}
+
+// Writes records as the json array produced by `task export`
+func WriteTaskwarrior(w io.Writer, records []*taskspb.DataRecord) error {
+	annotations := map[uint64][]taskwarriorAnnotation{}
+	for _, r := range records {
+		if a := r.GetAddendum(); a != nil {
+			annotations[a.GetTaskId()] = append(annotations[a.GetTaskId()], taskwarriorAnnotation{
+				Entry:       formatTaskwarriorTime(a.GetAddendum().GetTimeCreated()),
+				Description: a.GetAddendum().GetContent(),
+			})
+		}
+	}
+
+	tasks := []taskwarriorTask{}
+	for _, r := range records {
+		record := r.GetTask()
+		if record == nil {
+			continue
+		}
+		task := record.GetTask()
+		tw := taskwarriorTask{
+			Uuid:        taskwarriorUuid(record.GetTaskId()),
+			Description: task.GetName(),
+			Entry:       formatTaskwarriorTime(task.GetTimeCreated()),
+			Priority:    taskwarriorPriority(task.GetPriority()),
+			Annotations: annotations[record.GetTaskId()],
+			Estimate:    task.GetMinutesToComplete(),
+		}
+		switch task.GetStatus() {
+		case taskspb.Status_COMPLETED:
+			tw.Status = "completed"
+		case taskspb.Status_BACKLOG:
+			tw.Status = "waiting"
+			tw.Wait = TASKWARRIOR_SOMEDAY.Format(TASKWARRIOR_TIME_FORMAT)
+		default:
+			tw.Status = "pending"
+		}
+		for _, t := range task.GetTags() {
+			if project, isProject := strings.CutPrefix(t, TASKWARRIOR_PROJECT_PREFIX); isProject && tw.Project == "" {
+				tw.Project = project
+				continue
+			}
+			tw.Tags = append(tw.Tags, t)
+		}
+		for _, p := range task.GetPrerequisites() {
+			tw.Depends = append(tw.Depends, taskwarriorUuid(p))
+		}
+		tasks = append(tasks, tw)
+	}
+
+	encoder := json.NewEncoder(w)
+	if err := encoder.Encode(tasks); err != nil {
+		return fmt.Errorf("encoding tasks: %w", err)
+	}
+	return nil
+}
+
+// Reads either the json array produced by `task export` or one json task per
+// line. Deleted tasks are skipped, along with any dependency on them.
+func ReadTaskwarrior(r io.Reader) ([]*taskspb.DataRecord, error) {
+	tasks, err := decodeTaskwarrior(r)
+	if err != nil {
+		return nil, fmt.Errorf("decoding taskwarrior json: %w", err)
+	}
+
+	ids := map[string]uint64{}
+	for _, tw := range tasks {
+		if tw.Status == "deleted" {
+			continue
+		}
+		if tw.Uuid == "" {
+			return nil, fmt.Errorf("found task %q without a uuid", tw.Description)
+		}
+		if _, exists := ids[tw.Uuid]; exists {
+			return nil, fmt.Errorf("found duplicate task %s", tw.Uuid)
+		}
+		ids[tw.Uuid] = uint64(len(ids) + 1)
+	}
+
+	var records []*taskspb.DataRecord
+	var errs []error
+	for _, tw := range tasks {
+		if tw.Status == "deleted" {
+			continue
+		}
+		taskId := ids[tw.Uuid]
+		task, err := taskwarriorToWire(tw, ids)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("task %s: %w", tw.Uuid, err))
+			continue
+		}
+		records = append(records, &taskspb.DataRecord{
+			Record: &taskspb.DataRecord_Task{
+				Task: &taskspb.TaskRecord{TaskId: taskId, Task: task},
+			},
+		})
+		for _, a := range tw.Annotations {
+			created, err := parseTaskwarriorTime(a.Entry)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("task %s: annotation: %w", tw.Uuid, err))
+				continue
+			}
+			records = append(records, &taskspb.DataRecord{
+				Record: &taskspb.DataRecord_Addendum{
+					Addendum: &taskspb.AddendumRecord{
+						TaskId: taskId,
+						Addendum: &taskspb.Addendum{
+							Content:     a.Description,
+							TimeCreated: created,
+						},
+					},
+				},
+			})
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return records, nil
+}
+
+func decodeTaskwarrior(r io.Reader) ([]taskwarriorTask, error) {
+	reader := bufio.NewReader(r)
+	for {
+		b, err := reader.Peek(1)
+		if err == io.EOF {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading input: %w", err)
+		}
+		if !strings.ContainsRune(" \t\r\n", rune(b[0])) {
+			break
+		}
+		reader.ReadByte()
+	}
+
+	decoder := json.NewDecoder(reader)
+	if b, _ := reader.Peek(1); b[0] == '[' {
+		var tasks []taskwarriorTask
+		if err := decoder.Decode(&tasks); err != nil {
+			return nil, fmt.Errorf("decoding task array: %w", err)
+		}
+		return tasks, nil
+	}
+
+	var tasks []taskwarriorTask
+	for {
+		var tw taskwarriorTask
+		err := decoder.Decode(&tw)
+		if err == io.EOF {
+			return tasks, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("decoding task %d: %w", len(tasks)+1, err)
+		}
+		tasks = append(tasks, tw)
+	}
+}
+
+func taskwarriorToWire(tw taskwarriorTask, ids map[string]uint64) (*taskspb.Task, error) {
+	var errs []error
+
+	var status taskspb.Status
+	switch tw.Status {
+	case "pending", "recurring", "":
+		status = taskspb.Status_TRACKING
+		if wait, err := parseTaskwarriorTime(tw.Wait); err == nil && wait != nil && wait.AsTime().After(time.Now()) {
+			status = taskspb.Status_BACKLOG
+		}
+	case "waiting":
+		status = taskspb.Status_BACKLOG
+	case "completed":
+		status = taskspb.Status_COMPLETED
+	default:
+		errs = append(errs, fmt.Errorf("unrecognized status %q", tw.Status))
+	}
+
+	var priority taskspb.Priority
+	switch tw.Priority {
+	case "H":
+		priority = taskspb.Priority_DO_BEFORE_SLEEP
+	case "M":
+		priority = taskspb.Priority_DO_IMMEDIATELY
+	case "L":
+		priority = taskspb.Priority_SHOULD_DO
+	case "":
+		priority = taskspb.Priority_EVENTUALLY_DO
+	default:
+		errs = append(errs, fmt.Errorf("unrecognized priority %q", tw.Priority))
+	}
+
+	created, err := parseTaskwarriorTime(tw.Entry)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	tags := append([]string{}, tw.Tags...)
+	if tw.Project != "" {
+		tags = append(tags, TASKWARRIOR_PROJECT_PREFIX+tw.Project)
+	}
+	if len(tags) == 0 {
+		tags = []string{DEFAULT_TASKWARRIOR_TAG}
+	}
+
+	var prereqs []uint64
+	for _, u := range tw.Depends {
+		if id, exists := ids[u]; exists {
+			prereqs = append(prereqs, id)
+		}
+	}
+
+	minutes := tw.Estimate
+	if minutes == 0 {
+		minutes = DEFAULT_TASKWARRIOR_MINUTES
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	task := &taskspb.Task{
+		Name:              tw.Description,
+		MinutesToComplete: minutes,
+		Priority:          priority,
+		Status:            status,
+		Tags:              tags,
+		Prerequisites:     prereqs,
+		TimeCreated:       created,
+	}
+	if _, err := database.FromWireType(task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func taskwarriorPriority(p taskspb.Priority) string {
+	switch p {
+	case taskspb.Priority_DO_BEFORE_SLEEP:
+		return "H"
+	case taskspb.Priority_DO_IMMEDIATELY:
+		return "M"
+	case taskspb.Priority_SHOULD_DO:
+		return "L"
+	default:
+		return ""
+	}
+}
+
+func formatTaskwarriorTime(t *timestamppb.Timestamp) string {
+	if t == nil {
+		return ""
+	}
+	return t.AsTime().UTC().Format(TASKWARRIOR_TIME_FORMAT)
+}
+
+func parseTaskwarriorTime(s string) (*timestamppb.Timestamp, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(TASKWARRIOR_TIME_FORMAT, s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time %q: %w", s, err)
+	}
+	return timestamppb.New(t), nil
+}
+
+// A stable, name based (version 5 style) uuid for a taskmaster task id
+func taskwarriorUuid(taskId uint64) string {
+	sum := sha1.Sum(fmt.Appendf(nil, "taskmaster:%d", taskId))
+	sum[6] = (sum[6] & 0x0f) | 0x50
+	sum[8] = (sum[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
@@ -0,0 +1,100 @@
+package formats_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/WadeCappa/taskmaster/internal/formats"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const taskwarriorExport = `[
+{"id":1,"description":"write report","entry":"20250301T120000Z","modified":"20250301T120000Z","priority":"H","project":"work","status":"pending","tags":["writing"],"uuid":"a1","annotations":[{"entry":"20250302T080000Z","description":"started outline"}],"urgency":8.1},
+{"id":2,"description":"send report","entry":"20250301T120500Z","status":"pending","uuid":"b2","depends":"a1,c3","urgency":2},
+{"id":0,"description":"old idea","entry":"20250101T000000Z","status":"deleted","uuid":"c3"},
+{"id":0,"description":"done thing","entry":"20250101T000000Z","end":"20250102T000000Z","status":"completed","priority":"L","uuid":"d4","estimate":45}
+]`
+
+func TestTaskwarriorImport(t *testing.T) {
+	records, err := formats.ReadTaskwarrior(strings.NewReader(taskwarriorExport))
+	require.NoError(t, err)
+
+	var tasks []*taskspb.TaskRecord
+	var addendums []*taskspb.AddendumRecord
+	for _, r := range records {
+		if r.GetTask() != nil {
+			tasks = append(tasks, r.GetTask())
+		} else {
+			addendums = append(addendums, r.GetAddendum())
+		}
+	}
+	require.Len(t, tasks, 3)
+	require.Len(t, addendums, 1)
+
+	report := tasks[0].GetTask()
+	require.Equal(t, "write report", report.GetName())
+	require.Equal(t, taskspb.Priority_DO_BEFORE_SLEEP, report.GetPriority())
+	require.Equal(t, taskspb.Status_TRACKING, report.GetStatus())
+	require.Equal(t, []string{"writing", "project:work"}, report.GetTags())
+	require.Equal(t, uint64(formats.DEFAULT_TASKWARRIOR_MINUTES), report.GetMinutesToComplete())
+	require.Equal(t, time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC), report.GetTimeCreated().AsTime())
+	require.Equal(t, tasks[0].GetTaskId(), addendums[0].GetTaskId())
+	require.Equal(t, "started outline", addendums[0].GetAddendum().GetContent())
+
+	send := tasks[1].GetTask()
+	require.Equal(t, []string{formats.DEFAULT_TASKWARRIOR_TAG}, send.GetTags())
+	require.Equal(t, taskspb.Priority_EVENTUALLY_DO, send.GetPriority())
+	// the dependency on the deleted task is dropped
+	require.Equal(t, []uint64{tasks[0].GetTaskId()}, send.GetPrerequisites())
+
+	done := tasks[2].GetTask()
+	require.Equal(t, taskspb.Status_COMPLETED, done.GetStatus())
+	require.Equal(t, taskspb.Priority_SHOULD_DO, done.GetPriority())
+	require.Equal(t, uint64(45), done.GetMinutesToComplete())
+}
+
+func TestTaskwarriorRoundTrip(t *testing.T) {
+	records := []*taskspb.DataRecord{
+		taskRecord(1, &taskspb.Task{
+			Name:              "first",
+			MinutesToComplete: 20,
+			Priority:          taskspb.Priority_DO_IMMEDIATELY,
+			Status:            taskspb.Status_BACKLOG,
+			Tags:              []string{"a", "project:home"},
+			TimeCreated:       timestamppb.New(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)),
+		}),
+		taskRecord(2, &taskspb.Task{
+			Name:              "second",
+			MinutesToComplete: 5,
+			Priority:          taskspb.Priority_EVENTUALLY_DO,
+			Status:            taskspb.Status_COMPLETED,
+			Tags:              []string{"b"},
+			Prerequisites:     []uint64{1},
+			TimeCreated:       timestamppb.New(time.Date(2025, 3, 2, 12, 0, 0, 0, time.UTC)),
+		}),
+		{
+			Record: &taskspb.DataRecord_Addendum{
+				Addendum: &taskspb.AddendumRecord{
+					TaskId: 2,
+					Addendum: &taskspb.Addendum{
+						Content:     "note",
+						TimeCreated: timestamppb.New(time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC)),
+					},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, formats.WriteTaskwarrior(&buf, records))
+	res, err := formats.ReadTaskwarrior(&buf)
+	require.NoError(t, err)
+	require.Len(t, res, len(records))
+	for i := range records {
+		require.Equal(t, records[i].String(), res[i].String())
+	}
+}
@@ -0,0 +1,185 @@
+package formats
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/WadeCappa/taskmaster/internal/database"
+	"github.com/WadeCappa/taskmaster/internal/types"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// A todo.txt line maps onto a task as follows:
+//
+//	x                  <-> COMPLETED
+//	status:backlog     <-> BACKLOG, otherwise TRACKING
+//	(A)/(B)/(C)        <-> DO_BEFORE_SLEEP/DO_IMMEDIATELY/SHOULD_DO, anything else is EVENTUALLY_DO
+//	pri:A              <-> priority of a completed task, which todo.txt does not allow in parentheses
+//	creation date      <-> time created
+//	+project           <-> tag "project"
+//	@context           <-> tag "@context"
+//	due:YYYY-MM-DD     <-> due time
+//	minutes:N          <-> minutes to complete, DEFAULT_TODOTXT_MINUTES when missing
+//	tm:N               <-> the id of the task in taskmaster
+//
+// A line without any projects or contexts is tagged DEFAULT_TODOTXT_TAG. All
+// other words, including unrecognized key:value pairs, make up the name.
+const (
+	TODOTXT_DATE_FORMAT     = "2006-01-02"
+	TODOTXT_ID_KEY          = "tm"
+	TODOTXT_DUE_KEY         = "due"
+	TODOTXT_MINUTES_KEY     = "minutes"
+	TODOTXT_STATUS_KEY      = "status"
+	TODOTXT_PRIORITY_KEY    = "pri"
+	TODOTXT_BACKLOG         = "backlog"
+	DEFAULT_TODOTXT_TAG     = "todotxt"
+	DEFAULT_TODOTXT_MINUTES = 30
+)
+
+// Parses a single non-empty todo.txt line, returning the taskmaster id if the
+// line carries one.
+func ParseTodoTxt(line string) (types.Option[uint64], *taskspb.Task, error) {
+	tokens := strings.Fields(line)
+	if len(tokens) == 0 {
+		return types.None[uint64](), nil, fmt.Errorf("empty line")
+	}
+
+	task := &taskspb.Task{
+		Status:            taskspb.Status_TRACKING,
+		Priority:          taskspb.Priority_EVENTUALLY_DO,
+		MinutesToComplete: DEFAULT_TODOTXT_MINUTES,
+	}
+	if tokens[0] == "x" {
+		task.Status = taskspb.Status_COMPLETED
+		tokens = tokens[1:]
+		// a completed task may have a completion date, which can only be
+		// followed by a creation date
+		if len(tokens) > 0 && isTodoTxtDate(tokens[0]) {
+			tokens = tokens[1:]
+		}
+	}
+	if len(tokens) > 0 && isTodoTxtPriority(tokens[0]) {
+		task.Priority = priorityFromLetter(tokens[0][1:2])
+		tokens = tokens[1:]
+	}
+	if len(tokens) > 0 && isTodoTxtDate(tokens[0]) {
+		created, _ := time.Parse(TODOTXT_DATE_FORMAT, tokens[0])
+		task.TimeCreated = timestamppb.New(created)
+		tokens = tokens[1:]
+	}
+
+	taskId := types.None[uint64]()
+	var name []string
+	for _, token := range tokens {
+		if tag, isProject := strings.CutPrefix(token, "+"); isProject && tag != "" {
+			task.Tags = append(task.Tags, tag)
+			continue
+		}
+		if len(token) > 1 && strings.HasPrefix(token, "@") {
+			task.Tags = append(task.Tags, token)
+			continue
+		}
+		key, value, isKeyValue := strings.Cut(token, ":")
+		if !isKeyValue || value == "" {
+			name = append(name, token)
+			continue
+		}
+		switch key {
+		case TODOTXT_ID_KEY:
+			id, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return types.None[uint64](), nil, fmt.Errorf("invalid task id %q", value)
+			}
+			taskId = types.Some(id)
+		case TODOTXT_DUE_KEY:
+			due, err := time.Parse(TODOTXT_DATE_FORMAT, value)
+			if err != nil {
+				return types.None[uint64](), nil, fmt.Errorf("invalid due date %q", value)
+			}
+			task.DueTime = timestamppb.New(due)
+		case TODOTXT_MINUTES_KEY:
+			minutes, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return types.None[uint64](), nil, fmt.Errorf("invalid minutes %q", value)
+			}
+			task.MinutesToComplete = minutes
+		case TODOTXT_STATUS_KEY:
+			if value != TODOTXT_BACKLOG {
+				return types.None[uint64](), nil, fmt.Errorf("unrecognized status %q", value)
+			}
+			if task.Status != taskspb.Status_COMPLETED {
+				task.Status = taskspb.Status_BACKLOG
+			}
+		case TODOTXT_PRIORITY_KEY:
+			task.Priority = priorityFromLetter(value)
+		default:
+			name = append(name, token)
+		}
+	}
+	task.Name = strings.Join(name, " ")
+	if len(task.Tags) == 0 {
+		task.Tags = []string{DEFAULT_TODOTXT_TAG}
+	}
+
+	if _, err := database.FromWireType(task); err != nil {
+		return types.None[uint64](), nil, err
+	}
+	return taskId, task, nil
+}
+
+// Writes a task as a todo.txt line carrying its taskmaster id
+func FormatTodoTxt(taskId uint64, task *taskspb.Task) string {
+	var tokens []string
+	letter := letterFromPriority(task.GetPriority())
+	if task.GetStatus() == taskspb.Status_COMPLETED {
+		tokens = append(tokens, "x")
+	} else if letter != "" {
+		tokens = append(tokens, "("+letter+")")
+	}
+	// todo.txt only allows a creation date on a completed task after the
+	// completion date, which we do not keep
+	if task.GetTimeCreated() != nil && task.GetStatus() != taskspb.Status_COMPLETED {
+		tokens = append(tokens, task.GetTimeCreated().AsTime().UTC().Format(TODOTXT_DATE_FORMAT))
+	}
+	if task.GetName() != "" {
+		tokens = append(tokens, task.GetName())
+	}
+	for _, t := range task.GetTags() {
+		if strings.HasPrefix(t, "@") {
+			tokens = append(tokens, t)
+		} else {
+			tokens = append(tokens, "+"+t)
+		}
+	}
+	if task.GetStatus() == taskspb.Status_COMPLETED && letter != "" {
+		tokens = append(tokens, TODOTXT_PRIORITY_KEY+":"+letter)
+	}
+	if task.GetStatus() == taskspb.Status_BACKLOG {
+		tokens = append(tokens, TODOTXT_STATUS_KEY+":"+TODOTXT_BACKLOG)
+	}
+	if task.GetDueTime() != nil {
+		tokens = append(tokens, TODOTXT_DUE_KEY+":"+task.GetDueTime().AsTime().UTC().Format(TODOTXT_DATE_FORMAT))
+	}
+	if task.GetMinutesToComplete() != DEFAULT_TODOTXT_MINUTES {
+		tokens = append(tokens, fmt.Sprintf("%s:%d", TODOTXT_MINUTES_KEY, task.GetMinutesToComplete()))
+	}
+	tokens = append(tokens, fmt.Sprintf("%s:%d", TODOTXT_ID_KEY, taskId))
+	return strings.Join(tokens, " ")
+}
+
+// Appends a taskmaster id marker to a line that does not have one yet
+func WithTodoTxtId(line string, taskId uint64) string {
+	return fmt.Sprintf("%s %s:%d", strings.TrimRight(line, " \t"), TODOTXT_ID_KEY, taskId)
+}
+
+func isTodoTxtDate(token string) bool {
+	_, err := time.Parse(TODOTXT_DATE_FORMAT, token)
+	return err == nil
+}
+
+func isTodoTxtPriority(token string) bool {
+	return len(token) == 3 && token[0] == '(' && token[2] == ')' && token[1] >= 'A' && token[1] <= 'Z'
+}
@@ -0,0 +1,52 @@
+package formats_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/WadeCappa/taskmaster/internal/formats"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTodoTxt(t *testing.T) {
+	taskId, task, err := formats.ParseTodoTxt("(A) 2025-03-01 call mom +family @phone due:2025-03-05 minutes:15 tm:42")
+	require.NoError(t, err)
+
+	id, exists := taskId.Unwrap()
+	require.True(t, exists)
+	require.Equal(t, uint64(42), id)
+	require.Equal(t, "call mom", task.GetName())
+	require.Equal(t, taskspb.Priority_DO_BEFORE_SLEEP, task.GetPriority())
+	require.Equal(t, taskspb.Status_TRACKING, task.GetStatus())
+	require.Equal(t, []string{"family", "@phone"}, task.GetTags())
+	require.Equal(t, uint64(15), task.GetMinutesToComplete())
+	require.Equal(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), task.GetTimeCreated().AsTime())
+	require.Equal(t, time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC), task.GetDueTime().AsTime())
+}
+
+func TestParseCompletedTodoTxt(t *testing.T) {
+	taskId, task, err := formats.ParseTodoTxt("x 2025-03-02 2025-03-01 file taxes see http://example.com pri:B")
+	require.NoError(t, err)
+
+	_, exists := taskId.Unwrap()
+	require.False(t, exists)
+	require.Equal(t, "file taxes see http://example.com", task.GetName())
+	require.Equal(t, taskspb.Status_COMPLETED, task.GetStatus())
+	require.Equal(t, taskspb.Priority_DO_IMMEDIATELY, task.GetPriority())
+	require.Equal(t, []string{formats.DEFAULT_TODOTXT_TAG}, task.GetTags())
+	require.Equal(t, uint64(formats.DEFAULT_TODOTXT_MINUTES), task.GetMinutesToComplete())
+}
+
+func TestTodoTxtRoundTrip(t *testing.T) {
+	for _, line := range []string{
+		"(A) 2025-03-01 call mom +family @phone due:2025-03-05 minutes:15 tm:42",
+		"x file taxes +home pri:C tm:7",
+		"someday learn the cello +music status:backlog tm:8",
+	} {
+		taskId, task, err := formats.ParseTodoTxt(line)
+		require.NoError(t, err)
+		id, _ := taskId.Unwrap()
+		require.Equal(t, line, formats.FormatTodoTxt(id, task))
+	}
+}
@@ -0,0 +1,13 @@
+package formats_test
+
+import (
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+)
+
+func taskRecord(taskId uint64, task *taskspb.Task) *taskspb.DataRecord {
+	return &taskspb.DataRecord{
+		Record: &taskspb.DataRecord_Task{
+			Task: &taskspb.TaskRecord{TaskId: taskId, Task: task},
+		},
+	}
+}
@@ -0,0 +1,107 @@
+package profiles
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const (
+	DEFAULT_PROFILE = "default"
+	CONFIG_DIR      = "taskmaster"
+	CONFIG_FILE     = "config.json"
+	// the file holds bearer tokens, so only its owner may read it
+	CONFIG_FILE_MODE = 0o600
+	CONFIG_DIR_MODE  = 0o700
+)
+
+type Profile struct {
+	Addr   string `json:"addr,omitempty"`
+	Secure bool   `json:"secure,omitempty"`
+	Token  string `json:"token,omitempty"`
+	// paths to PEM files, only used when Secure is set
+	CaCert     string `json:"caCert,omitempty"`
+	ClientCert string `json:"clientCert,omitempty"`
+	ClientKey  string `json:"clientKey,omitempty"`
+}
+
+type Config struct {
+	// the profile used when none is given with --profile
+	Current  string             `json:"current,omitempty"`
+	Profiles map[string]Profile `json:"profiles"`
+}
+
+// The config file under $XDG_CONFIG_HOME, or the platform's equivalent
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("finding config directory: %w", err)
+	}
+	return filepath.Join(dir, CONFIG_DIR, CONFIG_FILE), nil
+}
+
+// A missing file is an empty config, not an error
+func Load(path string) (*Config, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Config{Profiles: map[string]Profile{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading config: %w", err)
+	}
+	config := &Config{}
+	if err := json.Unmarshal(content, config); err != nil {
+		return nil, fmt.Errorf("parsing config %s: %w", path, err)
+	}
+	if config.Profiles == nil {
+		config.Profiles = map[string]Profile{}
+	}
+	return config, nil
+}
+
+// Writes the config through a temporary file, so a failed write never
+// leaves a truncated config behind
+func (c *Config) Save(path string) error {
+	content, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding config: %w", err)
+	}
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, CONFIG_DIR_MODE); err != nil {
+		return fmt.Errorf("creating config directory: %w", err)
+	}
+	file, err := os.CreateTemp(dir, CONFIG_FILE+".*")
+	if err != nil {
+		return fmt.Errorf("creating config: %w", err)
+	}
+	defer os.Remove(file.Name())
+	if err := file.Chmod(CONFIG_FILE_MODE); err != nil {
+		file.Close()
+		return fmt.Errorf("restricting config permissions: %w", err)
+	}
+	if _, err := file.Write(append(content, '\n')); err != nil {
+		file.Close()
+		return fmt.Errorf("writing config: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("writing config: %w", err)
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("replacing config: %w", err)
+	}
+	return nil
+}
+
+// The name of the profile to use when the caller did not ask for one
+func (c *Config) Active(requested string) string {
+	if requested != "" {
+		return requested
+	}
+	if c.Current != "" {
+		return c.Current
+	}
+	return DEFAULT_PROFILE
+}
@@ -0,0 +1,53 @@
+package profiles_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/WadeCappa/taskmaster/internal/profiles"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMissingConfigIsEmpty(t *testing.T) {
+	config, err := profiles.Load(filepath.Join(t.TempDir(), "config.json"))
+	require.NoError(t, err)
+	require.Empty(t, config.Profiles)
+	require.Equal(t, profiles.DEFAULT_PROFILE, config.Active(""))
+}
+
+func TestConfigRoundTrips(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "taskmaster", "config.json")
+	config := &profiles.Config{
+		Current: "work",
+		Profiles: map[string]profiles.Profile{
+			"work":  {Addr: "tasks.example.com:443", Secure: true, Token: "secret"},
+			"local": {Addr: "localhost:6100"},
+		},
+	}
+	require.NoError(t, config.Save(path))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(profiles.CONFIG_FILE_MODE), info.Mode().Perm())
+
+	loaded, err := profiles.Load(path)
+	require.NoError(t, err)
+	require.Equal(t, config, loaded)
+	require.Equal(t, "work", loaded.Active(""))
+	require.Equal(t, "local", loaded.Active("local"))
+
+	// saving over an existing file keeps it private
+	require.NoError(t, os.Chmod(path, 0o644))
+	require.NoError(t, loaded.Save(path))
+	info, err = os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(profiles.CONFIG_FILE_MODE), info.Mode().Perm())
+}
+
+func TestMalformedConfigIsAnError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+	_, err := profiles.Load(path)
+	require.Error(t, err)
+}
@@ -0,0 +1,143 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/WadeCappa/taskmaster/internal/auth"
+)
+
+const (
+	DEFAULT_READ_RATE   = 20
+	DEFAULT_READ_BURST  = 100
+	DEFAULT_WRITE_RATE  = 5
+	DEFAULT_WRITE_BURST = 50
+	// buckets that have refilled are dropped this often, a full bucket is the
+	// same as no bucket at all
+	SWEEP_INTERVAL = time.Minute
+)
+
+// Reads and writes are limited separately, so that a script stuck writing
+// does not also stop its user from reading
+type Class int
+
+const (
+	Read Class = iota
+	Write
+)
+
+func (c Class) String() string {
+	if c == Read {
+		return "read"
+	}
+	return "write"
+}
+
+type Bucket struct {
+	// requests per second, requests of a class are not limited when this is zero
+	Rate float64
+	// how many requests can be made at once after a quiet period
+	Burst int
+}
+
+type Options struct {
+	Read  Bucket
+	Write Bucket
+}
+
+func DefaultOptions() Options {
+	return Options{
+		Read:  Bucket{Rate: DEFAULT_READ_RATE, Burst: DEFAULT_READ_BURST},
+		Write: Bucket{Rate: DEFAULT_WRITE_RATE, Burst: DEFAULT_WRITE_BURST},
+	}
+}
+
+// Returned when a user has run out of requests of a class
+type LimitedError struct {
+	Class Class
+	// when the next request of this class will be allowed
+	RetryAfter time.Duration
+}
+
+func (e *LimitedError) Error() string {
+	return fmt.Sprintf("too many %s requests, retry in %s", e.Class, e.RetryAfter.Round(time.Millisecond))
+}
+
+type bucketKey struct {
+	userId auth.UserId
+	class  Class
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// A token bucket for each user and class of request
+type Limiter struct {
+	options Options
+
+	lock      sync.Mutex
+	buckets   map[bucketKey]*bucket
+	lastSweep time.Time
+}
+
+func NewLimiter(options Options) *Limiter {
+	return &Limiter{
+		options: options,
+		buckets: map[bucketKey]*bucket{},
+	}
+}
+
+// Takes a token from the user's bucket for class, or returns a LimitedError
+// saying how long until one is available
+func (l *Limiter) Allow(userId auth.UserId, class Class, now time.Time) error {
+	limit := l.options.Read
+	if class == Write {
+		limit = l.options.Write
+	}
+	if limit.Rate <= 0 {
+		return nil
+	}
+	burst := math.Max(float64(limit.Burst), 1)
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.sweep(now)
+
+	key := bucketKey{userId: userId, class: class}
+	b, exists := l.buckets[key]
+	if !exists {
+		b = &bucket{tokens: burst, updated: now}
+		l.buckets[key] = b
+	}
+	if elapsed := now.Sub(b.updated); elapsed > 0 {
+		b.tokens = math.Min(burst, b.tokens+elapsed.Seconds()*limit.Rate)
+		b.updated = now
+	}
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / limit.Rate * float64(time.Second))
+		return &LimitedError{Class: class, RetryAfter: wait}
+	}
+	b.tokens--
+	return nil
+}
+
+// Expects the lock to be held
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < SWEEP_INTERVAL {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		limit := l.options.Read
+		if key.class == Write {
+			limit = l.options.Write
+		}
+		if b.tokens+now.Sub(b.updated).Seconds()*limit.Rate >= float64(limit.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
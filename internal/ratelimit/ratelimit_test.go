@@ -0,0 +1,40 @@
+package ratelimit_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/WadeCappa/taskmaster/internal/ratelimit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBurstThenRefill(t *testing.T) {
+	limiter := ratelimit.NewLimiter(ratelimit.Options{
+		Write: ratelimit.Bucket{Rate: 2, Burst: 3},
+	})
+	now := time.Now()
+
+	for range 3 {
+		require.NoError(t, limiter.Allow(1, ratelimit.Write, now))
+	}
+	err := limiter.Allow(1, ratelimit.Write, now)
+	var limited *ratelimit.LimitedError
+	require.True(t, errors.As(err, &limited))
+	require.Equal(t, ratelimit.Write, limited.Class)
+	require.Equal(t, 500*time.Millisecond, limited.RetryAfter)
+
+	// other users and other classes have their own buckets
+	require.NoError(t, limiter.Allow(2, ratelimit.Write, now))
+	require.NoError(t, limiter.Allow(1, ratelimit.Read, now))
+
+	require.NoError(t, limiter.Allow(1, ratelimit.Write, now.Add(500*time.Millisecond)))
+	require.Error(t, limiter.Allow(1, ratelimit.Write, now.Add(500*time.Millisecond)))
+
+	// a long quiet period only refills up to the burst
+	later := now.Add(time.Hour)
+	for range 3 {
+		require.NoError(t, limiter.Allow(1, ratelimit.Write, later))
+	}
+	require.Error(t, limiter.Allow(1, ratelimit.Write, later))
+}
@@ -0,0 +1,182 @@
+package server_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"testing"
+
+	"github.com/WadeCappa/taskmaster/internal/auth"
+	"github.com/WadeCappa/taskmaster/internal/database"
+	"github.com/WadeCappa/taskmaster/internal/types"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// a postgres url to run the access tests against, they are skipped when
+	// it is unset. Tests use random user ids, so the database can be reused.
+	TEST_POSTGRES_ENV = "TASKMASTER_TEST_POSTGRES"
+	SCHEMA_PATH       = "../../schema.sql"
+)
+
+func testDatabase(t *testing.T) *database.Database {
+	url := os.Getenv(TEST_POSTGRES_ENV)
+	if url == "" {
+		t.Skipf("%s is not set", TEST_POSTGRES_ENV)
+	}
+	schema, err := os.ReadFile(SCHEMA_PATH)
+	require.NoError(t, err)
+	conn, err := pgx.Connect(context.Background(), url)
+	require.NoError(t, err)
+	defer conn.Close(context.Background())
+	_, err = conn.Exec(context.Background(), string(schema))
+	require.NoError(t, err)
+	return database.NewDatabase(url)
+}
+
+func randomUser() auth.UserId {
+	return auth.UserId(rand.Int64())
+}
+
+func putTask(t *testing.T, db *database.Database, userId auth.UserId, workspace types.Option[database.WorkspaceId]) database.TaskId {
+	task, err := database.FromWireType(&taskspb.Task{
+		Name:              "write tests",
+		MinutesToComplete: 30,
+		Tags:              []string{"work"},
+	})
+	require.NoError(t, err)
+	taskId, err := db.Put(context.Background(), userId, workspace, types.None[database.TaskId](), task)
+	require.NoError(t, err)
+	return taskId
+}
+
+func share(t *testing.T, db *database.Database, owner auth.UserId, taskId database.TaskId, userId auth.UserId, role taskspb.Role) {
+	s, err := database.ShareFromWireType(&taskspb.ShareRequest{
+		Target: &taskspb.ShareTarget{Target: &taskspb.ShareTarget_TaskId{TaskId: uint64(taskId)}},
+		UserId: uint64(userId),
+		Role:   role,
+	}, owner)
+	require.NoError(t, err)
+	require.NoError(t, db.Share(context.Background(), owner, s))
+}
+
+func requireCode(t *testing.T, code codes.Code, err error) {
+	require.Equal(t, code, status.Code(intercept(fmt.Errorf("handling request: %w", err))))
+}
+
+func TestDescribeNeedsAccess(t *testing.T) {
+	db := testDatabase(t)
+	ctx := context.Background()
+	owner, viewer, stranger := randomUser(), randomUser(), randomUser()
+	taskId := putTask(t, db, owner, types.None[database.WorkspaceId]())
+
+	_, err := db.Describe(ctx, stranger, taskId)
+	requireCode(t, codes.PermissionDenied, err)
+
+	share(t, db, owner, taskId, viewer, taskspb.Role_VIEWER)
+	described, err := db.Describe(ctx, viewer, taskId)
+	require.NoError(t, err)
+	require.Equal(t, database.Access{Owner: owner, Role: database.Viewer}, described.First.Access())
+
+	_, err = db.Describe(ctx, stranger, taskId+1_000_000)
+	requireCode(t, codes.NotFound, err)
+}
+
+func TestMarkNeedsEditor(t *testing.T) {
+	db := testDatabase(t)
+	ctx := context.Background()
+	owner, viewer, editor, stranger := randomUser(), randomUser(), randomUser(), randomUser()
+	taskId := putTask(t, db, owner, types.None[database.WorkspaceId]())
+	share(t, db, owner, taskId, viewer, taskspb.Role_VIEWER)
+	share(t, db, owner, taskId, editor, taskspb.Role_EDITOR)
+
+	requireCode(t, codes.PermissionDenied, db.Mark(ctx, stranger, taskId, "done?"))
+	requireCode(t, codes.PermissionDenied, db.Mark(ctx, viewer, taskId, "done?"))
+	require.NoError(t, db.Mark(ctx, editor, taskId, "halfway there"))
+	require.NoError(t, db.Mark(ctx, owner, taskId, "nearly done"))
+
+	described, err := db.Describe(ctx, viewer, taskId)
+	require.NoError(t, err)
+	require.Len(t, described.Second, 2)
+}
+
+func TestSetStatusNeedsEditor(t *testing.T) {
+	db := testDatabase(t)
+	ctx := context.Background()
+	owner, viewer, editor, stranger := randomUser(), randomUser(), randomUser(), randomUser()
+	taskId := putTask(t, db, owner, types.None[database.WorkspaceId]())
+	share(t, db, owner, taskId, viewer, taskspb.Role_VIEWER)
+	share(t, db, owner, taskId, editor, taskspb.Role_EDITOR)
+
+	requireCode(t, codes.PermissionDenied, db.SetStatus(ctx, database.Completed, taskId, stranger))
+	requireCode(t, codes.PermissionDenied, db.SetStatus(ctx, database.Completed, taskId, viewer))
+	require.NoError(t, db.SetStatus(ctx, database.Completed, taskId, editor))
+
+	described, err := db.Describe(ctx, owner, taskId)
+	require.NoError(t, err)
+	require.True(t, described.First.HasStatus(database.Completed))
+}
+
+func TestWorkspaceRoles(t *testing.T) {
+	db := testDatabase(t)
+	ctx := context.Background()
+	owner, member, viewer := randomUser(), randomUser(), randomUser()
+	inviteCode := fmt.Sprintf("invite-%d", rand.Int64())
+	workspaceId, err := db.CreateWorkspace(ctx, owner, "team", inviteCode)
+	require.NoError(t, err)
+	_, err = db.JoinWorkspace(ctx, member, inviteCode)
+	require.NoError(t, err)
+	_, err = db.JoinWorkspace(ctx, viewer, inviteCode)
+	require.NoError(t, err)
+	require.NoError(t, db.SetWorkspaceRole(ctx, owner, workspaceId, viewer, database.WorkspaceViewer))
+	taskId := putTask(t, db, owner, types.Some(workspaceId))
+
+	described, err := db.Describe(ctx, member, taskId)
+	require.NoError(t, err)
+	require.Equal(t, database.Editor, described.First.Access().Role)
+	require.NoError(t, db.Mark(ctx, member, taskId, "picked this up"))
+	require.NoError(t, db.SetStatus(ctx, database.Completed, taskId, member))
+	_, err = db.Put(ctx, member, types.None[database.WorkspaceId](), types.Some(taskId), described.First)
+	require.NoError(t, err)
+
+	// members edit the shared backlog, but only owners share its tasks
+	s, err := database.ShareFromWireType(&taskspb.ShareRequest{
+		Target: &taskspb.ShareTarget{Target: &taskspb.ShareTarget_TaskId{TaskId: uint64(taskId)}},
+		UserId: uint64(randomUser()),
+		Role:   taskspb.Role_VIEWER,
+	}, member)
+	require.NoError(t, err)
+	requireCode(t, codes.PermissionDenied, db.Share(ctx, member, s))
+
+	_, err = db.Describe(ctx, viewer, taskId)
+	require.NoError(t, err)
+	requireCode(t, codes.PermissionDenied, db.Mark(ctx, viewer, taskId, "done?"))
+	requireCode(t, codes.PermissionDenied, db.SetStatus(ctx, database.Tracking, taskId, viewer))
+}
+
+func TestSharingATagOnlySharesTheOwnersTag(t *testing.T) {
+	db := testDatabase(t)
+	ctx := context.Background()
+	owner, other, viewer := randomUser(), randomUser(), randomUser()
+	putTask(t, db, other, types.None[database.WorkspaceId]())
+	work := &taskspb.ShareTarget{Target: &taskspb.ShareTarget_Tag{Tag: "work"}}
+
+	// only the other user has a work tag, so there is nothing of owner's to share
+	s, err := database.ShareFromWireType(&taskspb.ShareRequest{Target: work, UserId: uint64(viewer), Role: taskspb.Role_VIEWER}, owner)
+	require.NoError(t, err)
+	requireCode(t, codes.InvalidArgument, db.Share(ctx, owner, s))
+	shares, err := db.GetShares(ctx, owner)
+	require.NoError(t, err)
+	require.Empty(t, shares)
+
+	putTask(t, db, owner, types.None[database.WorkspaceId]())
+	require.NoError(t, db.Share(ctx, owner, s))
+	shares, err = db.GetShares(ctx, owner)
+	require.NoError(t, err)
+	require.Len(t, shares, 1)
+}
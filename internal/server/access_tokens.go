@@ -0,0 +1,73 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/WadeCappa/taskmaster/internal/auth"
+	"github.com/WadeCappa/taskmaster/internal/database"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"google.golang.org/grpc"
+)
+
+func (s *tasksServer) CreateAccessToken(
+	ctx context.Context,
+	request *taskspb.CreateAccessTokenRequest,
+) (*taskspb.CreateAccessTokenResponse, error) {
+	userId, err := s.auth.GetUserId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting user Id: %w", err)
+	}
+	accessToken, err := database.AccessTokenFromWireType(request, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("converting access token from wire type: %w", err)
+	}
+
+	token, hash, err := auth.NewAccessToken()
+	if err != nil {
+		return nil, fmt.Errorf("creating access token: %w", err)
+	}
+	tokenId, err := s.db.CreateAccessToken(ctx, userId, accessToken, hash)
+	if err != nil {
+		return nil, fmt.Errorf("storing access token: %w", err)
+	}
+	return &taskspb.CreateAccessTokenResponse{
+		TokenId: uint64(tokenId),
+		Token:   token,
+	}, nil
+}
+
+func (s *tasksServer) ListAccessTokens(
+	request *taskspb.ListAccessTokensRequest,
+	stream grpc.ServerStreamingServer[taskspb.ListAccessTokensResponse],
+) error {
+	userId, err := s.auth.GetUserId(stream.Context())
+	if err != nil {
+		return fmt.Errorf("getting user Id: %w", err)
+	}
+	tokens, err := s.db.GetAccessTokens(stream.Context(), userId)
+	if err != nil {
+		return fmt.Errorf("finding access tokens: %w", err)
+	}
+	for _, t := range tokens {
+		if err := stream.Send(t.Second.ToWireType(t.First)); err != nil {
+			return fmt.Errorf("sending access token: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *tasksServer) RevokeAccessToken(
+	ctx context.Context,
+	request *taskspb.RevokeAccessTokenRequest,
+) (*taskspb.RevokeAccessTokenResponse, error) {
+	userId, err := s.auth.GetUserId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting user Id: %w", err)
+	}
+	if err := s.db.RevokeAccessToken(ctx, userId, database.AccessTokenId(request.GetTokenId())); err != nil {
+		return nil, fmt.Errorf("revoking access token: %w", err)
+	}
+	return &taskspb.RevokeAccessTokenResponse{}, nil
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/WadeCappa/taskmaster/internal/auth"
+	"github.com/WadeCappa/taskmaster/internal/database"
+	"github.com/WadeCappa/taskmaster/internal/formats"
+)
+
+const (
+	CALENDAR_PATH        = "/v1/calendar.ics"
+	CALENDAR_TOKEN_PARAM = "token"
+)
+
+type calendarHandler struct {
+	db   *database.Database
+	auth *auth.Auth
+}
+
+// Serves the caller's tasks as an iCalendar feed. Calendar apps often cannot
+// set headers, so the bearer token is also accepted as the password of basic
+// auth or as a token query parameter.
+func NewCalendarHandler(
+	db *database.Database,
+	auth *auth.Auth,
+) http.Handler {
+	return &calendarHandler{
+		db:   db,
+		auth: auth,
+	}
+}
+
+func (h *calendarHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userId, err := h.auth.GetUserIdFromToken(r.Context(), bearerFromRequest(r))
+	if err != nil {
+		w.Header().Set("WWW-Authenticate", `Basic realm="taskmaster"`)
+		noteError(r.Context(), err)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	noteUser(r.Context(), userId)
+
+	data, err := h.db.Export(r.Context(), userId)
+	if err != nil {
+		noteError(r.Context(), fmt.Errorf("exporting calendar: %w", err))
+		http.Error(w, "failed to read tasks", http.StatusInternalServerError)
+		return
+	}
+
+	var body bytes.Buffer
+	if err := formats.WriteICalendar(&body, toRecords(data)); err != nil {
+		noteError(r.Context(), fmt.Errorf("writing calendar: %w", err))
+		http.Error(w, "failed to write calendar", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Write(body.Bytes())
+}
+
+func bearerFromRequest(r *http.Request) string {
+	if _, password, ok := r.BasicAuth(); ok {
+		return password
+	}
+	if header := r.Header.Get("Authorization"); header != "" {
+		return header
+	}
+	return r.URL.Query().Get(CALENDAR_TOKEN_PARAM)
+}
@@ -0,0 +1,124 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	"github.com/WadeCappa/taskmaster/internal/auth"
+	"github.com/WadeCappa/taskmaster/internal/database"
+	"github.com/WadeCappa/taskmaster/internal/ratelimit"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const (
+	INTERNAL_ERROR_MESSAGE = "internal error"
+)
+
+// Converts errors returned by the tasks service into grpc statuses.
+func UnaryErrorInterceptor(
+	ctx context.Context,
+	request any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	response, err := handler(ctx, request)
+	if err != nil {
+		return nil, toStatus(err).Err()
+	}
+	return response, nil
+}
+
+// Converts errors returned by the tasks service into grpc statuses.
+func StreamErrorInterceptor(
+	srv any,
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if err := handler(srv, stream); err != nil {
+		return toStatus(err).Err()
+	}
+	return nil
+}
+
+// Domain errors keep only their own message, the context that was wrapped
+// around them is left to the request logs rather than sent to the client.
+func toStatus(err error) *status.Status {
+	var unauthenticated *auth.UnauthenticatedError
+	var insufficientScope *auth.InsufficientScopeError
+	var notFound *database.NotFoundError
+	var permissionDenied *database.PermissionDeniedError
+	var invalid *database.InvalidArgumentError
+	var failedPrecondition *database.FailedPreconditionError
+	var quotaExceeded *database.QuotaExceededError
+	var limited *ratelimit.LimitedError
+	switch {
+	case errors.As(err, &unauthenticated):
+		return status.New(codes.Unauthenticated, unauthenticated.Error())
+	case errors.As(err, &insufficientScope):
+		return status.New(codes.PermissionDenied, insufficientScope.Error())
+	case errors.As(err, &notFound):
+		return status.New(codes.NotFound, notFound.Error())
+	case errors.As(err, &permissionDenied):
+		return status.New(codes.PermissionDenied, permissionDenied.Error())
+	case errors.As(err, &invalid):
+		return badRequest(invalid)
+	case errors.As(err, &failedPrecondition):
+		return status.New(codes.FailedPrecondition, failedPrecondition.Error())
+	case errors.As(err, &quotaExceeded):
+		return withDetails(status.New(codes.ResourceExhausted, quotaExceeded.Error()), &errdetails.QuotaFailure{
+			Violations: []*errdetails.QuotaFailure_Violation{{
+				Subject:     quotaExceeded.Resource,
+				Description: quotaExceeded.Error(),
+			}},
+		})
+	case errors.As(err, &limited):
+		return withDetails(status.New(codes.ResourceExhausted, limited.Error()), &errdetails.RetryInfo{
+			RetryDelay: durationpb.New(limited.RetryAfter),
+		})
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err)
+	}
+
+	// errors that already carry a status, like ours or one from a grpc call,
+	// without whatever was wrapped around them
+	var withStatus interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &withStatus) {
+		return withStatus.GRPCStatus()
+	}
+	// the request logs have the whole error, clients only learn that it failed
+	return status.New(codes.Internal, INTERNAL_ERROR_MESSAGE)
+}
+
+func badRequest(invalid *database.InvalidArgumentError) *status.Status {
+	details := &errdetails.BadRequest{}
+	for _, v := range invalid.Violations {
+		details.FieldViolations = append(details.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		})
+	}
+	return withDetails(status.New(codes.InvalidArgument, invalid.Error()), details)
+}
+
+// Falls back to the bare status if details cannot be attached
+func withDetails(bare *status.Status, details protoadapt.MessageV1) *status.Status {
+	detailed, err := bare.WithDetails(details)
+	if err != nil {
+		return bare
+	}
+	return detailed
+}
+
+// Whether err means the caller cannot see something, because it does not exist
+// or because they have no access to it
+func hidden(err error) bool {
+	var notFound *database.NotFoundError
+	var permissionDenied *database.PermissionDeniedError
+	return errors.As(err, &notFound) || errors.As(err, &permissionDenied)
+}
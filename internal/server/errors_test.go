@@ -0,0 +1,74 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/WadeCappa/taskmaster/internal/database"
+	"github.com/WadeCappa/taskmaster/internal/server"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func intercept(err error) error {
+	_, intercepted := server.UnaryErrorInterceptor(
+		context.Background(),
+		nil,
+		&grpc.UnaryServerInfo{},
+		func(ctx context.Context, request any) (any, error) {
+			return nil, err
+		},
+	)
+	return intercepted
+}
+
+func TestDomainErrorsMapToStatusCodes(t *testing.T) {
+	err := intercept(fmt.Errorf("finding task: %w", &database.NotFoundError{Resource: "task", Id: 7}))
+	require.Equal(t, codes.NotFound, status.Code(err))
+	require.Equal(t, "task 7 does not exist", status.Convert(err).Message())
+
+	err = intercept(fmt.Errorf("updating task status: %w", &database.PermissionDeniedError{Resource: "task", Id: 7}))
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	err = intercept(fmt.Errorf("leaving workspace: %w", &database.FailedPreconditionError{Reason: "workspaces need at least one owner"}))
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	require.Equal(t, "workspaces need at least one owner", status.Convert(err).Message())
+
+	err = intercept(fmt.Errorf("putting task: %w", &database.QuotaExceededError{Resource: "tasks", Limit: 10}))
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.Equal(t, "quota of 10 tasks exceeded", status.Convert(err).Message())
+
+	err = intercept(fmt.Errorf("calling db: %w", context.DeadlineExceeded))
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+
+	err = intercept(errors.New("connecting to postgres: connection refused"))
+	require.Equal(t, codes.Internal, status.Code(err))
+	require.Equal(t, server.INTERNAL_ERROR_MESSAGE, status.Convert(err).Message())
+
+	err = intercept(fmt.Errorf("getting user Id: %w", status.Error(codes.Unavailable, "auth is down")))
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	require.Equal(t, "auth is down", status.Convert(err).Message())
+}
+
+func TestValidationErrorsAreReturnedAsFieldViolations(t *testing.T) {
+	_, err := database.FromWireType(&taskspb.Task{Tags: []string{"work"}})
+	require.Error(t, err)
+
+	converted := status.Convert(intercept(fmt.Errorf("putting task: %w", database.WithFieldPrefix(err, "task"))))
+	require.Equal(t, codes.InvalidArgument, converted.Code())
+	require.Len(t, converted.Details(), 1)
+	badRequest, ok := converted.Details()[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+
+	var fields []string
+	for _, v := range badRequest.GetFieldViolations() {
+		fields = append(fields, v.GetField())
+	}
+	require.Equal(t, []string{"task.name", "task.minutes_to_complete"}, fields)
+}
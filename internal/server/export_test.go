@@ -0,0 +1,132 @@
+package server_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/WadeCappa/taskmaster/internal/auth"
+	"github.com/WadeCappa/taskmaster/internal/database"
+	"github.com/WadeCappa/taskmaster/internal/server"
+	"github.com/WadeCappa/taskmaster/internal/types"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+type exportStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	records []*taskspb.DataRecord
+}
+
+func (s *exportStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *exportStream) Send(response *taskspb.ExportDataResponse) error {
+	s.records = append(s.records, response.GetRecord())
+	return nil
+}
+
+type importStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	records  []*taskspb.DataRecord
+	response *taskspb.ImportDataResponse
+}
+
+func (s *importStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *importStream) Recv() (*taskspb.ImportDataRequest, error) {
+	if len(s.records) == 0 {
+		return nil, io.EOF
+	}
+	record := s.records[0]
+	s.records = s.records[1:]
+	return &taskspb.ImportDataRequest{Record: record}, nil
+}
+
+func (s *importStream) SendAndClose(response *taskspb.ImportDataResponse) error {
+	s.response = response
+	return nil
+}
+
+func callerContext(userId auth.UserId) context.Context {
+	return auth.WithCaller(context.Background(), auth.Caller{UserId: userId})
+}
+
+func addendumRecord(taskId uint64, content string) *taskspb.DataRecord {
+	return &taskspb.DataRecord{Record: &taskspb.DataRecord_Addendum{Addendum: &taskspb.AddendumRecord{
+		TaskId:   taskId,
+		Addendum: &taskspb.Addendum{Content: content},
+	}}}
+}
+
+func taskRecord(taskId uint64, name string) *taskspb.DataRecord {
+	return &taskspb.DataRecord{Record: &taskspb.DataRecord_Task{Task: &taskspb.TaskRecord{
+		TaskId: taskId,
+		Task:   &taskspb.Task{Name: name, MinutesToComplete: 10, Tags: []string{"work"}},
+	}}}
+}
+
+// Both checks happen before the database is touched
+func TestImportRejectsInconsistentRecords(t *testing.T) {
+	tasks := server.NewServer(nil, testAuth(), nil)
+
+	duplicates := &importStream{ctx: callerContext(1), records: []*taskspb.DataRecord{
+		taskRecord(7, "first"),
+		taskRecord(7, "second"),
+	}}
+	err := tasks.ImportData(duplicates)
+	requireCode(t, codes.InvalidArgument, err)
+	require.ErrorContains(t, err, "duplicate record for task 7")
+
+	orphan := &importStream{ctx: callerContext(1), records: []*taskspb.DataRecord{
+		addendumRecord(8, "nobody owns this"),
+		taskRecord(7, "first"),
+	}}
+	err = tasks.ImportData(orphan)
+	requireCode(t, codes.InvalidArgument, err)
+	require.ErrorContains(t, err, "task 8 which has no task record")
+}
+
+func TestExportThenImportRemapsPrerequisites(t *testing.T) {
+	db := testDatabase(t)
+	ctx := context.Background()
+	tasks := server.NewServer(db, testAuth(), nil)
+	exporter, importer := randomUser(), randomUser()
+
+	first := putTask(t, db, exporter, types.None[database.WorkspaceId]())
+	second, err := database.FromWireType(&taskspb.Task{
+		Name:              "review tests",
+		MinutesToComplete: 15,
+		Tags:              []string{"work"},
+		Prerequisites:     []uint64{uint64(first)},
+	})
+	require.NoError(t, err)
+	secondId, err := db.Put(ctx, exporter, types.None[database.WorkspaceId](), types.None[database.TaskId](), second)
+	require.NoError(t, err)
+	require.NoError(t, db.Mark(ctx, exporter, secondId, "waiting on the first one"))
+
+	exported := &exportStream{ctx: callerContext(exporter)}
+	require.NoError(t, tasks.ExportData(&taskspb.ExportDataRequest{}, exported))
+	require.Len(t, exported.records, 3)
+
+	imported := &importStream{ctx: callerContext(importer), records: exported.records}
+	require.NoError(t, tasks.ImportData(imported))
+	require.Len(t, imported.response.GetTaskIds(), 2)
+	require.Equal(t, uint64(1), imported.response.GetNumberOfAddendums())
+
+	newFirst := database.TaskId(imported.response.GetTaskIds()[uint64(first)])
+	newSecond := database.TaskId(imported.response.GetTaskIds()[uint64(secondId)])
+	require.NotEqual(t, first, newFirst)
+	described, err := db.Describe(ctx, importer, newSecond)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{uint64(newFirst)}, described.First.ToWireType().GetPrerequisites())
+	require.Len(t, described.Second, 1)
+	require.Equal(t, database.Access{Owner: importer, Role: database.Owner}, described.First.Access())
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	DEFAULT_HEALTH_INTERVAL = 5 * time.Second
+	// a dependency that takes longer than this to answer counts as unreachable
+	HEALTH_CHECK_TIMEOUT = 2 * time.Second
+)
+
+// Fails when something the server needs to handle requests cannot be reached
+type HealthCheck func(ctx context.Context) error
+
+// Reports both the server as a whole and the tasks service as not serving
+// until WatchHealth has run its first checks
+func NewHealthServer() *health.Server {
+	healthServer := health.NewServer()
+	setServing(healthServer, healthpb.HealthCheckResponse_NOT_SERVING)
+	return healthServer
+}
+
+// Runs every check once per interval, and reports both the server as a whole
+// and the tasks service as serving only while every check passes. Returns once
+// ctx is done, leaving the last statuses in place.
+func WatchHealth(ctx context.Context, healthServer *health.Server, interval time.Duration, checks map[string]HealthCheck) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	failing := map[string]bool{}
+	for {
+		serving := healthpb.HealthCheckResponse_SERVING
+		for name, check := range checks {
+			checkCtx, cancel := context.WithTimeout(ctx, HEALTH_CHECK_TIMEOUT)
+			err := check(checkCtx)
+			cancel()
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				serving = healthpb.HealthCheckResponse_NOT_SERVING
+				if !failing[name] {
+					slog.Warn("dependency is unreachable", "dependency", name, "error", err.Error())
+				}
+				failing[name] = true
+			} else if failing[name] {
+				slog.Info("dependency is reachable again", "dependency", name)
+				delete(failing, name)
+			}
+		}
+		setServing(healthServer, serving)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func setServing(healthServer *health.Server, serving healthpb.HealthCheckResponse_ServingStatus) {
+	healthServer.SetServingStatus("", serving)
+	healthServer.SetServingStatus(taskspb.Tasks_ServiceDesc.ServiceName, serving)
+}
@@ -0,0 +1,58 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/WadeCappa/taskmaster/internal/server"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func servingStatus(t *testing.T, healthServer *health.Server, service string) healthpb.HealthCheckResponse_ServingStatus {
+	response, err := healthServer.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	require.NoError(t, err)
+	return response.GetStatus()
+}
+
+func TestWatchHealthFollowsChecks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	healthServer := server.NewHealthServer()
+	var postgresDown atomic.Bool
+	postgresDown.Store(true)
+
+	done := make(chan struct{})
+	go func() {
+		server.WatchHealth(ctx, healthServer, time.Millisecond, map[string]server.HealthCheck{
+			"auth": func(context.Context) error { return nil },
+			"postgres": func(context.Context) error {
+				if postgresDown.Load() {
+					return errors.New("connection refused")
+				}
+				return nil
+			},
+		})
+		close(done)
+	}()
+
+	require.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatus(t, healthServer, ""))
+	postgresDown.Store(false)
+	require.Eventually(t, func() bool {
+		return servingStatus(t, healthServer, "") == healthpb.HealthCheckResponse_SERVING
+	}, time.Second, time.Millisecond)
+	require.Equal(t, healthpb.HealthCheckResponse_SERVING, servingStatus(t, healthServer, taskspb.Tasks_ServiceDesc.ServiceName))
+
+	postgresDown.Store(true)
+	require.Eventually(t, func() bool {
+		return servingStatus(t, healthServer, taskspb.Tasks_ServiceDesc.ServiceName) == healthpb.HealthCheckResponse_NOT_SERVING
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	<-done
+}
@@ -0,0 +1,262 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/WadeCappa/taskmaster/internal/auth"
+	"github.com/WadeCappa/taskmaster/internal/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const (
+	REQUEST_ID_HEADER = "x-request-id"
+	// request ids sent by clients that are longer than this, or that have
+	// characters other than letters, digits and -_.: are replaced
+	MAX_REQUEST_ID_LENGTH = 128
+	REDACTED              = "[REDACTED]"
+)
+
+var (
+	validRequestId = regexp.MustCompile(`^[A-Za-z0-9\-_.:]+$`)
+	// bearer tokens and access tokens that end up in error messages, like
+	// ones passed along from the auth server
+	bearerToken = regexp.MustCompile(`(?i)(` + regexp.QuoteMeta(auth.BEARER_PREFIX) + `)\S+`)
+	accessToken = regexp.MustCompile(regexp.QuoteMeta(auth.ACCESS_TOKEN_PREFIX) + `\w+`)
+)
+
+type requestLogKey struct{}
+
+// Filled in while a request is handled and logged once it finishes
+type requestLog struct {
+	id     string
+	userId types.Option[auth.UserId]
+	// the error before it was converted into a status, with everything that
+	// was wrapped around it
+	err error
+}
+
+// Logs every grpc request as it finishes. Has to run after the error
+// interceptors, so that it sees whole error chains rather than the statuses
+// they are converted into. The request id is taken from the x-request-id
+// header, or generated when there is none, and sent back in the response
+// headers. At debug level the request itself is logged too, without the
+// fields marked debug_redact in the proto.
+func UnaryLoggingInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		request any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		start := time.Now()
+		ctx, entry := startRequestLog(ctx, requestIdFromMetadata(ctx))
+		grpc.SetHeader(ctx, metadata.Pairs(REQUEST_ID_HEADER, entry.id))
+		response, err := handler(ctx, request)
+		entry.err = err
+
+		code := toStatus(err).Code()
+		attributes := entry.attributes(ctx, info.FullMethod, start)
+		attributes = append(attributes, slog.String("code", code.String()))
+		if message, ok := request.(proto.Message); ok && logger.Enabled(ctx, slog.LevelDebug) {
+			attributes = append(attributes, slog.Any("request", redactedJson(message)))
+		}
+		logger.LogAttrs(ctx, grpcLevel(info.FullMethod, code), "grpc request", attributes...)
+		return response, err
+	}
+}
+
+// Logs every grpc stream as it closes, see UnaryLoggingInterceptor
+func StreamLoggingInterceptor(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		start := time.Now()
+		ctx, entry := startRequestLog(stream.Context(), requestIdFromMetadata(stream.Context()))
+		stream.SetHeader(metadata.Pairs(REQUEST_ID_HEADER, entry.id))
+		err := handler(srv, &authorizedStream{ServerStream: stream, ctx: ctx})
+		entry.err = err
+
+		code := toStatus(err).Code()
+		attributes := entry.attributes(ctx, info.FullMethod, start)
+		attributes = append(attributes, slog.String("code", code.String()))
+		logger.LogAttrs(ctx, grpcLevel(info.FullMethod, code), "grpc request", attributes...)
+		return err
+	}
+}
+
+// Logs every http request as it finishes and sends back its request id in the
+// X-Request-Id header. Only the path is logged, since calendar feeds carry
+// bearer tokens in their query.
+func LogHttpRequests(logger *slog.Logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ctx, entry := startRequestLog(r.Context(), r.Header.Get(REQUEST_ID_HEADER))
+		w.Header().Set(REQUEST_ID_HEADER, entry.id)
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r.WithContext(ctx))
+
+		attributes := entry.attributes(ctx, r.Method+" "+r.URL.Path, start)
+		attributes = append(attributes, slog.Int("status", recorder.status))
+		level := slog.LevelInfo
+		if recorder.status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		} else if recorder.status >= http.StatusBadRequest {
+			level = slog.LevelWarn
+		}
+		logger.LogAttrs(ctx, level, "http request", attributes...)
+	})
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+func startRequestLog(ctx context.Context, requestId string) (context.Context, *requestLog) {
+	if len(requestId) > MAX_REQUEST_ID_LENGTH || !validRequestId.MatchString(requestId) {
+		requestId = newRequestId()
+	}
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("request.id", requestId))
+	entry := &requestLog{id: requestId}
+	return context.WithValue(ctx, requestLogKey{}, entry), entry
+}
+
+func requestIdFromMetadata(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get(REQUEST_ID_HEADER)) == 0 {
+		return ""
+	}
+	return md.Get(REQUEST_ID_HEADER)[0]
+}
+
+func newRequestId() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// Records who made the request, for requests that are being logged
+func noteUser(ctx context.Context, userId auth.UserId) {
+	if entry, ok := ctx.Value(requestLogKey{}).(*requestLog); ok {
+		entry.userId = types.Some(userId)
+	}
+}
+
+// Records why the request failed, for requests that are being logged
+func noteError(ctx context.Context, err error) {
+	if entry, ok := ctx.Value(requestLogKey{}).(*requestLog); ok {
+		entry.err = err
+	}
+}
+
+func (e *requestLog) attributes(ctx context.Context, method string, start time.Time) []slog.Attr {
+	attributes := []slog.Attr{
+		slog.String("method", method),
+		slog.String("request_id", e.id),
+		slog.Duration("duration", time.Since(start)),
+	}
+	if userId, exists := e.userId.Unwrap(); exists {
+		attributes = append(attributes, slog.Uint64("user_id", uint64(userId)))
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+		attributes = append(attributes, slog.String("trace_id", spanContext.TraceID().String()))
+	}
+	if e.err != nil {
+		attributes = append(attributes, slog.String("error", redactTokens(e.err.Error())))
+	}
+	return attributes
+}
+
+// Errors the server is at fault for are logged as errors, everything else the
+// caller can fix themselves. Health checks come in every few seconds, so they
+// are only logged at info level when they fail.
+func grpcLevel(method string, code codes.Code) slog.Level {
+	switch code {
+	case codes.OK:
+		if strings.HasPrefix(method, "/"+healthpb.Health_ServiceDesc.ServiceName+"/") {
+			return slog.LevelDebug
+		}
+		return slog.LevelInfo
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable, codes.Unimplemented:
+		return slog.LevelError
+	default:
+		return slog.LevelWarn
+	}
+}
+
+func redactTokens(message string) string {
+	message = bearerToken.ReplaceAllString(message, "${1}"+REDACTED)
+	return accessToken.ReplaceAllString(message, REDACTED)
+}
+
+// Clears every field marked debug_redact, however deeply it is nested
+func redactedJson(message proto.Message) json.RawMessage {
+	redacted := proto.Clone(message)
+	redact(redacted.ProtoReflect())
+	encoded, err := protojson.Marshal(redacted)
+	if err != nil {
+		return json.RawMessage(`"unprintable request"`)
+	}
+	return encoded
+}
+
+func redact(message protoreflect.Message) {
+	message.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		if options, ok := field.Options().(*descriptorpb.FieldOptions); ok && options.GetDebugRedact() {
+			if field.Kind() == protoreflect.StringKind && !field.IsList() && !field.IsMap() {
+				message.Set(field, protoreflect.ValueOfString(REDACTED))
+			} else {
+				message.Clear(field)
+			}
+			return true
+		}
+		if field.Kind() != protoreflect.MessageKind && field.Kind() != protoreflect.GroupKind {
+			return true
+		}
+		switch {
+		case field.IsList():
+			for i := 0; i < value.List().Len(); i++ {
+				redact(value.List().Get(i).Message())
+			}
+		case field.IsMap():
+			if field.MapValue().Kind() == protoreflect.MessageKind {
+				value.Map().Range(func(_ protoreflect.MapKey, entry protoreflect.Value) bool {
+					redact(entry.Message())
+					return true
+				})
+			}
+		default:
+			redact(value.Message())
+		}
+		return true
+	})
+}
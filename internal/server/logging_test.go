@@ -0,0 +1,103 @@
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/WadeCappa/taskmaster/internal/auth"
+	"github.com/WadeCappa/taskmaster/internal/database"
+	"github.com/WadeCappa/taskmaster/internal/server"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func logTo(out *bytes.Buffer, level slog.Level) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(out, &slog.HandlerOptions{Level: level}))
+}
+
+func logLine(t *testing.T, out *bytes.Buffer) map[string]any {
+	var line map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &line))
+	return line
+}
+
+func TestLoggingInterceptorRecordsCallerAndErrorChain(t *testing.T) {
+	var out bytes.Buffer
+	a := testAuth().AcceptAccessTokens(fakeAccessTokens{
+		"tmpat_read": {UserId: 7, Scopes: []taskspb.Scope{taskspb.Scope_READ_ONLY}},
+	})
+	info := &grpc.UnaryServerInfo{FullMethod: taskspb.Tasks_DescribeTask_FullMethodName}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		auth.AUTHORIZATION_HEADER, auth.BEARER_PREFIX+"tmpat_read",
+		server.REQUEST_ID_HEADER, "from-client-1",
+	))
+
+	_, err := server.UnaryLoggingInterceptor(logTo(&out, slog.LevelInfo))(ctx, &taskspb.DescribeTaskRequest{TaskId: 3}, info, func(ctx context.Context, request any) (any, error) {
+		return server.UnaryScopeInterceptor(a)(ctx, request, info, func(ctx context.Context, request any) (any, error) {
+			return nil, fmt.Errorf("describing task 3: %w", &database.NotFoundError{})
+		})
+	})
+	require.Error(t, err)
+
+	line := logLine(t, &out)
+	require.Equal(t, "WARN", line["level"])
+	require.Equal(t, info.FullMethod, line["method"])
+	require.Equal(t, "from-client-1", line["request_id"])
+	require.Equal(t, float64(7), line["user_id"])
+	require.Equal(t, "NotFound", line["code"])
+	require.Contains(t, line["error"], "describing task 3: ")
+	require.NotContains(t, line, "request")
+}
+
+func TestLoggingInterceptorRedacts(t *testing.T) {
+	var out bytes.Buffer
+	info := &grpc.UnaryServerInfo{FullMethod: taskspb.Tasks_MarkTask_FullMethodName}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(server.REQUEST_ID_HEADER, "not a valid id"))
+
+	server.UnaryLoggingInterceptor(logTo(&out, slog.LevelDebug))(
+		ctx,
+		&taskspb.MarkTaskRequest{TaskId: 3, Content: "my secret plans"},
+		info,
+		func(ctx context.Context, request any) (any, error) {
+			return nil, errors.New("auth server rejected Bearer abc123 and tmpat_def456")
+		},
+	)
+
+	require.NotContains(t, out.String(), "my secret plans")
+	require.NotContains(t, out.String(), "abc123")
+	require.NotContains(t, out.String(), "def456")
+
+	line := logLine(t, &out)
+	require.Equal(t, "ERROR", line["level"])
+	require.NotEqual(t, "not a valid id", line["request_id"])
+	require.Len(t, line["request_id"], 32)
+	require.Equal(t, map[string]any{"taskId": "3", "content": server.REDACTED}, line["request"])
+}
+
+func TestLogHttpRequestsReturnsRequestId(t *testing.T) {
+	var out bytes.Buffer
+	handler := server.LogHttpRequests(logTo(&out, slog.LevelInfo), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "not here", http.StatusNotFound)
+	}))
+
+	request := httptest.NewRequest(http.MethodGet, "/v1/calendar.ics?token=secret-token", nil)
+	request.Header.Set(server.REQUEST_ID_HEADER, "from-client-2")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	require.Equal(t, "from-client-2", recorder.Header().Get(server.REQUEST_ID_HEADER))
+	require.NotContains(t, out.String(), "secret-token")
+	line := logLine(t, &out)
+	require.Equal(t, "GET /v1/calendar.ics", line["method"])
+	require.Equal(t, float64(http.StatusNotFound), line["status"])
+	require.Equal(t, "from-client-2", line["request_id"])
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	requests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "taskmaster_grpc_requests_total",
+		Help: "Finished grpc requests, by method and status code",
+	}, []string{"method", "code"})
+	requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "taskmaster_grpc_request_duration_seconds",
+		Help:    "How long grpc requests took, by method. Streams are timed until they close",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"})
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "taskmaster_http_requests_total",
+		Help: "Finished http requests, by the grpc method they call or the path they serve and by http status",
+	}, []string{"method", "code"})
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "taskmaster_http_request_duration_seconds",
+		Help:    "How long http requests took, by the grpc method they call or the path they serve",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"})
+)
+
+// Counts and times every request. Has to run before the error interceptors,
+// so that it sees the status codes that are sent to clients.
+func UnaryMetricsInterceptor(
+	ctx context.Context,
+	request any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	start := time.Now()
+	response, err := handler(ctx, request)
+	observe(info.FullMethod, start, err)
+	return response, err
+}
+
+// Counts and times every request. Has to run before the error interceptors,
+// so that it sees the status codes that are sent to clients.
+func StreamMetricsInterceptor(
+	srv any,
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	start := time.Now()
+	err := handler(srv, stream)
+	observe(info.FullMethod, start, err)
+	return err
+}
+
+// Counts and times every request to next. method labels the requests, paths
+// are never used since they contain ids.
+func CountHttpRequests(method string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+		httpRequests.WithLabelValues(method, strconv.Itoa(recorder.status)).Inc()
+		httpRequestDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	})
+}
+
+func observe(method string, start time.Time, err error) {
+	requests.WithLabelValues(method, status.Code(err).String()).Inc()
+	requestDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,76 @@
+package server_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/WadeCappa/taskmaster/internal/database"
+	"github.com/WadeCappa/taskmaster/internal/server"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// Returns the value of the counter with the given labels, or zero when it has
+// not been counted yet
+func counterValue(t *testing.T, name string, labels map[string]string) float64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+	metrics:
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if labels[label.GetName()] != label.GetValue() {
+					continue metrics
+				}
+			}
+			return metric.GetCounter().GetValue()
+		}
+	}
+	return 0
+}
+
+func TestMetricsCountStatusCodesSentToClients(t *testing.T) {
+	method := "/tasks.tasks/DescribeTask"
+	call := func(err error) {
+		server.UnaryMetricsInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, request any) (any, error) {
+			return server.UnaryErrorInterceptor(ctx, request, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, request any) (any, error) {
+				return nil, err
+			})
+		})
+	}
+	notFound := map[string]string{"method": method, "code": "NotFound"}
+	ok := map[string]string{"method": method, "code": "OK"}
+	before := counterValue(t, "taskmaster_grpc_requests_total", notFound)
+
+	call(&database.NotFoundError{Resource: "task", Id: 7})
+	call(&database.NotFoundError{Resource: "task", Id: 8})
+	require.Equal(t, before+2, counterValue(t, "taskmaster_grpc_requests_total", notFound))
+
+	okBefore := counterValue(t, "taskmaster_grpc_requests_total", ok)
+	call(nil)
+	require.Equal(t, okBefore+1, counterValue(t, "taskmaster_grpc_requests_total", ok))
+}
+
+func TestMetricsCountHttpStatuses(t *testing.T) {
+	method := "/tasks.tasks/GetTags"
+	handler := server.CountHttpRequests(method, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("fail") != "" {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	limited := map[string]string{"method": method, "code": "429"}
+	ok := map[string]string{"method": method, "code": "200"}
+	limitedBefore := counterValue(t, "taskmaster_http_requests_total", limited)
+	okBefore := counterValue(t, "taskmaster_http_requests_total", ok)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/tags?fail=1", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/tags", nil))
+	require.Equal(t, limitedBefore+1, counterValue(t, "taskmaster_http_requests_total", limited))
+	require.Equal(t, okBefore+1, counterValue(t, "taskmaster_http_requests_total", ok))
+}
@@ -0,0 +1,55 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/WadeCappa/taskmaster/internal/auth"
+	"github.com/WadeCappa/taskmaster/internal/database"
+	"github.com/WadeCappa/taskmaster/internal/types"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookEventsDescribeTheTaskWhenTheyHappened(t *testing.T) {
+	db := testDatabase(t)
+	ctx := context.Background()
+	owner, editor := randomUser(), randomUser()
+	webhook, err := database.WebhookFromWireType(&taskspb.Webhook{Url: "https://example.com/hook"})
+	require.NoError(t, err)
+	_, err = db.CreateWebhook(ctx, editor, webhook, "secret")
+	require.NoError(t, err)
+
+	taskId := putTask(t, db, owner, types.None[database.WorkspaceId]())
+	share(t, db, owner, taskId, editor, taskspb.Role_EDITOR)
+	require.NoError(t, db.SetStatus(ctx, database.Completed, taskId, owner))
+	require.NoError(t, db.Mark(ctx, owner, taskId, "done"))
+
+	// other tests may have left events behind, only this task's are kept
+	var recorded []database.TaskEvent
+	for {
+		handled, err := db.EnqueueDeliveries(ctx, 32, func(event database.TaskEvent) (string, error) {
+			if event.TaskId == taskId {
+				recorded = append(recorded, event)
+			}
+			return "{}", nil
+		})
+		require.NoError(t, err)
+		if handled == 0 {
+			break
+		}
+	}
+
+	// the task was created before it was shared, so only the editor's webhook
+	// hears about the later events, which see the task as it was then
+	require.Len(t, recorded, 2)
+	require.Equal(t, taskspb.TaskEventType_STATUS_CHANGED, recorded[0].Type)
+	require.Equal(t, types.Some(database.Tracking), recorded[0].PreviousStatus)
+	require.True(t, recorded[0].Task.HasStatus(database.Completed))
+	require.True(t, recorded[0].Task.HasAllTags("work"))
+	require.Equal(t, taskspb.TaskEventType_ADDENDUM_ADDED, recorded[1].Type)
+	addendum, exists := recorded[1].Addendum.Unwrap()
+	require.True(t, exists)
+	require.Equal(t, "done", addendum.ToWireType().GetContent())
+	require.Equal(t, []auth.UserId{editor}, recorded[1].UserIds)
+}
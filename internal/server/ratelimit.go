@@ -0,0 +1,64 @@
+package server
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/WadeCappa/taskmaster/internal/auth"
+	"github.com/WadeCappa/taskmaster/internal/ratelimit"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"google.golang.org/grpc"
+)
+
+// Limits how often each user can call tasks methods. Has to run after the
+// scope interceptors, which attach the caller to the context.
+func UnaryRateLimitInterceptor(a *auth.Auth, limiter *ratelimit.Limiter) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		request any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		if err := rateLimit(ctx, a, limiter, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, request)
+	}
+}
+
+// Streams take a single token when they are opened, however long they run.
+func StreamRateLimitInterceptor(a *auth.Auth, limiter *ratelimit.Limiter) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if err := rateLimit(stream.Context(), a, limiter, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+func rateLimit(ctx context.Context, a *auth.Auth, limiter *ratelimit.Limiter, method string) error {
+	if !strings.HasPrefix(method, "/"+taskspb.Tasks_ServiceDesc.ServiceName+"/") {
+		return nil
+	}
+	userId, err := a.GetUserId(ctx)
+	if err != nil {
+		return err
+	}
+	return limiter.Allow(userId, methodClass(method), time.Now())
+}
+
+// Methods that only need to read are reads, everything else including the
+// methods that need a login session are writes
+func methodClass(method string) ratelimit.Class {
+	if slices.Equal(requiredScopes[method], []taskspb.Scope{taskspb.Scope_READ_ONLY}) {
+		return ratelimit.Read
+	}
+	return ratelimit.Write
+}
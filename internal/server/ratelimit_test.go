@@ -0,0 +1,69 @@
+package server_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/WadeCappa/taskmaster/internal/ratelimit"
+	"github.com/WadeCappa/taskmaster/internal/server"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func unlimited() *ratelimit.Limiter {
+	return ratelimit.NewLimiter(ratelimit.Options{})
+}
+
+func TestRateLimitsAreSeparateForReadsAndWrites(t *testing.T) {
+	a := testAuth()
+	limiter := ratelimit.NewLimiter(ratelimit.Options{
+		Read:  ratelimit.Bucket{Rate: 1, Burst: 2},
+		Write: ratelimit.Bucket{Rate: 1, Burst: 1},
+	})
+	call := func(method string) error {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "token"))
+		_, err := server.UnaryErrorInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, request any) (any, error) {
+			return server.UnaryRateLimitInterceptor(a, limiter)(ctx, request, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, request any) (any, error) {
+				return "called", nil
+			})
+		})
+		return err
+	}
+
+	require.NoError(t, call(taskspb.Tasks_PutTask_FullMethodName))
+	err := call(taskspb.Tasks_MarkTask_FullMethodName)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.Len(t, status.Convert(err).Details(), 1)
+	retry, ok := status.Convert(err).Details()[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	require.Positive(t, retry.GetRetryDelay().AsDuration())
+
+	// writing too much does not stop a user from reading
+	require.NoError(t, call(taskspb.Tasks_DescribeTask_FullMethodName))
+	require.NoError(t, call(taskspb.Tasks_GetTasks_FullMethodName))
+	require.Equal(t, codes.ResourceExhausted, status.Code(call(taskspb.Tasks_DescribeTask_FullMethodName)))
+}
+
+func TestRateLimitsApplyToRest(t *testing.T) {
+	tasks := &fakeTasks{tasks: map[uint64]*taskspb.Task{}}
+	handler := server.NewRestHandler(tasks, testAuth(), ratelimit.NewLimiter(ratelimit.Options{
+		Read: ratelimit.Bucket{Rate: 0.5, Burst: 1},
+	}))
+
+	code, _ := do(t, handler, http.MethodGet, "/v1/tasks", "")
+	require.Equal(t, http.StatusOK, code)
+
+	request := httptest.NewRequest(http.MethodGet, "/v1/tasks", nil)
+	request.Header.Set("Authorization", "token")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	require.Equal(t, "2", recorder.Header().Get("Retry-After"))
+}
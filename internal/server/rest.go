@@ -0,0 +1,374 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/WadeCappa/taskmaster/internal/auth"
+	"github.com/WadeCappa/taskmaster/internal/ratelimit"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	REST_PATH     = "/v1/"
+	MAX_BODY_SIZE = 1 << 20
+)
+
+type restHandler struct {
+	tasks   taskspb.TasksServer
+	auth    *auth.Auth
+	limiter *ratelimit.Limiter
+}
+
+// Serves the tasks service as json over http for clients that cannot use grpc.
+// Every route calls straight into tasks, with the Authorization header passed
+// along as grpc metadata, so requests are authorized exactly as they are over
+// grpc, including the scopes of access tokens and rate limits. Lists are
+// returned as json arrays of the messages a stream would send.
+func NewRestHandler(tasks taskspb.TasksServer, a *auth.Auth, limiter *ratelimit.Limiter) http.Handler {
+	h := &restHandler{tasks: tasks, auth: a, limiter: limiter}
+	mux := http.NewServeMux()
+	mux.Handle("GET /v1/tasks", h.scoped(taskspb.Tasks_GetTasks_FullMethodName, h.getTasks))
+	mux.Handle("POST /v1/tasks", h.scoped(taskspb.Tasks_PutTask_FullMethodName, h.putTask))
+	mux.Handle("GET /v1/tasks/{id}", h.scoped(taskspb.Tasks_DescribeTask_FullMethodName, h.describeTask))
+	mux.Handle("POST /v1/tasks/{id}/addendums", h.scoped(taskspb.Tasks_MarkTask_FullMethodName, h.markTask))
+	mux.Handle("PUT /v1/tasks/{id}/status", h.scoped(taskspb.Tasks_SetStatus_FullMethodName, h.setStatus))
+	mux.Handle("PUT /v1/tasks/{id}/assignee", h.scoped(taskspb.Tasks_AssignTask_FullMethodName, h.assignTask))
+	mux.Handle("GET /v1/tags", h.scoped(taskspb.Tasks_GetTags_FullMethodName, h.getTags))
+	return mux
+}
+
+// Authorizes, rate limits and counts a route as the grpc method it calls, the
+// same way the interceptors would
+func (h *restHandler) scoped(method string, route http.HandlerFunc) http.Handler {
+	return CountHttpRequests(method, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorized, err := authorize(withBearer(r), h.auth, method)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+		if err := rateLimit(authorized, h.auth, h.limiter, method); err != nil {
+			writeError(w, r, err)
+			return
+		}
+		route(w, r.WithContext(authorized))
+	}))
+}
+
+func (h *restHandler) getTasks(w http.ResponseWriter, r *http.Request) {
+	request := &taskspb.GetTasksRequest{}
+	if value := r.URL.Query().Get("status"); value != "" {
+		taskStatus, err := parseEnum[taskspb.Status]("status", value, taskspb.Status_name, taskspb.Status_value)
+		if err != nil {
+			writeError(w, r, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+		request.Status = taskStatus
+	}
+	if value := r.URL.Query().Get("assignment"); value != "" {
+		assignment, err := parseEnum[taskspb.AssignmentFilter]("assignment", value, taskspb.AssignmentFilter_name, taskspb.AssignmentFilter_value)
+		if err != nil {
+			writeError(w, r, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+		request.Assignment = assignment
+	}
+	workspaceId, err := workspaceFromQuery(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	request.WorkspaceId = workspaceId
+	for _, tags := range r.URL.Query()["tags"] {
+		for _, t := range strings.Split(tags, ",") {
+			if t != "" {
+				request.Tags = append(request.Tags, t)
+			}
+		}
+	}
+
+	stream := &collectingStream[taskspb.GetTasksResponse]{ctx: withBearer(r)}
+	if err := h.tasks.GetTasks(request, stream); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeList(w, r, stream.messages)
+}
+
+func (h *restHandler) putTask(w http.ResponseWriter, r *http.Request) {
+	request := &taskspb.PutTaskRequest{}
+	if err := readBody(r, request); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response, err := h.tasks.PutTask(withBearer(r), request)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeMessage(w, r, response)
+}
+
+func (h *restHandler) describeTask(w http.ResponseWriter, r *http.Request) {
+	taskId, err := taskIdFromPath(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	response, err := h.tasks.DescribeTask(withBearer(r), &taskspb.DescribeTaskRequest{
+		TaskId: taskId,
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeMessage(w, r, response)
+}
+
+func (h *restHandler) markTask(w http.ResponseWriter, r *http.Request) {
+	taskId, err := taskIdFromPath(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	request := &taskspb.MarkTaskRequest{}
+	if err := readBody(r, request); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	request.TaskId = taskId
+	response, err := h.tasks.MarkTask(withBearer(r), request)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeMessage(w, r, response)
+}
+
+func (h *restHandler) setStatus(w http.ResponseWriter, r *http.Request) {
+	taskId, err := taskIdFromPath(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	request := &taskspb.SetStatusRequest{}
+	if err := readBody(r, request); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	request.TaskId = taskId
+	response, err := h.tasks.SetStatus(withBearer(r), request)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeMessage(w, r, response)
+}
+
+func (h *restHandler) assignTask(w http.ResponseWriter, r *http.Request) {
+	taskId, err := taskIdFromPath(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	request := &taskspb.AssignTaskRequest{}
+	if err := readBody(r, request); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	request.TaskId = taskId
+	response, err := h.tasks.AssignTask(withBearer(r), request)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeMessage(w, r, response)
+}
+
+func (h *restHandler) getTags(w http.ResponseWriter, r *http.Request) {
+	workspaceId, err := workspaceFromQuery(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	stream := &collectingStream[taskspb.GetTagsResponse]{ctx: withBearer(r)}
+	if err := h.tasks.GetTags(&taskspb.GetTagsRequest{WorkspaceId: workspaceId}, stream); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeList(w, r, stream.messages)
+}
+
+// Stands in for a grpc stream, keeping everything that is sent so that it can
+// be written out as a single response. Only Send and Context are supported.
+type collectingStream[T any] struct {
+	grpc.ServerStream
+	ctx      context.Context
+	messages []*T
+}
+
+func (s *collectingStream[T]) Send(message *T) error {
+	s.messages = append(s.messages, message)
+	return nil
+}
+
+func (s *collectingStream[T]) Context() context.Context {
+	return s.ctx
+}
+
+// Also passes along the tls connection, for callers identified by client certificate
+func withBearer(r *http.Request) context.Context {
+	ctx := r.Context()
+	if r.TLS != nil {
+		ctx = peer.NewContext(ctx, &peer.Peer{AuthInfo: credentials.TLSInfo{State: *r.TLS}})
+	}
+	return metadata.NewIncomingContext(
+		ctx,
+		metadata.Pairs(auth.AUTHORIZATION_HEADER, r.Header.Get("Authorization")),
+	)
+}
+
+func taskIdFromPath(r *http.Request) (uint64, error) {
+	taskId, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "task id %q is not a number", r.PathValue("id"))
+	}
+	return taskId, nil
+}
+
+// Nil when the request is for the caller's personal workspace
+func workspaceFromQuery(r *http.Request) (*uint64, error) {
+	value := r.URL.Query().Get("workspace")
+	if value == "" {
+		return nil, nil
+	}
+	workspaceId, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "workspace %q is not a number", value)
+	}
+	return &workspaceId, nil
+}
+
+// Accepts either the name or the number of a value of a proto enum
+func parseEnum[E ~int32](kind string, value string, names map[int32]string, values map[string]int32) (E, error) {
+	if number, err := strconv.ParseInt(value, 10, 32); err == nil {
+		if _, exists := names[int32(number)]; exists {
+			return E(number), nil
+		}
+	}
+	if number, exists := values[strings.ToUpper(value)]; exists {
+		return E(number), nil
+	}
+	return 0, fmt.Errorf("unrecognized %s %q", kind, value)
+}
+
+func readBody(r *http.Request, message proto.Message) error {
+	body, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, MAX_BODY_SIZE))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return status.Errorf(codes.InvalidArgument, "request body is larger than %d bytes", MAX_BODY_SIZE)
+		}
+		return status.Errorf(codes.InvalidArgument, "reading request body: %v", err)
+	}
+	if err := protojson.Unmarshal(body, message); err != nil {
+		return status.Errorf(codes.InvalidArgument, "parsing request body: %v", err)
+	}
+	return nil
+}
+
+func writeMessage(w http.ResponseWriter, r *http.Request, message proto.Message) {
+	body, err := protojson.Marshal(message)
+	if err != nil {
+		writeError(w, r, fmt.Errorf("converting response to json: %w", err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+func writeList[M proto.Message](w http.ResponseWriter, r *http.Request, messages []M) {
+	var body bytes.Buffer
+	body.WriteByte('[')
+	for i, message := range messages {
+		if i > 0 {
+			body.WriteByte(',')
+		}
+		encoded, err := protojson.Marshal(message)
+		if err != nil {
+			writeError(w, r, fmt.Errorf("converting response to json: %w", err))
+			return
+		}
+		body.Write(encoded)
+	}
+	body.WriteByte(']')
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body.Bytes())
+}
+
+// Errors are written as a google.rpc.Status, the same shape grpc clients see.
+// Retry info is also sent as a Retry-After header, for clients that only know http.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	noteError(r.Context(), err)
+	converted := toStatus(err)
+	code := httpStatusFromCode(converted.Code())
+	for _, detail := range converted.Details() {
+		if retry, ok := detail.(*errdetails.RetryInfo); ok {
+			seconds := math.Ceil(retry.GetRetryDelay().AsDuration().Seconds())
+			w.Header().Set("Retry-After", strconv.Itoa(max(int(seconds), 1)))
+		}
+	}
+	body, marshalErr := protojson.Marshal(converted.Proto())
+	if marshalErr != nil {
+		http.Error(w, converted.Message(), code)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(body)
+}
+
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Canceled:
+		return 499
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
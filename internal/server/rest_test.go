@@ -0,0 +1,170 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/WadeCappa/taskmaster/internal/auth"
+	"github.com/WadeCappa/taskmaster/internal/server"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type fakeTasks struct {
+	taskspb.UnimplementedTasksServer
+	bearers []string
+	tasks   map[uint64]*taskspb.Task
+}
+
+func (f *fakeTasks) authorize(ctx context.Context) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	f.bearers = append(f.bearers, md.Get("authorization")...)
+	if len(md.Get("authorization")) == 0 || md.Get("authorization")[0] != "token" {
+		return status.Error(codes.Unauthenticated, "bad token")
+	}
+	return nil
+}
+
+func (f *fakeTasks) PutTask(ctx context.Context, request *taskspb.PutTaskRequest) (*taskspb.PutTaskResponse, error) {
+	if err := f.authorize(ctx); err != nil {
+		return nil, err
+	}
+	f.tasks[101] = request.GetTask()
+	return &taskspb.PutTaskResponse{TaskId: 101}, nil
+}
+
+func (f *fakeTasks) GetTasks(request *taskspb.GetTasksRequest, stream grpc.ServerStreamingServer[taskspb.GetTasksResponse]) error {
+	if err := f.authorize(stream.Context()); err != nil {
+		return err
+	}
+	// every task in the fake is in the personal workspace
+	if request.WorkspaceId != nil {
+		return nil
+	}
+	for id, task := range f.tasks {
+		if request.GetAssignment() == taskspb.AssignmentFilter_ASSIGNED_TO_ME && task.GetAssigneeUserId() != 1 {
+			continue
+		}
+		if task.GetStatus() == request.GetStatus() && strings.Join(task.GetTags(), ",") == strings.Join(request.GetTags(), ",") {
+			stream.Send(&taskspb.GetTasksResponse{TaskId: id, Task: task})
+		}
+	}
+	return nil
+}
+
+func (f *fakeTasks) SetStatus(ctx context.Context, request *taskspb.SetStatusRequest) (*taskspb.SetStatusResponse, error) {
+	if err := f.authorize(ctx); err != nil {
+		return nil, err
+	}
+	task, exists := f.tasks[request.GetTaskId()]
+	if !exists {
+		return nil, errors.New("no such task")
+	}
+	task.Status = request.GetStatus()
+	return &taskspb.SetStatusResponse{}, nil
+}
+
+func (f *fakeTasks) AssignTask(ctx context.Context, request *taskspb.AssignTaskRequest) (*taskspb.AssignTaskResponse, error) {
+	if err := f.authorize(ctx); err != nil {
+		return nil, err
+	}
+	task, exists := f.tasks[request.GetTaskId()]
+	if !exists {
+		return nil, errors.New("no such task")
+	}
+	task.AssigneeUserId = request.AssigneeUserId
+	return &taskspb.AssignTaskResponse{}, nil
+}
+
+func testAuth() *auth.Auth {
+	return auth.NewStaticAuth(auth.NewStubAuthmaster(map[string]auth.UserId{"token": 1}))
+}
+
+func do(t *testing.T, handler http.Handler, method string, path string, body string) (int, string) {
+	request := httptest.NewRequest(method, path, strings.NewReader(body))
+	request.Header.Set("Authorization", "token")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	response, err := io.ReadAll(recorder.Result().Body)
+	require.NoError(t, err)
+	return recorder.Code, string(response)
+}
+
+func TestRestHandlerRoundTripsThroughTheTasksService(t *testing.T) {
+	tasks := &fakeTasks{tasks: map[uint64]*taskspb.Task{}}
+	handler := server.NewRestHandler(tasks, testAuth(), unlimited())
+
+	code, body := do(t, handler, http.MethodPost, "/v1/tasks", `{"task":{"name":"write tests","minutesToComplete":"30","tags":["work"]}}`)
+	require.Equal(t, http.StatusOK, code)
+	require.JSONEq(t, `{"taskId":"101"}`, body)
+
+	code, body = do(t, handler, http.MethodPut, "/v1/tasks/101/status", `{"status":"COMPLETED"}`)
+	require.Equal(t, http.StatusOK, code)
+	require.JSONEq(t, `{}`, body)
+
+	code, body = do(t, handler, http.MethodGet, "/v1/tasks?status=completed&tags=work", "")
+	require.Equal(t, http.StatusOK, code)
+	require.JSONEq(t, `[{"taskId":"101","task":{"name":"write tests","minutesToComplete":"30","status":"COMPLETED","tags":["work"]}}]`, body)
+
+	code, body = do(t, handler, http.MethodGet, "/v1/tasks?status=0", "")
+	require.Equal(t, http.StatusOK, code)
+	require.JSONEq(t, `[]`, body)
+
+	code, body = do(t, handler, http.MethodGet, "/v1/tasks?status=completed&tags=work&workspace=7", "")
+	require.Equal(t, http.StatusOK, code)
+	require.JSONEq(t, `[]`, body)
+
+	code, body = do(t, handler, http.MethodGet, "/v1/tasks?status=completed&tags=work&assignment=assigned_to_me", "")
+	require.Equal(t, http.StatusOK, code)
+	require.JSONEq(t, `[]`, body)
+
+	code, body = do(t, handler, http.MethodPut, "/v1/tasks/101/assignee", `{"assigneeUserId":"1"}`)
+	require.Equal(t, http.StatusOK, code)
+	require.JSONEq(t, `{}`, body)
+
+	code, body = do(t, handler, http.MethodGet, "/v1/tasks?status=completed&tags=work&assignment=1", "")
+	require.Equal(t, http.StatusOK, code)
+	require.JSONEq(t, `[{"taskId":"101","task":{"name":"write tests","minutesToComplete":"30","status":"COMPLETED","tags":["work"],"assigneeUserId":"1"}}]`, body)
+
+	require.Equal(t, []string{"token", "token", "token", "token", "token", "token", "token", "token"}, tasks.bearers)
+}
+
+func TestRestHandlerErrors(t *testing.T) {
+	handler := server.NewRestHandler(&fakeTasks{tasks: map[uint64]*taskspb.Task{}}, testAuth(), unlimited())
+
+	code, _ := do(t, handler, http.MethodPost, "/v1/tasks", `{"task":`)
+	require.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = do(t, handler, http.MethodGet, "/v1/tasks/abc", "")
+	require.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = do(t, handler, http.MethodGet, "/v1/tasks?status=DONE", "")
+	require.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = do(t, handler, http.MethodGet, "/v1/tasks?workspace=team", "")
+	require.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = do(t, handler, http.MethodGet, "/v1/tasks?assignment=mine", "")
+	require.Equal(t, http.StatusBadRequest, code)
+
+	code, body := do(t, handler, http.MethodGet, "/v1/tags", "")
+	require.Equal(t, http.StatusNotImplemented, code)
+	require.Contains(t, body, `"code":12`)
+
+	code, _ = do(t, handler, http.MethodDelete, "/v1/tasks/101", "")
+	require.Equal(t, http.StatusMethodNotAllowed, code)
+
+	request := httptest.NewRequest(http.MethodGet, "/v1/tasks", nil)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"context"
+	"strings"
+
+	"github.com/WadeCappa/taskmaster/internal/auth"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The scopes an access token needs for each method of the tasks service. Nil
+// means the method needs a login session. Methods missing from here are
+// refused, so that new methods have to be added deliberately.
+var requiredScopes = map[string][]taskspb.Scope{
+	taskspb.Tasks_GetTasks_FullMethodName:             {taskspb.Scope_READ_ONLY},
+	taskspb.Tasks_DescribeTask_FullMethodName:         {taskspb.Scope_READ_ONLY},
+	taskspb.Tasks_GetTags_FullMethodName:              {taskspb.Scope_READ_ONLY},
+	taskspb.Tasks_ExportData_FullMethodName:           {taskspb.Scope_READ_ONLY},
+	taskspb.Tasks_WatchTasks_FullMethodName:           {taskspb.Scope_READ_ONLY},
+	taskspb.Tasks_ListWorkspaces_FullMethodName:       {taskspb.Scope_READ_ONLY},
+	taskspb.Tasks_ListWorkspaceMembers_FullMethodName: {taskspb.Scope_READ_ONLY},
+	taskspb.Tasks_PutTask_FullMethodName:              {taskspb.Scope_WRITE_TASKS},
+	taskspb.Tasks_SetStatus_FullMethodName:            {taskspb.Scope_WRITE_TASKS},
+	taskspb.Tasks_AssignTask_FullMethodName:           {taskspb.Scope_WRITE_TASKS},
+	taskspb.Tasks_MarkTask_FullMethodName:             {taskspb.Scope_WRITE_ADDENDUMS},
+	taskspb.Tasks_ImportData_FullMethodName:           {taskspb.Scope_WRITE_TASKS, taskspb.Scope_WRITE_ADDENDUMS},
+
+	taskspb.Tasks_CreateWebhook_FullMethodName:         nil,
+	taskspb.Tasks_ListWebhooks_FullMethodName:          nil,
+	taskspb.Tasks_DeleteWebhook_FullMethodName:         nil,
+	taskspb.Tasks_ListWebhookDeliveries_FullMethodName: nil,
+	taskspb.Tasks_CreateAccessToken_FullMethodName:     nil,
+	taskspb.Tasks_ListAccessTokens_FullMethodName:      nil,
+	taskspb.Tasks_RevokeAccessToken_FullMethodName:     nil,
+	taskspb.Tasks_Share_FullMethodName:                 nil,
+	taskspb.Tasks_ListShares_FullMethodName:            nil,
+	taskspb.Tasks_Unshare_FullMethodName:               nil,
+	taskspb.Tasks_CreateWorkspace_FullMethodName:       nil,
+	taskspb.Tasks_JoinWorkspace_FullMethodName:         nil,
+	taskspb.Tasks_LeaveWorkspace_FullMethodName:        nil,
+	taskspb.Tasks_SetWorkspaceRole_FullMethodName:      nil,
+}
+
+// Identifies the caller of every tasks method and checks that they are allowed
+// to call it. Methods of other services, like the stand in authmaster, are
+// passed through.
+func UnaryScopeInterceptor(a *auth.Auth) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		request any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		authorized, err := authorize(ctx, a, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(authorized, request)
+	}
+}
+
+// Identifies the caller of every tasks method and checks that they are allowed
+// to call it. Methods of other services, like the stand in authmaster, are
+// passed through.
+func StreamScopeInterceptor(a *auth.Auth) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		authorized, err := authorize(stream.Context(), a, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authorizedStream{ServerStream: stream, ctx: authorized})
+	}
+}
+
+// Returns ctx with the caller attached, so handlers do not look them up again
+func authorize(ctx context.Context, a *auth.Auth, method string) (context.Context, error) {
+	if !strings.HasPrefix(method, "/"+taskspb.Tasks_ServiceDesc.ServiceName+"/") {
+		return ctx, nil
+	}
+	required, known := requiredScopes[method]
+	if !known {
+		return nil, status.Errorf(codes.PermissionDenied, "%s has not declared the scopes it needs", method)
+	}
+
+	caller, err := a.GetCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	noteUser(ctx, caller.UserId)
+	if required == nil && !caller.IsSession() {
+		return nil, &auth.InsufficientScopeError{Method: method}
+	}
+	var missing []taskspb.Scope
+	for _, scope := range required {
+		if !caller.HasScope(scope) {
+			missing = append(missing, scope)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, &auth.InsufficientScopeError{Method: method, Missing: missing}
+	}
+	return auth.WithCaller(ctx, caller), nil
+}
+
+type authorizedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authorizedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,135 @@
+package server_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/WadeCappa/taskmaster/internal/auth"
+	"github.com/WadeCappa/taskmaster/internal/server"
+	"github.com/WadeCappa/taskmaster/internal/types"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type fakeAccessTokens map[string]auth.Caller
+
+func (f fakeAccessTokens) LookupAccessToken(ctx context.Context, hash []byte) (types.Option[auth.Caller], error) {
+	for token, caller := range f {
+		if string(auth.HashAccessToken(token)) == string(hash) {
+			return types.Some(caller), nil
+		}
+	}
+	return types.None[auth.Caller](), nil
+}
+
+func callUnary(a *auth.Auth, method string, token string) (auth.Caller, error) {
+	ctx := context.Background()
+	if token != "" {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(auth.AUTHORIZATION_HEADER, token))
+	}
+	var caller auth.Caller
+	_, err := server.UnaryScopeInterceptor(a)(
+		ctx,
+		nil,
+		&grpc.UnaryServerInfo{FullMethod: method},
+		func(ctx context.Context, request any) (any, error) {
+			var err error
+			caller, err = a.GetCaller(ctx)
+			return nil, err
+		},
+	)
+	return caller, err
+}
+
+func TestScopeInterceptor(t *testing.T) {
+	a := testAuth().AcceptAccessTokens(fakeAccessTokens{
+		"tmpat_read":      {UserId: 1, Scopes: []taskspb.Scope{taskspb.Scope_READ_ONLY}},
+		"tmpat_tasks":     {UserId: 1, Scopes: []taskspb.Scope{taskspb.Scope_WRITE_TASKS}},
+		"tmpat_addendums": {UserId: 1, Scopes: []taskspb.Scope{taskspb.Scope_WRITE_ADDENDUMS}},
+	})
+
+	allowed := []struct {
+		method string
+		token  string
+	}{
+		{taskspb.Tasks_GetTasks_FullMethodName, "token"},
+		{taskspb.Tasks_GetTasks_FullMethodName, "tmpat_read"},
+		{taskspb.Tasks_GetTasks_FullMethodName, "tmpat_tasks"},
+		{taskspb.Tasks_PutTask_FullMethodName, "tmpat_tasks"},
+		{taskspb.Tasks_MarkTask_FullMethodName, "tmpat_addendums"},
+		{taskspb.Tasks_CreateAccessToken_FullMethodName, "token"},
+	}
+	for _, c := range allowed {
+		caller, err := callUnary(a, c.method, c.token)
+		require.NoError(t, err, c)
+		require.Equal(t, auth.UserId(1), caller.UserId)
+	}
+
+	denied := []struct {
+		method string
+		token  string
+	}{
+		{taskspb.Tasks_PutTask_FullMethodName, "tmpat_read"},
+		{taskspb.Tasks_MarkTask_FullMethodName, "tmpat_tasks"},
+		{taskspb.Tasks_ImportData_FullMethodName, "tmpat_tasks"},
+		{taskspb.Tasks_CreateAccessToken_FullMethodName, "tmpat_tasks"},
+		{taskspb.Tasks_CreateWebhook_FullMethodName, "tmpat_read"},
+	}
+	for _, c := range denied {
+		_, err := callUnary(a, c.method, c.token)
+		var insufficient *auth.InsufficientScopeError
+		require.ErrorAs(t, err, &insufficient, c)
+	}
+
+	_, err := callUnary(a, taskspb.Tasks_GetTasks_FullMethodName, "tmpat_revoked")
+	var unauthenticated *auth.UnauthenticatedError
+	require.ErrorAs(t, err, &unauthenticated)
+
+	_, err = callUnary(a, "/tasks.tasks/NotDeclared", "token")
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// other services, like the stand in authmaster, do their own auth
+	_, err = server.UnaryScopeInterceptor(a)(
+		context.Background(),
+		nil,
+		&grpc.UnaryServerInfo{FullMethod: "/authmaster.authmaster/Login"},
+		func(ctx context.Context, request any) (any, error) { return "called", nil },
+	)
+	require.NoError(t, err)
+}
+
+func TestScopesApplyToRest(t *testing.T) {
+	tasks := &fakeTasks{tasks: map[uint64]*taskspb.Task{}}
+	handler := server.NewRestHandler(tasks, testAuth().AcceptAccessTokens(fakeAccessTokens{
+		"tmpat_read": {UserId: 1, Scopes: []taskspb.Scope{taskspb.Scope_READ_ONLY}},
+	}), unlimited())
+
+	request := httptest.NewRequest(http.MethodPost, "/v1/tasks", strings.NewReader(`{"task":{"name":"write tests"}}`))
+	request.Header.Set("Authorization", "tmpat_read")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+	require.Empty(t, tasks.tasks)
+}
+
+func TestEveryTasksMethodDeclaresItsScopes(t *testing.T) {
+	a := testAuth()
+	var methods []string
+	for _, m := range taskspb.Tasks_ServiceDesc.Methods {
+		methods = append(methods, m.MethodName)
+	}
+	for _, s := range taskspb.Tasks_ServiceDesc.Streams {
+		methods = append(methods, s.StreamName)
+	}
+	for _, m := range methods {
+		_, err := callUnary(a, "/"+taskspb.Tasks_ServiceDesc.ServiceName+"/"+m, "token")
+		require.NoError(t, err, m)
+	}
+}
@@ -2,29 +2,35 @@ package server
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"io"
 
 	"github.com/WadeCappa/taskmaster/internal/auth"
 	"github.com/WadeCappa/taskmaster/internal/database"
+	"github.com/WadeCappa/taskmaster/internal/events"
+	"github.com/WadeCappa/taskmaster/internal/types"
 	"github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 type tasksServer struct {
 	taskspb.TasksServer
 
-	db   *database.Database
-	auth *auth.Auth
+	db     *database.Database
+	auth   *auth.Auth
+	broker *events.Broker
 }
 
 func NewServer(
 	db *database.Database,
 	auth *auth.Auth,
+	broker *events.Broker,
 ) taskspb.TasksServer {
 	return &tasksServer{
-		db:   db,
-		auth: auth,
+		db:     db,
+		auth:   auth,
+		broker: broker,
 	}
 }
 
@@ -38,9 +44,13 @@ func (s *tasksServer) PutTask(
 	}
 	task, err := database.FromWireType(request.GetTask())
 	if err != nil {
-		return nil, fmt.Errorf("converting task to wire type: %w", err)
+		return nil, fmt.Errorf("converting task to wire type: %w", database.WithFieldPrefix(err, "task"))
 	}
-	newTaskId, err := s.db.Put(ctx, userId, task)
+	taskId := types.None[database.TaskId]()
+	if request.TaskId != nil {
+		taskId = types.Some(database.TaskId(request.GetTaskId()))
+	}
+	newTaskId, err := s.db.Put(ctx, userId, database.WorkspaceFromWire(request.WorkspaceId), taskId, task)
 	if err != nil {
 		return nil, fmt.Errorf("putting task id: %w", err)
 	}
@@ -63,10 +73,16 @@ func (s *tasksServer) GetTasks(
 	for i, t := range request.GetTags() {
 		tags[i] = database.Tag(t)
 	}
+	assignment, err := database.AssignmentFromWire(request.GetAssignment())
+	if err != nil {
+		return database.NewInvalidArgumentError("assignment", err.Error())
+	}
 
 	tasks, err := s.db.Get(
 		stream.Context(),
 		userId,
+		database.WorkspaceFromWire(request.WorkspaceId),
+		assignment,
 		database.Status(request.GetStatus()),
 		tags...,
 	)
@@ -75,9 +91,12 @@ func (s *tasksServer) GetTasks(
 	}
 
 	for _, taskAndId := range tasks {
+		access := taskAndId.Second.Access()
 		stream.Send(&taskspb.GetTasksResponse{
-			TaskId: uint64(taskAndId.First),
-			Task:   taskAndId.Second.ToWireType(),
+			TaskId:  uint64(taskAndId.First),
+			Task:    taskAndId.Second.ToWireType(),
+			OwnerId: uint64(access.Owner),
+			Role:    taskspb.Role(access.Role),
 		})
 	}
 	return nil
@@ -102,9 +121,12 @@ func (s *tasksServer) DescribeTask(
 		wireAddendums[i] = t.ToWireType()
 	}
 
+	access := task.First.Access()
 	return &taskspb.DescribeTaskResponse{
 		Task:     task.First.ToWireType(),
 		Addendum: wireAddendums,
+		OwnerId:  uint64(access.Owner),
+		Role:     taskspb.Role(access.Role),
 	}, nil
 }
 
@@ -117,7 +139,7 @@ func (s *tasksServer) MarkTask(
 		return nil, fmt.Errorf("getting user Id: %w", err)
 	}
 	if request.GetContent() == "" {
-		return nil, errors.New("received mark request without any content")
+		return nil, database.NewInvalidArgumentError("content", "received mark request without any content")
 	}
 	if err := s.db.Mark(
 		ctx,
@@ -138,7 +160,7 @@ func (s *tasksServer) GetTags(
 	if err != nil {
 		return fmt.Errorf("getting user Id: %w", err)
 	}
-	tags, err := s.db.GetTags(stream.Context(), userId)
+	tags, err := s.db.GetTags(stream.Context(), userId, database.WorkspaceFromWire(request.WorkspaceId))
 	if err != nil {
 		return fmt.Errorf("getting tags from DB: %w", err)
 	}
@@ -156,10 +178,232 @@ func (s *tasksServer) SetStatus(
 	if err != nil {
 		return nil, fmt.Errorf("getting user Id: %w", err)
 	}
+	status, err := database.StatusFromWire(request.GetStatus())
+	if err != nil {
+		return nil, database.NewInvalidArgumentError("status", err.Error())
+	}
 	if err := s.db.SetStatus(
-		ctx, database.Status(request.GetStatus()), database.TaskId(request.GetTaskId()), userId,
+		ctx, status, database.TaskId(request.GetTaskId()), userId,
 	); err != nil {
 		return nil, fmt.Errorf("updating task status: %w", err)
 	}
 	return &taskspb.SetStatusResponse{}, nil
 }
+
+func (s *tasksServer) AssignTask(
+	ctx context.Context,
+	request *taskspb.AssignTaskRequest,
+) (*taskspb.AssignTaskResponse, error) {
+	userId, err := s.auth.GetUserId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting user Id: %w", err)
+	}
+	if err := s.db.Assign(
+		ctx,
+		userId,
+		database.TaskId(request.GetTaskId()),
+		database.AssigneeFromWire(request.AssigneeUserId),
+	); err != nil {
+		return nil, fmt.Errorf("assigning task: %w", err)
+	}
+	return &taskspb.AssignTaskResponse{}, nil
+}
+
+func (s *tasksServer) ExportData(
+	request *taskspb.ExportDataRequest,
+	stream grpc.ServerStreamingServer[taskspb.ExportDataResponse],
+) error {
+	userId, err := s.auth.GetUserId(stream.Context())
+	if err != nil {
+		return fmt.Errorf("getting user Id: %w", err)
+	}
+	data, err := s.db.Export(stream.Context(), userId)
+	if err != nil {
+		return fmt.Errorf("exporting user data: %w", err)
+	}
+
+	for _, record := range toRecords(data) {
+		if err := stream.Send(&taskspb.ExportDataResponse{Record: record}); err != nil {
+			return fmt.Errorf("sending record: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *tasksServer) ImportData(
+	stream grpc.ClientStreamingServer[taskspb.ImportDataRequest, taskspb.ImportDataResponse],
+) error {
+	userId, err := s.auth.GetUserId(stream.Context())
+	if err != nil {
+		return fmt.Errorf("getting user Id: %w", err)
+	}
+
+	data := database.UserData{Addendums: map[database.TaskId][]database.Addendum{}}
+	seen := map[database.TaskId]struct{}{}
+	numberOfAddendums := uint64(0)
+	for {
+		request, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("receiving next record: %w", err)
+		}
+
+		switch record := request.GetRecord().GetRecord().(type) {
+		case *taskspb.DataRecord_Task:
+			taskId := database.TaskId(record.Task.GetTaskId())
+			if _, exists := seen[taskId]; exists {
+				return database.NewInvalidArgumentError(
+					"record.task.task_id",
+					fmt.Sprintf("found duplicate record for task %d", taskId),
+				)
+			}
+			seen[taskId] = struct{}{}
+			task, err := database.FromWireType(record.Task.GetTask())
+			if err != nil {
+				return fmt.Errorf(
+					"converting task %d from wire type: %w",
+					taskId,
+					database.WithFieldPrefix(err, "record.task.task"),
+				)
+			}
+			data.Tasks = append(data.Tasks, types.Of(taskId, task))
+		case *taskspb.DataRecord_Addendum:
+			if record.Addendum.GetAddendum().GetContent() == "" {
+				return database.NewInvalidArgumentError(
+					"record.addendum.addendum.content",
+					fmt.Sprintf("received addendum for task %d without any content", record.Addendum.GetTaskId()),
+				)
+			}
+			taskId := database.TaskId(record.Addendum.GetTaskId())
+			data.Addendums[taskId] = append(
+				data.Addendums[taskId],
+				database.AddendumFromWireType(record.Addendum.GetAddendum()),
+			)
+			numberOfAddendums++
+		default:
+			return database.NewInvalidArgumentError("record", "received import request without a record")
+		}
+	}
+
+	for taskId := range data.Addendums {
+		if _, exists := seen[taskId]; !exists {
+			return database.NewInvalidArgumentError(
+				"record.addendum.task_id",
+				fmt.Sprintf("received addendum for task %d which has no task record", taskId),
+			)
+		}
+	}
+
+	newIds, err := s.db.Import(stream.Context(), userId, data)
+	if err != nil {
+		return fmt.Errorf("importing user data: %w", err)
+	}
+
+	taskIds := make(map[uint64]uint64, len(newIds))
+	for oldId, newId := range newIds {
+		taskIds[uint64(oldId)] = uint64(newId)
+	}
+	return stream.SendAndClose(&taskspb.ImportDataResponse{
+		TaskIds:           taskIds,
+		NumberOfAddendums: numberOfAddendums,
+	})
+}
+
+func (s *tasksServer) WatchTasks(
+	request *taskspb.WatchTasksRequest,
+	stream grpc.ServerStreamingServer[taskspb.WatchTasksResponse],
+) error {
+	userId, err := s.auth.GetUserId(stream.Context())
+	if err != nil {
+		return fmt.Errorf("getting user Id: %w", err)
+	}
+
+	tags := make([]database.Tag, len(request.GetTags()))
+	for i, t := range request.GetTags() {
+		tags[i] = database.Tag(t)
+	}
+	status := database.Status(request.GetStatus())
+	workspace := database.WorkspaceFromWire(request.WorkspaceId)
+	if err := s.db.RequireWorkspaceViewer(stream.Context(), userId, workspace); err != nil {
+		return fmt.Errorf("checking access to workspace: %w", err)
+	}
+
+	var subscription <-chan events.Event
+	var cancel func()
+	if workspaceId, exists := workspace.Unwrap(); exists {
+		subscription, cancel = s.broker.SubscribeWorkspace(workspaceId)
+	} else {
+		subscription, cancel = s.broker.Subscribe(userId)
+	}
+	defer cancel()
+	// flush headers now so clients can tell the watch is live before any event arrives
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return fmt.Errorf("sending header: %w", err)
+	}
+	for {
+		var event events.Event
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event = <-subscription:
+		}
+		if event.Type == taskspb.TaskEventType_RESYNC {
+			if err := stream.Send(&taskspb.WatchTasksResponse{Type: event.Type}); err != nil {
+				return fmt.Errorf("sending resync: %w", err)
+			}
+			continue
+		}
+
+		// events only carry ids, the task is read as it is now. This may be
+		// newer than the event, but never older.
+		described, err := s.db.Describe(stream.Context(), userId, event.TaskId)
+		if hidden(err) {
+			// the caller lost access to the task, or it is gone, since the event
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("finding task %d for event: %w", event.TaskId, err)
+		}
+		task := described.First
+		previousStatus, hasPreviousStatus := event.PreviousStatus.Unwrap()
+		if !task.HasAllTags(tags...) {
+			continue
+		}
+		if !task.HasStatus(status) && !(hasPreviousStatus && previousStatus == status) {
+			continue
+		}
+
+		response := event.ToWireType(task, described.Second)
+		if err := stream.Send(response); err != nil {
+			return fmt.Errorf("sending event: %w", err)
+		}
+	}
+}
+
+// Flattens user data into records, each task is followed by its addendums
+func toRecords(data database.UserData) []*taskspb.DataRecord {
+	var records []*taskspb.DataRecord
+	for _, taskAndId := range data.Tasks {
+		records = append(records, &taskspb.DataRecord{
+			Record: &taskspb.DataRecord_Task{
+				Task: &taskspb.TaskRecord{
+					TaskId: uint64(taskAndId.First),
+					Task:   taskAndId.Second.ToWireType(),
+				},
+			},
+		})
+		for _, a := range data.Addendums[taskAndId.First] {
+			records = append(records, &taskspb.DataRecord{
+				Record: &taskspb.DataRecord_Addendum{
+					Addendum: &taskspb.AddendumRecord{
+						TaskId:   uint64(taskAndId.First),
+						Addendum: a.ToWireType(),
+					},
+				},
+			})
+		}
+	}
+	return records
+}
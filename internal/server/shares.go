@@ -0,0 +1,67 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/WadeCappa/taskmaster/internal/auth"
+	"github.com/WadeCappa/taskmaster/internal/database"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"google.golang.org/grpc"
+)
+
+func (s *tasksServer) Share(
+	ctx context.Context,
+	request *taskspb.ShareRequest,
+) (*taskspb.ShareResponse, error) {
+	userId, err := s.auth.GetUserId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting user Id: %w", err)
+	}
+	share, err := database.ShareFromWireType(request, userId)
+	if err != nil {
+		return nil, fmt.Errorf("converting share from wire type: %w", err)
+	}
+	if err := s.db.Share(ctx, userId, share); err != nil {
+		return nil, fmt.Errorf("sharing: %w", err)
+	}
+	return &taskspb.ShareResponse{}, nil
+}
+
+func (s *tasksServer) ListShares(
+	request *taskspb.ListSharesRequest,
+	stream grpc.ServerStreamingServer[taskspb.ListSharesResponse],
+) error {
+	userId, err := s.auth.GetUserId(stream.Context())
+	if err != nil {
+		return fmt.Errorf("getting user Id: %w", err)
+	}
+	shares, err := s.db.GetShares(stream.Context(), userId)
+	if err != nil {
+		return fmt.Errorf("finding shares: %w", err)
+	}
+	for _, share := range shares {
+		if err := stream.Send(share.ToWireType()); err != nil {
+			return fmt.Errorf("sending share: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *tasksServer) Unshare(
+	ctx context.Context,
+	request *taskspb.UnshareRequest,
+) (*taskspb.UnshareResponse, error) {
+	userId, err := s.auth.GetUserId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting user Id: %w", err)
+	}
+	target, err := database.ShareTargetFromWireType(request.GetTarget())
+	if err != nil {
+		return nil, fmt.Errorf("converting share target from wire type: %w", err)
+	}
+	if err := s.db.Unshare(ctx, userId, target, auth.UserId(request.GetUserId())); err != nil {
+		return nil, fmt.Errorf("unsharing: %w", err)
+	}
+	return &taskspb.UnshareResponse{}, nil
+}
@@ -0,0 +1,55 @@
+package server_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/WadeCappa/taskmaster/internal/database"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func violatedFields(t *testing.T, err error) []string {
+	converted := status.Convert(intercept(fmt.Errorf("sharing: %w", err)))
+	require.Equal(t, codes.InvalidArgument, converted.Code())
+	badRequest, ok := converted.Details()[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+	var fields []string
+	for _, v := range badRequest.GetFieldViolations() {
+		fields = append(fields, v.GetField())
+	}
+	return fields
+}
+
+func TestShareValidation(t *testing.T) {
+	task := &taskspb.ShareTarget{Target: &taskspb.ShareTarget_TaskId{TaskId: 101}}
+	tag := &taskspb.ShareTarget{Target: &taskspb.ShareTarget_Tag{Tag: "work"}}
+
+	for _, request := range []*taskspb.ShareRequest{
+		{Target: task, UserId: 2, Role: taskspb.Role_VIEWER},
+		{Target: tag, UserId: 2, Role: taskspb.Role_EDITOR},
+	} {
+		_, err := database.ShareFromWireType(request, 1)
+		require.NoError(t, err)
+	}
+
+	_, err := database.ShareFromWireType(&taskspb.ShareRequest{}, 1)
+	require.Equal(t, []string{"target", "user_id"}, violatedFields(t, err))
+
+	// nobody can be made an owner, and owners already have access
+	_, err = database.ShareFromWireType(&taskspb.ShareRequest{Target: task, UserId: 1, Role: taskspb.Role_OWNER}, 1)
+	require.Equal(t, []string{"user_id", "role"}, violatedFields(t, err))
+
+	_, err = database.ShareFromWireType(&taskspb.ShareRequest{
+		Target: &taskspb.ShareTarget{Target: &taskspb.ShareTarget_Tag{}},
+		UserId: 2,
+		Role:   taskspb.Role(7),
+	}, 1)
+	require.Equal(t, []string{"target.tag", "role"}, violatedFields(t, err))
+
+	_, err = database.ShareTargetFromWireType(nil)
+	require.Equal(t, []string{"target"}, violatedFields(t, err))
+}
@@ -0,0 +1,36 @@
+package server
+
+import (
+	"context"
+
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Watches never finish on their own, so they would hold up a graceful stop
+// until its deadline. This ends them as soon as shutdown is done, telling
+// clients to watch again once this or another server is up.
+func EndWatchesOnShutdown(shutdown context.Context) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if info.FullMethod != taskspb.Tasks_WatchTasks_FullMethodName {
+			return handler(srv, stream)
+		}
+		ctx, cancel := context.WithCancel(stream.Context())
+		defer cancel()
+		stop := context.AfterFunc(shutdown, cancel)
+		defer stop()
+
+		err := handler(srv, &authorizedStream{ServerStream: stream, ctx: ctx})
+		if err == nil && shutdown.Err() != nil {
+			return status.Error(codes.Unavailable, "server is shutting down, watch again")
+		}
+		return err
+	}
+}
@@ -0,0 +1,46 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/WadeCappa/taskmaster/internal/server"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestEndWatchesOnShutdown(t *testing.T) {
+	shutdown, shutDown := context.WithCancel(context.Background())
+	interceptor := server.EndWatchesOnShutdown(shutdown)
+	stream := &contextStream{ctx: context.Background()}
+	watch := func(srv any, stream grpc.ServerStream) error {
+		<-stream.Context().Done()
+		return nil
+	}
+
+	ended := make(chan error)
+	go func() {
+		ended <- interceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: taskspb.Tasks_WatchTasks_FullMethodName}, watch)
+	}()
+	shutDown()
+	require.Equal(t, codes.Unavailable, status.Code(<-ended))
+
+	// other streams are left to finish on their own
+	err := interceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: taskspb.Tasks_ExportData_FullMethodName}, func(srv any, stream grpc.ServerStream) error {
+		require.NoError(t, stream.Context().Err())
+		return nil
+	})
+	require.NoError(t, err)
+}
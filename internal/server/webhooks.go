@@ -0,0 +1,117 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/WadeCappa/taskmaster/internal/database"
+	"github.com/WadeCappa/taskmaster/internal/types"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	DEFAULT_DELIVERIES_LIMIT = 50
+	MAX_DELIVERIES_LIMIT     = 500
+	WEBHOOK_SECRET_BYTES     = 32
+)
+
+func (s *tasksServer) CreateWebhook(
+	ctx context.Context,
+	request *taskspb.CreateWebhookRequest,
+) (*taskspb.CreateWebhookResponse, error) {
+	userId, err := s.auth.GetUserId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting user Id: %w", err)
+	}
+	webhook, err := database.WebhookFromWireType(request.GetWebhook())
+	if err != nil {
+		return nil, fmt.Errorf("converting webhook from wire type: %w", database.WithFieldPrefix(err, "webhook"))
+	}
+
+	secretBytes := make([]byte, WEBHOOK_SECRET_BYTES)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return nil, fmt.Errorf("generating webhook secret: %w", err)
+	}
+	secret := hex.EncodeToString(secretBytes)
+
+	webhookId, err := s.db.CreateWebhook(ctx, userId, webhook, secret)
+	if err != nil {
+		return nil, fmt.Errorf("creating webhook: %w", err)
+	}
+	return &taskspb.CreateWebhookResponse{
+		WebhookId: uint64(webhookId),
+		Secret:    secret,
+	}, nil
+}
+
+func (s *tasksServer) ListWebhooks(
+	request *taskspb.ListWebhooksRequest,
+	stream grpc.ServerStreamingServer[taskspb.ListWebhooksResponse],
+) error {
+	userId, err := s.auth.GetUserId(stream.Context())
+	if err != nil {
+		return fmt.Errorf("getting user Id: %w", err)
+	}
+	webhooks, err := s.db.GetWebhooks(stream.Context(), userId)
+	if err != nil {
+		return fmt.Errorf("finding webhooks: %w", err)
+	}
+	for _, w := range webhooks {
+		if err := stream.Send(&taskspb.ListWebhooksResponse{
+			WebhookId:   uint64(w.First),
+			Webhook:     w.Second.ToWireType(),
+			TimeCreated: timestamppb.New(w.Second.CreatedTime()),
+		}); err != nil {
+			return fmt.Errorf("sending webhook: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *tasksServer) DeleteWebhook(
+	ctx context.Context,
+	request *taskspb.DeleteWebhookRequest,
+) (*taskspb.DeleteWebhookResponse, error) {
+	userId, err := s.auth.GetUserId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting user Id: %w", err)
+	}
+	if err := s.db.DeleteWebhook(ctx, userId, database.WebhookId(request.GetWebhookId())); err != nil {
+		return nil, fmt.Errorf("deleting webhook: %w", err)
+	}
+	return &taskspb.DeleteWebhookResponse{}, nil
+}
+
+func (s *tasksServer) ListWebhookDeliveries(
+	request *taskspb.ListWebhookDeliveriesRequest,
+	stream grpc.ServerStreamingServer[taskspb.ListWebhookDeliveriesResponse],
+) error {
+	userId, err := s.auth.GetUserId(stream.Context())
+	if err != nil {
+		return fmt.Errorf("getting user Id: %w", err)
+	}
+
+	webhookId := types.None[database.WebhookId]()
+	if request.WebhookId != nil {
+		webhookId = types.Some(database.WebhookId(request.GetWebhookId()))
+	}
+	limit := DEFAULT_DELIVERIES_LIMIT
+	if request.GetLimit() > 0 {
+		limit = min(int(request.GetLimit()), MAX_DELIVERIES_LIMIT)
+	}
+
+	deliveries, err := s.db.GetDeliveries(stream.Context(), userId, webhookId, limit)
+	if err != nil {
+		return fmt.Errorf("finding deliveries: %w", err)
+	}
+	for _, d := range deliveries {
+		if err := stream.Send(d.ToWireType()); err != nil {
+			return fmt.Errorf("sending delivery: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/WadeCappa/taskmaster/internal/auth"
+	"github.com/WadeCappa/taskmaster/internal/database"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"google.golang.org/grpc"
+)
+
+const (
+	INVITE_CODE_BYTES = 16
+)
+
+func (s *tasksServer) CreateWorkspace(
+	ctx context.Context,
+	request *taskspb.CreateWorkspaceRequest,
+) (*taskspb.CreateWorkspaceResponse, error) {
+	userId, err := s.auth.GetUserId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting user Id: %w", err)
+	}
+	if request.GetName() == "" {
+		return nil, database.NewInvalidArgumentError("name", "workspaces need a name")
+	}
+
+	inviteBytes := make([]byte, INVITE_CODE_BYTES)
+	if _, err := rand.Read(inviteBytes); err != nil {
+		return nil, fmt.Errorf("generating invite code: %w", err)
+	}
+	inviteCode := hex.EncodeToString(inviteBytes)
+
+	workspaceId, err := s.db.CreateWorkspace(ctx, userId, request.GetName(), inviteCode)
+	if err != nil {
+		return nil, fmt.Errorf("creating workspace: %w", err)
+	}
+	return &taskspb.CreateWorkspaceResponse{
+		WorkspaceId: uint64(workspaceId),
+		InviteCode:  inviteCode,
+	}, nil
+}
+
+func (s *tasksServer) ListWorkspaces(
+	request *taskspb.ListWorkspacesRequest,
+	stream grpc.ServerStreamingServer[taskspb.ListWorkspacesResponse],
+) error {
+	userId, err := s.auth.GetUserId(stream.Context())
+	if err != nil {
+		return fmt.Errorf("getting user Id: %w", err)
+	}
+	workspaces, err := s.db.GetWorkspaces(stream.Context(), userId)
+	if err != nil {
+		return fmt.Errorf("finding workspaces: %w", err)
+	}
+	for _, w := range workspaces {
+		if err := stream.Send(w.Second.ToWireType(w.First)); err != nil {
+			return fmt.Errorf("sending workspace: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *tasksServer) JoinWorkspace(
+	ctx context.Context,
+	request *taskspb.JoinWorkspaceRequest,
+) (*taskspb.JoinWorkspaceResponse, error) {
+	userId, err := s.auth.GetUserId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting user Id: %w", err)
+	}
+	if request.GetInviteCode() == "" {
+		return nil, database.NewInvalidArgumentError("invite_code", "joining a workspace needs an invite code")
+	}
+	workspaceId, err := s.db.JoinWorkspace(ctx, userId, request.GetInviteCode())
+	if err != nil {
+		return nil, fmt.Errorf("joining workspace: %w", err)
+	}
+	return &taskspb.JoinWorkspaceResponse{WorkspaceId: uint64(workspaceId)}, nil
+}
+
+func (s *tasksServer) LeaveWorkspace(
+	ctx context.Context,
+	request *taskspb.LeaveWorkspaceRequest,
+) (*taskspb.LeaveWorkspaceResponse, error) {
+	userId, err := s.auth.GetUserId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting user Id: %w", err)
+	}
+	if err := s.db.LeaveWorkspace(ctx, userId, database.WorkspaceId(request.GetWorkspaceId())); err != nil {
+		return nil, fmt.Errorf("leaving workspace: %w", err)
+	}
+	return &taskspb.LeaveWorkspaceResponse{}, nil
+}
+
+func (s *tasksServer) ListWorkspaceMembers(
+	request *taskspb.ListWorkspaceMembersRequest,
+	stream grpc.ServerStreamingServer[taskspb.ListWorkspaceMembersResponse],
+) error {
+	userId, err := s.auth.GetUserId(stream.Context())
+	if err != nil {
+		return fmt.Errorf("getting user Id: %w", err)
+	}
+	members, err := s.db.GetWorkspaceMembers(
+		stream.Context(),
+		userId,
+		database.WorkspaceId(request.GetWorkspaceId()),
+	)
+	if err != nil {
+		return fmt.Errorf("finding workspace members: %w", err)
+	}
+	for _, m := range members {
+		if err := stream.Send(m.ToWireType()); err != nil {
+			return fmt.Errorf("sending workspace member: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *tasksServer) SetWorkspaceRole(
+	ctx context.Context,
+	request *taskspb.SetWorkspaceRoleRequest,
+) (*taskspb.SetWorkspaceRoleResponse, error) {
+	userId, err := s.auth.GetUserId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting user Id: %w", err)
+	}
+	role, err := database.WorkspaceRoleFromWire(request.GetRole())
+	if err != nil {
+		return nil, database.NewInvalidArgumentError("role", err.Error())
+	}
+	if err := s.db.SetWorkspaceRole(
+		ctx,
+		userId,
+		database.WorkspaceId(request.GetWorkspaceId()),
+		auth.UserId(request.GetUserId()),
+		role,
+	); err != nil {
+		return nil, fmt.Errorf("setting workspace role: %w", err)
+	}
+	return &taskspb.SetWorkspaceRoleResponse{}, nil
+}
@@ -0,0 +1,46 @@
+package store
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// queries that were not named with NameQueries are timed under this name
+const UNNAMED_QUERY = "unnamed"
+
+var (
+	connectionsOpened = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "taskmaster_store_connections_opened_total",
+		Help: "Connections opened to postgres. Every call opens its own connection",
+	})
+	connectionErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "taskmaster_store_connection_errors_total",
+		Help: "Attempts to connect to postgres that failed",
+	})
+	openConnections = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "taskmaster_store_open_connections",
+		Help: "Connections to postgres that are currently open, including listeners",
+	})
+	connectDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "taskmaster_store_connect_duration_seconds",
+		Help:    "How long it took to open a connection to postgres",
+		Buckets: prometheus.DefBuckets,
+	})
+	queryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "taskmaster_store_query_duration_seconds",
+		Help:    "How long each named query took, by whether it failed. Queries sent in a batch are not timed",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"query", "result"})
+)
+
+// maps the sql of a query to the name it is timed under. Only written to
+// during init, so it is not locked.
+var queryNames = map[string]string{}
+
+// Names queries by their sql, so that their durations can be told apart.
+// Should only be called from init.
+func NameQueries(names map[string]string) {
+	for sql, name := range names {
+		queryNames[sql] = name
+	}
+}
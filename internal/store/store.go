@@ -3,8 +3,12 @@ package store
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func Call(
@@ -13,11 +17,11 @@ func Call(
 	query func(*pgx.Conn) error,
 ) error {
 	// this is probably insecure. Will want to change how we access this in the future
-	conn, err := pgx.Connect(ctx, postgresUrl)
+	conn, err := connect(ctx, postgresUrl)
 	if err != nil {
 		return fmt.Errorf("connecting to postgres: %w", err)
 	}
-	defer conn.Close(ctx)
+	defer disconnect(ctx, conn)
 
 	if err := query(conn); err != nil {
 		return fmt.Errorf("querying postgres: %w", err)
@@ -31,14 +35,70 @@ func CallAndReturn[T any](
 	query func(*pgx.Conn) (*T, error),
 ) (*T, error) {
 	// this is probably insecure. Will want to change how we access this in the future
-	conn, err := pgx.Connect(ctx, postgresUrl)
+	conn, err := connect(ctx, postgresUrl)
 	if err != nil {
 		return nil, fmt.Errorf("connecting to postgres: %w", err)
 	}
-	defer conn.Close(ctx)
+	defer disconnect(ctx, conn)
 	res, err := query(conn)
 	if err != nil {
 		return nil, fmt.Errorf("querying postgres: %w", err)
 	}
 	return res, nil
 }
+
+// Blocks, passing the payload of every notification sent to channel to
+// consumer, until ctx is done or the connection fails.
+func Listen(
+	ctx context.Context,
+	postgresUrl string,
+	channel string,
+	consumer func(payload string),
+) error {
+	conn, err := connect(ctx, postgresUrl)
+	if err != nil {
+		return fmt.Errorf("connecting to postgres: %w", err)
+	}
+	defer disconnect(context.Background(), conn)
+
+	if _, err := conn.Exec(ctx, "listen "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return fmt.Errorf("listening on %s: %w", channel, err)
+	}
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("waiting for notification: %w", err)
+		}
+		consumer(notification.Payload)
+	}
+}
+
+// Opens a connection that times its queries, and is counted until it is
+// passed to disconnect
+func connect(ctx context.Context, postgresUrl string) (*pgx.Conn, error) {
+	config, err := pgx.ParseConfig(postgresUrl)
+	if err != nil {
+		return nil, fmt.Errorf("parsing postgres url: %w", err)
+	}
+	config.Tracer = queryTracer{}
+
+	ctx, span := otel.Tracer(TRACER_NAME).Start(ctx, "connect", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+	start := time.Now()
+	conn, err := pgx.ConnectConfig(ctx, config)
+	connectDuration.Observe(time.Since(start).Seconds())
+	if err != nil {
+		connectionErrors.Inc()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	connectionsOpened.Inc()
+	openConnections.Inc()
+	return conn, nil
+}
+
+func disconnect(ctx context.Context, conn *pgx.Conn) {
+	conn.Close(ctx)
+	openConnections.Dec()
+}
@@ -0,0 +1,55 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const TRACER_NAME = "github.com/WadeCappa/taskmaster/internal/store"
+
+type queryStartKey struct{}
+
+type queryStart struct {
+	name  string
+	start time.Time
+	span  trace.Span
+}
+
+// Times every query and records it as a span under the span in its context
+type queryTracer struct{}
+
+func (queryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	name, exists := queryNames[data.SQL]
+	if !exists {
+		name = UNNAMED_QUERY
+	}
+	ctx, span := otel.Tracer(TRACER_NAME).Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system.name", "postgresql"),
+			attribute.String("db.query.text", data.SQL),
+		),
+	)
+	return context.WithValue(ctx, queryStartKey{}, queryStart{name: name, start: time.Now(), span: span})
+}
+
+func (queryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	start, ok := ctx.Value(queryStartKey{}).(queryStart)
+	if !ok {
+		return
+	}
+	result := "ok"
+	if data.Err != nil {
+		result = "error"
+		start.span.RecordError(data.Err)
+		start.span.SetStatus(codes.Error, data.Err.Error())
+	}
+	queryDuration.WithLabelValues(start.name, result).Observe(time.Since(start.start).Seconds())
+	start.span.End()
+}
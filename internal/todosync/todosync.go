@@ -0,0 +1,183 @@
+package todosync
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/WadeCappa/taskmaster/internal/formats"
+	"github.com/WadeCappa/taskmaster/internal/types"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+type Preference int
+
+const (
+	PreferFile Preference = iota
+	PreferServer
+)
+
+// What a task looked like the last time it was synced. Lines are stored in a
+// canonical form so that formatting differences are not seen as edits.
+type Record struct {
+	Line    string `json:"line"`
+	Removed bool   `json:"removed,omitempty"`
+}
+
+type State map[uint64]Record
+
+type Line struct {
+	Text   string
+	TaskId types.Option[uint64]
+	// nil for blank lines
+	Task *taskspb.Task
+}
+
+type Plan struct {
+	Lines []*Line
+	// lines that need a new task, their TaskId should be set once it is created
+	Creates []*Line
+	// tasks that were edited in the file and need to be written to the server
+	Updates map[uint64]*taskspb.Task
+	// tasks that were removed from the file but still exist on the server
+	Removed   map[uint64]*taskspb.Task
+	Conflicts []string
+}
+
+// Works out how to bring the file and the server back in line with each other
+// using the state from the last sync as the common ancestor. A side that has
+// not changed since the last sync takes the other side's changes, when both
+// sides have changed the conflict is recorded and resolved using prefer.
+func Reconcile(
+	lines []string,
+	state State,
+	remote map[uint64]*taskspb.Task,
+	prefer Preference,
+) (Plan, error) {
+	plan := Plan{
+		Updates: map[uint64]*taskspb.Task{},
+		Removed: map[uint64]*taskspb.Task{},
+	}
+	var errs []error
+	seen := map[uint64]int{}
+	for i, text := range lines {
+		lineNumber := i + 1
+		if strings.TrimSpace(text) == "" {
+			plan.Lines = append(plan.Lines, &Line{Text: text})
+			continue
+		}
+		taskId, task, err := formats.ParseTodoTxt(text)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", lineNumber, err))
+			continue
+		}
+		line := &Line{Text: text, TaskId: taskId, Task: task}
+		plan.Lines = append(plan.Lines, line)
+
+		id, exists := taskId.Unwrap()
+		if !exists {
+			plan.Creates = append(plan.Creates, line)
+			continue
+		}
+		if previous, exists := seen[id]; exists {
+			errs = append(errs, fmt.Errorf("line %d: task %d is already on line %d", lineNumber, id, previous))
+			continue
+		}
+		seen[id] = lineNumber
+
+		remoteTask, exists := remote[id]
+		if !exists {
+			plan.Conflicts = append(plan.Conflicts, fmt.Sprintf("line %d: task %d does not exist on the server", lineNumber, id))
+			continue
+		}
+
+		local := Key(id, task)
+		server := Key(id, remoteTask)
+		base, hasBase := state[id]
+		switch {
+		case local == server:
+			line.Task = remoteTask
+		case hasBase && !base.Removed && base.Line == server:
+			plan.Updates[id] = merge(task, remoteTask)
+		case hasBase && !base.Removed && base.Line == local:
+			line.Text = formats.FormatTodoTxt(id, remoteTask)
+			line.Task = remoteTask
+		default:
+			plan.Conflicts = append(plan.Conflicts, fmt.Sprintf("line %d: task %d was changed in the file and on the server", lineNumber, id))
+			if prefer == PreferServer {
+				line.Text = formats.FormatTodoTxt(id, remoteTask)
+				line.Task = remoteTask
+			} else {
+				plan.Updates[id] = merge(task, remoteTask)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return Plan{}, errors.Join(errs...)
+	}
+
+	var missing []uint64
+	for id := range remote {
+		if _, exists := seen[id]; !exists {
+			missing = append(missing, id)
+		}
+	}
+	slices.Sort(missing)
+	for _, id := range missing {
+		remoteTask := remote[id]
+		base, hasBase := state[id]
+		switch {
+		case !hasBase:
+			// created on the server since the last sync
+		case base.Line == Key(id, remoteTask):
+			// removed from the file and untouched on the server
+			plan.Removed[id] = remoteTask
+			continue
+		default:
+			plan.Conflicts = append(plan.Conflicts, fmt.Sprintf("task %d was removed from the file and changed on the server", id))
+			if prefer == PreferFile {
+				plan.Removed[id] = remoteTask
+				continue
+			}
+		}
+		plan.Lines = append(plan.Lines, &Line{
+			Text:   formats.FormatTodoTxt(id, remoteTask),
+			TaskId: types.Some(id),
+			Task:   remoteTask,
+		})
+	}
+	return plan, nil
+}
+
+// The state to persist once all creates and updates have been applied
+func (p *Plan) State() State {
+	state := State{}
+	for _, line := range p.Lines {
+		if id, exists := line.TaskId.Unwrap(); exists {
+			state[id] = Record{Line: Key(id, line.Task)}
+		}
+	}
+	for id, task := range p.Removed {
+		state[id] = Record{Line: Key(id, task), Removed: true}
+	}
+	return state
+}
+
+// A canonical line for a task that ignores tag order and creation time, which
+// cannot be changed after a task has been created.
+func Key(taskId uint64, task *taskspb.Task) string {
+	canonical := proto.Clone(task).(*taskspb.Task)
+	canonical.TimeCreated = nil
+	canonical.Tags = slices.Clone(canonical.Tags)
+	slices.Sort(canonical.Tags)
+	return formats.FormatTodoTxt(taskId, canonical)
+}
+
+// A todo.txt line has no prerequisites, those are kept from the server
+func merge(local *taskspb.Task, remote *taskspb.Task) *taskspb.Task {
+	merged := proto.Clone(local).(*taskspb.Task)
+	merged.Prerequisites = remote.GetPrerequisites()
+	return merged
+}
@@ -0,0 +1,86 @@
+package todosync_test
+
+import (
+	"testing"
+
+	"github.com/WadeCappa/taskmaster/internal/formats"
+	"github.com/WadeCappa/taskmaster/internal/todosync"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func parse(t *testing.T, line string) *taskspb.Task {
+	_, task, err := formats.ParseTodoTxt(line)
+	require.NoError(t, err)
+	return task
+}
+
+func TestReconcileNewLinesAndTasks(t *testing.T) {
+	remote := map[uint64]*taskspb.Task{
+		5: parse(t, "(B) made on the server +work"),
+	}
+	plan, err := todosync.Reconcile([]string{"(A) made in the file +home", ""}, todosync.State{}, remote, todosync.PreferFile)
+	require.NoError(t, err)
+
+	require.Len(t, plan.Creates, 1)
+	require.Equal(t, "made in the file", plan.Creates[0].Task.GetName())
+	require.Empty(t, plan.Updates)
+	require.Empty(t, plan.Conflicts)
+	require.Len(t, plan.Lines, 3)
+	require.Equal(t, "(B) made on the server +work tm:5", plan.Lines[2].Text)
+}
+
+func TestReconcileTakesChangesFromTheSideThatChanged(t *testing.T) {
+	state := todosync.State{
+		1: {Line: "(A) edited in file +home tm:1"},
+		2: {Line: "(A) edited on server +home tm:2"},
+	}
+	remote := map[uint64]*taskspb.Task{
+		1: parse(t, "(A) edited in file +home"),
+		2: parse(t, "x edited on server +home pri:A"),
+	}
+	lines := []string{
+		"(C) edited in file +home tm:1",
+		"(A) edited on server +home tm:2",
+	}
+
+	plan, err := todosync.Reconcile(lines, state, remote, todosync.PreferFile)
+	require.NoError(t, err)
+	require.Empty(t, plan.Conflicts)
+	require.Len(t, plan.Updates, 1)
+	require.Equal(t, taskspb.Priority_SHOULD_DO, plan.Updates[1].GetPriority())
+	require.Equal(t, "(C) edited in file +home tm:1", plan.Lines[0].Text)
+	require.Equal(t, "x edited on server +home pri:A tm:2", plan.Lines[1].Text)
+}
+
+func TestReconcileConflicts(t *testing.T) {
+	state := todosync.State{1: {Line: "(A) task +home tm:1"}}
+	remote := map[uint64]*taskspb.Task{1: parse(t, "(B) task +home")}
+	lines := []string{"(C) task +home tm:1"}
+
+	plan, err := todosync.Reconcile(lines, state, remote, todosync.PreferFile)
+	require.NoError(t, err)
+	require.Len(t, plan.Conflicts, 1)
+	require.Contains(t, plan.Updates, uint64(1))
+	require.Equal(t, "(C) task +home tm:1", plan.Lines[0].Text)
+
+	plan, err = todosync.Reconcile(lines, state, remote, todosync.PreferServer)
+	require.NoError(t, err)
+	require.Len(t, plan.Conflicts, 1)
+	require.Empty(t, plan.Updates)
+	require.Equal(t, "(B) task +home tm:1", plan.Lines[0].Text)
+}
+
+func TestReconcileRemovedLinesStayRemoved(t *testing.T) {
+	state := todosync.State{1: {Line: "(A) task +home tm:1"}}
+	remote := map[uint64]*taskspb.Task{1: parse(t, "(A) task +home")}
+
+	plan, err := todosync.Reconcile(nil, state, remote, todosync.PreferFile)
+	require.NoError(t, err)
+	require.Empty(t, plan.Lines)
+	require.True(t, plan.State()[1].Removed)
+
+	plan, err = todosync.Reconcile(nil, plan.State(), remote, todosync.PreferFile)
+	require.NoError(t, err)
+	require.Empty(t, plan.Lines)
+}
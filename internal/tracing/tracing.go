@@ -0,0 +1,84 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	EXPORTER_NONE   = "none"
+	EXPORTER_OTLP   = "otlp"
+	EXPORTER_STDOUT = "stdout"
+)
+
+type Options struct {
+	// one of EXPORTER_NONE, EXPORTER_OTLP or EXPORTER_STDOUT
+	Exporter string
+	// host:port of an OTLP collector that accepts grpc. When empty the
+	// standard OTEL_EXPORTER_OTLP_ENDPOINT variable is used, or localhost:4317
+	Endpoint string
+	// send spans to the collector without TLS
+	Insecure bool
+	// where the stdout exporter writes spans, defaults to os.Stdout
+	Writer      io.Writer
+	ServiceName string
+}
+
+// Installs the global tracer provider and the w3c trace context propagator,
+// which carries traces across grpc calls. The returned function flushes any
+// spans that have not been exported yet and has to be called before exiting.
+// With EXPORTER_NONE spans are not recorded at all.
+func Setup(ctx context.Context, options Options) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch options.Exporter {
+	case EXPORTER_NONE, "":
+		return func(context.Context) error { return nil }, nil
+	case EXPORTER_OTLP:
+		var otlpOptions []otlptracegrpc.Option
+		if options.Endpoint != "" {
+			otlpOptions = append(otlpOptions, otlptracegrpc.WithEndpoint(options.Endpoint))
+		}
+		if options.Insecure {
+			otlpOptions = append(otlpOptions, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, otlpOptions...)
+	case EXPORTER_STDOUT:
+		writer := options.Writer
+		if writer == nil {
+			writer = os.Stdout
+		}
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(writer), stdouttrace.WithPrettyPrint())
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q, expected %q, %q or %q", options.Exporter, EXPORTER_NONE, EXPORTER_OTLP, EXPORTER_STDOUT)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("creating %s exporter: %w", options.Exporter, err)
+	}
+
+	serviceResource, err := resource.Merge(
+		resource.Default(),
+		resource.NewSchemaless(attribute.String("service.name", options.ServiceName)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("describing service: %w", err)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(serviceResource),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
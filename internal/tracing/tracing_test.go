@@ -0,0 +1,33 @@
+package tracing_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/WadeCappa/taskmaster/internal/tracing"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func TestUnknownExporter(t *testing.T) {
+	_, err := tracing.Setup(context.Background(), tracing.Options{Exporter: "zipkin"})
+	require.ErrorContains(t, err, "unknown trace exporter")
+}
+
+func TestStdoutExporter(t *testing.T) {
+	var out bytes.Buffer
+	shutdown, err := tracing.Setup(context.Background(), tracing.Options{
+		Exporter:    tracing.EXPORTER_STDOUT,
+		Writer:      &out,
+		ServiceName: "tracing-test",
+	})
+	require.NoError(t, err)
+
+	_, span := otel.Tracer("tracing_test").Start(context.Background(), "some work")
+	span.End()
+	require.NoError(t, shutdown(context.Background()))
+
+	require.Contains(t, out.String(), "some work")
+	require.Contains(t, out.String(), "tracing-test")
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/WadeCappa/taskmaster/internal/types"
 	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
 )
 
@@ -24,6 +25,13 @@ func priorityLabel(p taskspb.Priority) string {
 	}
 }
 
+func assigneeLabel(assignee types.Option[uint64]) string {
+	if userId, exists := assignee.Unwrap(); exists {
+		return fmt.Sprintf("@%d", userId)
+	}
+	return "-"
+}
+
 func formatDuration(minutes uint64) string {
 	if minutes == 0 {
 		return "-"
@@ -1,11 +1,19 @@
 package tui
 
-import "github.com/WadeCappa/taskmaster/internal/types"
+import (
+	"github.com/WadeCappa/taskmaster/internal/types"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"google.golang.org/grpc"
+)
 
 type maybeTasksLoadedEvent struct {
 	result types.Result[[]taskEntry]
 }
 
+type maybeWorkspacesLoadedEvent struct {
+	result types.Result[[]workspaceEntry]
+}
+
 type maybeTaskDetailLoadedEvent struct {
 	result types.Result[taskDetailLoadedEvent]
 }
@@ -14,3 +22,17 @@ type taskDetailLoadedEvent struct {
 	taskId uint64
 	detail *taskDetail
 }
+
+type maybeWatchEvent struct {
+	generation int
+	result     types.Result[watchEvent]
+}
+
+type watchEvent struct {
+	stream   grpc.ServerStreamingClient[taskspb.WatchTasksResponse]
+	response *taskspb.WatchTasksResponse
+}
+
+type reconnectEvent struct {
+	generation int
+}
@@ -2,9 +2,13 @@ package tui
 
 import (
 	"context"
+	"time"
 
+	"github.com/WadeCappa/taskmaster/internal/types"
 	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
 	tea "github.com/charmbracelet/bubbletea"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Model struct {
@@ -13,6 +17,11 @@ type Model struct {
 
 	activeStatus int
 	tags         []string
+	assignment   taskspb.AssignmentFilter
+
+	// the personal workspace is always first
+	workspaces      []workspaceEntry
+	activeWorkspace int
 
 	editingTags bool
 	tagInput    string
@@ -30,21 +39,65 @@ type Model struct {
 	tasksErr     error
 	detailErr    error
 
+	watch watchState
+
 	tui tuiState
 }
 
+type watchState struct {
+	// events from any subscription other than the current generation are stale
+	generation int
+	ctx        context.Context
+	cancel     context.CancelFunc
+
+	connection       connectionState
+	reconnectDelay   time.Duration
+	reconnectPending bool
+	// why the watch stopped, when it failed in a way that retrying won't fix
+	err error
+}
+
+const TRACER_NAME = "github.com/WadeCappa/taskmaster/internal/tui"
+
+type connectionState int
+
+const (
+	connecting connectionState = iota
+	live
+	reconnecting
+	stopped
+)
+
+type workspaceEntry struct {
+	id   types.Option[uint64]
+	name string
+}
+
 type tuiState struct {
 	width  int
 	height int
 }
 
 func NewModel(client taskspb.TasksClient, ctx context.Context) Model {
+	watchCtx, cancel := context.WithCancel(ctx)
 	return Model{
-		client: client,
-		ctx:    ctx,
+		client:     client,
+		ctx:        ctx,
+		workspaces: []workspaceEntry{{id: types.None[uint64](), name: "personal"}},
+		watch: watchState{
+			ctx:            watchCtx,
+			cancel:         cancel,
+			reconnectDelay: MIN_RECONNECT_DELAY,
+		},
 	}
 }
 
+// Each request gets a trace of its own, rather than every request being part
+// of one trace that lasts as long as the tui
+func (m Model) startSpan(name string) (context.Context, trace.Span) {
+	return otel.Tracer(TRACER_NAME).Start(m.ctx, name, trace.WithNewRoot())
+}
+
 func (m Model) Init() tea.Cmd {
-	return m.fetchTasksCmd()
+	return tea.Batch(m.fetchTasksCmd(), m.startWatchCmd(), m.fetchWorkspacesCmd())
 }
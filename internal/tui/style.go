@@ -11,4 +11,5 @@ var (
 	tagInputStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
 	statusHighlight = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("214"))
 	detailLabel     = lipgloss.NewStyle().Bold(true)
+	liveStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
 )
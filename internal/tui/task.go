@@ -1,6 +1,9 @@
 package tui
 
-import taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+import (
+	"github.com/WadeCappa/taskmaster/internal/types"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+)
 
 type taskEntry struct {
 	id       uint64
@@ -9,11 +12,18 @@ type taskEntry struct {
 	minutes  uint64
 	tags     []string
 	addCount uint64
+	assignee types.Option[uint64]
 }
 
 func taskFromWire(getTaskResponse *taskspb.GetTasksResponse) taskEntry {
-	task := getTaskResponse.GetTask()
-	id := getTaskResponse.GetTaskId()
+	return entryFromWire(getTaskResponse.GetTaskId(), getTaskResponse.GetTask())
+}
+
+func entryFromWire(id uint64, task *taskspb.Task) taskEntry {
+	assignee := types.None[uint64]()
+	if task.AssigneeUserId != nil {
+		assignee = types.Some(task.GetAssigneeUserId())
+	}
 	return taskEntry{
 		id:       id,
 		name:     task.GetName(),
@@ -21,5 +31,6 @@ func taskFromWire(getTaskResponse *taskspb.GetTasksResponse) taskEntry {
 		minutes:  task.GetMinutesToComplete(),
 		tags:     task.GetTags(),
 		addCount: task.GetNumberOfAddendums(),
+		assignee: assignee,
 	}
 }
@@ -2,6 +2,8 @@ package tui
 
 import (
 	"fmt"
+	"io"
+	"slices"
 	"strings"
 
 	"github.com/WadeCappa/taskmaster/internal/calls"
@@ -22,22 +24,45 @@ func (m Model) Update(message tea.Msg) (tea.Model, tea.Cmd) {
 		m.tasksLoading = false
 		tasks, err := message.result.Unwrap()
 		if err != nil {
+			if isUnavailable(err) {
+				// keep showing what we have, the reconnect will reload the list
+				return m, m.scheduleReconnect()
+			}
 			m.tasksErr = err
 			m.tasks = nil
 			return m, nil
 		}
+		selected := types.None[uint64]()
+		if len(m.tasks) > 0 {
+			selected = types.Some(m.tasks[m.taskCursor].id)
+		}
 		m.tasksErr = nil
 		m.tasks = tasks
 		m.taskCursor = 0
+		if selectedId, exists := selected.Unwrap(); exists {
+			m.taskCursor = max(slices.IndexFunc(m.tasks, func(entry taskEntry) bool {
+				return entry.id == selectedId
+			}), 0)
+		}
 		m.taskListOffset = 0
+		m.adjustOffset()
 		m.detail = nil
 		m.detailErr = nil
-		if len(m.tasks) > 0 {
-			m.detailLoading = true
-			m.detailTaskId = m.tasks[0].id
-			return m, m.fetchDetailCmd(m.tasks[0].id)
+		return m, m.selectCurrent()
+	case maybeWorkspacesLoadedEvent:
+		workspaces, err := message.result.Unwrap()
+		if err != nil {
+			// without the list we can still show the personal workspace
+			return m, nil
 		}
+		m.workspaces = append(m.workspaces[:1], workspaces...)
 		return m, nil
+	case maybeWatchEvent:
+		cmd := m.handleWatchEvent(message)
+		return m, cmd
+	case reconnectEvent:
+		cmd := m.handleReconnect(message)
+		return m, cmd
 	case maybeTaskDetailLoadedEvent:
 		event, err := message.result.Unwrap()
 		if err != nil {
@@ -66,6 +91,7 @@ func (m Model) handleKey(message tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	switch message.String() {
 	case "q", "ctrl+c":
+		m.watch.cancel()
 		return m, tea.Quit
 	case "j", "down":
 		if len(m.tasks) > 0 {
@@ -91,10 +117,20 @@ func (m Model) handleKey(message tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.activeStatus < 0 {
 			m.activeStatus = len(taskspb.Status_value) - 1
 		}
-		return m, m.refetch()
+		cmd := m.refetch()
+		return m, cmd
 	case "l", "right":
 		m.activeStatus = (m.activeStatus + 1) % len(taskspb.Status_value)
-		return m, m.refetch()
+		cmd := m.refetch()
+		return m, cmd
+	case "a":
+		m.assignment = (m.assignment + 1) % taskspb.AssignmentFilter(len(taskspb.AssignmentFilter_value))
+		cmd := m.refetch()
+		return m, cmd
+	case "w":
+		m.activeWorkspace = (m.activeWorkspace + 1) % len(m.workspaces)
+		cmd := m.refetch()
+		return m, cmd
 	case "t":
 		m.editingTags = true
 		m.savedTags = m.tags
@@ -121,7 +157,8 @@ func (m Model) handleTagEditKey(message tea.KeyMsg) (tea.Model, tea.Cmd) {
 				}
 			}
 		}
-		return m, m.refetch()
+		cmd := m.refetch()
+		return m, cmd
 	case "esc":
 		m.editingTags = false
 		m.tags = m.savedTags
@@ -154,7 +191,7 @@ func (m *Model) adjustOffset() {
 
 func (m *Model) refetch() tea.Cmd {
 	m.tasksLoading = true
-	return m.fetchTasksCmd()
+	return tea.Batch(m.fetchTasksCmd(), m.restartWatch())
 }
 
 func (m Model) listHeight() int {
@@ -164,7 +201,10 @@ func (m Model) listHeight() int {
 
 func (m Model) fetchTasksCmd() tea.Cmd {
 	return func() tea.Msg {
-		responses, err := calls.Get(m.ctx, m.client, m.tags, taskspb.Status(m.activeStatus))
+		ctx, span := m.startSpan("get tasks")
+		defer span.End()
+		workspace := m.workspaces[m.activeWorkspace].id
+		responses, err := calls.Get(ctx, m.client, workspace, m.assignment, m.tags, taskspb.Status(m.activeStatus))
 		if err != nil {
 			return maybeTasksLoadedEvent{
 				result: types.Failure[[]taskEntry](fmt.Errorf("getting tasks from server: %w", err)),
@@ -179,9 +219,40 @@ func (m Model) fetchTasksCmd() tea.Cmd {
 	}
 }
 
+func (m Model) fetchWorkspacesCmd() tea.Cmd {
+	return func() tea.Msg {
+		ctx, span := m.startSpan("list workspaces")
+		defer span.End()
+		stream, err := m.client.ListWorkspaces(ctx, &taskspb.ListWorkspacesRequest{})
+		if err != nil {
+			return maybeWorkspacesLoadedEvent{
+				result: types.Failure[[]workspaceEntry](fmt.Errorf("getting workspaces from server: %w", err)),
+			}
+		}
+		workspaces := []workspaceEntry{}
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				return maybeWorkspacesLoadedEvent{types.Success(workspaces)}
+			}
+			if err != nil {
+				return maybeWorkspacesLoadedEvent{
+					result: types.Failure[[]workspaceEntry](fmt.Errorf("receiving workspace: %w", err)),
+				}
+			}
+			workspaces = append(workspaces, workspaceEntry{
+				id:   types.Some(resp.GetWorkspaceId()),
+				name: resp.GetName(),
+			})
+		}
+	}
+}
+
 func (m Model) fetchDetailCmd(taskId uint64) tea.Cmd {
 	return func() tea.Msg {
-		resp, err := m.client.DescribeTask(m.ctx, &taskspb.DescribeTaskRequest{
+		ctx, span := m.startSpan("describe task")
+		defer span.End()
+		resp, err := m.client.DescribeTask(ctx, &taskspb.DescribeTaskRequest{
 			TaskId: taskId,
 		})
 		if err != nil {
@@ -6,6 +6,7 @@ import (
 
 	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
 	"github.com/charmbracelet/lipgloss"
+	"google.golang.org/grpc/status"
 )
 
 func (m Model) View() string {
@@ -41,7 +42,10 @@ func (m Model) viewTopBar() string {
 		tagSection = "Tags: " + dimStyle.Render("<none>")
 	}
 
-	bar := statusSection + "  | " + tagSection
+	workspaceSection := "Workspace: " + m.workspaces[m.activeWorkspace].name
+	assignmentSection := "Showing: " + strings.ToLower(strings.ReplaceAll(m.assignment.String(), "_", " "))
+
+	bar := statusSection + "  | " + tagSection + "  | " + workspaceSection + "  | " + assignmentSection + "  | " + m.viewConnection()
 
 	style := lipgloss.NewStyle().
 		Width(m.tui.width).
@@ -51,6 +55,19 @@ func (m Model) viewTopBar() string {
 	return style.Render(bar)
 }
 
+func (m Model) viewConnection() string {
+	switch m.watch.connection {
+	case live:
+		return liveStyle.Render("● live")
+	case reconnecting:
+		return errorStyle.Render("○ reconnecting")
+	case stopped:
+		return errorStyle.Render("○ stopped: " + status.Code(m.watch.err).String())
+	default:
+		return dimStyle.Render("○ connecting")
+	}
+}
+
 func (m Model) viewPanels() string {
 	leftWidth := m.tui.width/2 - 2
 	rightWidth := m.tui.width - leftWidth - 4
@@ -89,9 +106,10 @@ func (m Model) viewTaskList(width, height int) string {
 		name := t.name
 		prio := priorityLabel(t.priority)
 		timeStr := formatDuration(t.minutes)
+		assignee := assigneeLabel(t.assignee)
 
 		// Truncate name if too long
-		maxName := width - len(prefix) - len(prio) - len(timeStr) - 4
+		maxName := width - len(prefix) - len(prio) - len(timeStr) - len(assignee) - 5
 		if maxName < 5 {
 			maxName = 5
 		}
@@ -99,12 +117,12 @@ func (m Model) viewTaskList(width, height int) string {
 			name = name[:maxName-1] + "…"
 		}
 
-		gap := width - len(prefix) - len(name) - len(prio) - len(timeStr) - 2
+		gap := width - len(prefix) - len(name) - len(prio) - len(timeStr) - len(assignee) - 3
 		if gap < 1 {
 			gap = 1
 		}
 
-		line := prefix + name + strings.Repeat(" ", gap) + timeStr + " " + prio
+		line := prefix + name + strings.Repeat(" ", gap) + assignee + " " + timeStr + " " + prio
 		if i == m.taskCursor {
 			line = selectedStyle.Render(line)
 		}
@@ -165,7 +183,7 @@ func (m Model) viewHelpBar() string {
 	if m.editingTags {
 		help = "enter: apply tags  esc: cancel  ctrl+c: quit"
 	} else {
-		help = "j/k: navigate  J/L: status  t: edit tags  q: quit"
+		help = "j/k: navigate  J/L: status  t: edit tags  w: workspace  a: assignment  q: quit"
 	}
 	return helpStyle.Padding(0, 1).Render(help)
 }
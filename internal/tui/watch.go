@@ -0,0 +1,222 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/WadeCappa/taskmaster/internal/types"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	tea "github.com/charmbracelet/bubbletea"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	MIN_RECONNECT_DELAY = 500 * time.Millisecond
+	MAX_RECONNECT_DELAY = 30 * time.Second
+)
+
+// restartWatch drops the current subscription, any events still in flight from it
+// are ignored once they arrive.
+func (m *Model) restartWatch() tea.Cmd {
+	m.watch.cancel()
+	m.watch.generation++
+	m.watch.ctx, m.watch.cancel = context.WithCancel(m.ctx)
+	m.watch.reconnectPending = false
+	m.watch.err = nil
+	if m.watch.connection != reconnecting {
+		m.watch.connection = connecting
+	}
+	return m.startWatchCmd()
+}
+
+func (m Model) startWatchCmd() tea.Cmd {
+	ctx := m.watch.ctx
+	generation := m.watch.generation
+	request := &taskspb.WatchTasksRequest{
+		Status: taskspb.Status(m.activeStatus),
+		Tags:   m.tags,
+	}
+	if workspaceId, exists := m.workspaces[m.activeWorkspace].id.Unwrap(); exists {
+		request.WorkspaceId = &workspaceId
+	}
+	return func() tea.Msg {
+		stream, err := m.client.WatchTasks(ctx, request)
+		if err != nil {
+			return maybeWatchEvent{
+				generation: generation,
+				result:     types.Failure[watchEvent](fmt.Errorf("watching tasks: %w", err)),
+			}
+		}
+		// grpc only surfaces connection errors once we try to read from the stream,
+		// so a header round trip tells us whether we are actually connected
+		if _, err := stream.Header(); err != nil {
+			return maybeWatchEvent{
+				generation: generation,
+				result:     types.Failure[watchEvent](fmt.Errorf("watching tasks: %w", err)),
+			}
+		}
+		return maybeWatchEvent{
+			generation: generation,
+			result:     types.Success(watchEvent{stream: stream}),
+		}
+	}
+}
+
+func (m Model) nextWatchEventCmd(stream grpc.ServerStreamingClient[taskspb.WatchTasksResponse]) tea.Cmd {
+	generation := m.watch.generation
+	return func() tea.Msg {
+		response, err := stream.Recv()
+		if err != nil {
+			return maybeWatchEvent{
+				generation: generation,
+				result:     types.Failure[watchEvent](fmt.Errorf("receiving task event: %w", err)),
+			}
+		}
+		return maybeWatchEvent{
+			generation: generation,
+			result:     types.Success(watchEvent{stream: stream, response: response}),
+		}
+	}
+}
+
+func (m *Model) handleWatchEvent(message maybeWatchEvent) tea.Cmd {
+	if message.generation != m.watch.generation {
+		return nil
+	}
+	event, err := message.result.Unwrap()
+	if err != nil && isUnavailable(err) {
+		return m.scheduleReconnect()
+	}
+	if err != nil {
+		// retrying won't help, so show why until the next refetch watches again
+		m.watch.connection = stopped
+		m.watch.err = err
+		return nil
+	}
+
+	if m.watch.connection == reconnecting {
+		// we may have missed events while disconnected, so start again from a fresh list
+		m.watch.connection = live
+		m.watch.reconnectDelay = MIN_RECONNECT_DELAY
+		return tea.Batch(m.fetchTasksCmd(), m.nextWatchEventCmd(event.stream))
+	}
+	m.watch.connection = live
+	m.watch.reconnectDelay = MIN_RECONNECT_DELAY
+	if event.response == nil {
+		return m.nextWatchEventCmd(event.stream)
+	}
+	if event.response.GetType() == taskspb.TaskEventType_RESYNC || m.assignment != taskspb.AssignmentFilter_ALL_TASKS {
+		// we missed events, or events don't say who is asking for a task, so
+		// reload the list instead
+		return tea.Batch(m.fetchTasksCmd(), m.nextWatchEventCmd(event.stream))
+	}
+	return tea.Batch(m.mergeTaskEvent(event.response), m.nextWatchEventCmd(event.stream))
+}
+
+func (m *Model) scheduleReconnect() tea.Cmd {
+	m.watch.connection = reconnecting
+	if m.watch.reconnectPending {
+		return nil
+	}
+	m.watch.reconnectPending = true
+	delay := m.watch.reconnectDelay
+	m.watch.reconnectDelay = min(m.watch.reconnectDelay*2, MAX_RECONNECT_DELAY)
+	generation := m.watch.generation
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return reconnectEvent{generation: generation}
+	})
+}
+
+func (m *Model) handleReconnect(message reconnectEvent) tea.Cmd {
+	if message.generation != m.watch.generation {
+		return nil
+	}
+	return m.restartWatch()
+}
+
+// mergeTaskEvent applies a single change to the task list without reloading it,
+// keeping the cursor on whichever task it was on before.
+func (m *Model) mergeTaskEvent(response *taskspb.WatchTasksResponse) tea.Cmd {
+	selected := types.None[uint64]()
+	if len(m.tasks) > 0 {
+		selected = types.Some(m.tasks[m.taskCursor].id)
+	}
+
+	taskId := response.GetTaskId()
+	task := response.GetTask()
+	index := slices.IndexFunc(m.tasks, func(entry taskEntry) bool {
+		return entry.id == taskId
+	})
+	visible := task.GetStatus() == taskspb.Status(m.activeStatus) && hasAllTags(task.GetTags(), m.tags)
+
+	switch {
+	case visible && index >= 0:
+		m.tasks[index] = entryFromWire(taskId, task)
+	case visible:
+		// keep the same ordering the server uses, priority first then oldest first
+		position := len(m.tasks)
+		for i, entry := range m.tasks {
+			if entry.priority > task.GetPriority() {
+				position = i
+				break
+			}
+		}
+		m.tasks = slices.Insert(m.tasks, position, entryFromWire(taskId, task))
+	case index >= 0:
+		m.tasks = slices.Delete(m.tasks, index, index+1)
+	default:
+		return nil
+	}
+
+	selectedId, exists := selected.Unwrap()
+	if !exists {
+		// the list was empty, so there is nothing to keep the cursor on
+		m.taskCursor = 0
+		m.adjustOffset()
+		return m.selectCurrent()
+	}
+	if position := slices.IndexFunc(m.tasks, func(entry taskEntry) bool {
+		return entry.id == selectedId
+	}); position >= 0 {
+		m.taskCursor = position
+		m.adjustOffset()
+		if selectedId == taskId {
+			return m.fetchDetailCmd(taskId)
+		}
+		return nil
+	}
+
+	// the selected task left this view, move to whatever took its place
+	m.taskCursor = min(m.taskCursor, max(len(m.tasks)-1, 0))
+	m.adjustOffset()
+	return m.selectCurrent()
+}
+
+func (m *Model) selectCurrent() tea.Cmd {
+	if len(m.tasks) == 0 {
+		m.detail = nil
+		m.detailErr = nil
+		m.detailLoading = false
+		return nil
+	}
+	m.detailLoading = true
+	m.detailTaskId = m.tasks[m.taskCursor].id
+	return m.fetchDetailCmd(m.detailTaskId)
+}
+
+func hasAllTags(tags []string, required []string) bool {
+	for _, tag := range required {
+		if !slices.Contains(tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+func isUnavailable(err error) bool {
+	return status.Code(err) == codes.Unavailable
+}
@@ -0,0 +1,42 @@
+package webhooks
+
+import (
+	"fmt"
+	"strings"
+
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+)
+
+// Parses a filter written as comma separated key=value pairs, for example
+// "type=STATUS_CHANGED,status=COMPLETED" or "type=CREATED,tag=oncall". tag may
+// be repeated, names are case insensitive.
+func ParseFilter(filter string) (*taskspb.WebhookFilter, error) {
+	parsed := &taskspb.WebhookFilter{}
+	for _, pair := range strings.Split(filter, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if !found || value == "" {
+			return nil, fmt.Errorf("expected key=value, got %q", pair)
+		}
+		switch strings.ToLower(key) {
+		case "type":
+			eventType, exists := taskspb.TaskEventType_value[strings.ToUpper(value)]
+			if !exists {
+				return nil, fmt.Errorf("unrecognized event type %q", value)
+			}
+			asType := taskspb.TaskEventType(eventType)
+			parsed.Type = &asType
+		case "status":
+			status, exists := taskspb.Status_value[strings.ToUpper(value)]
+			if !exists {
+				return nil, fmt.Errorf("unrecognized status %q", value)
+			}
+			asStatus := taskspb.Status(status)
+			parsed.Status = &asStatus
+		case "tag":
+			parsed.Tags = append(parsed.Tags, value)
+		default:
+			return nil, fmt.Errorf("unrecognized filter key %q", key)
+		}
+	}
+	return parsed, nil
+}
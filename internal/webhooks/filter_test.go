@@ -0,0 +1,30 @@
+package webhooks_test
+
+import (
+	"testing"
+
+	"github.com/WadeCappa/taskmaster/internal/webhooks"
+	taskspb "github.com/WadeCappa/taskmaster/pkg/go/tasks/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilter(t *testing.T) {
+	filter, err := webhooks.ParseFilter("type=status_changed, status=COMPLETED")
+	require.NoError(t, err)
+	require.Equal(t, taskspb.TaskEventType_STATUS_CHANGED, filter.GetType())
+	require.Equal(t, taskspb.Status_COMPLETED, filter.GetStatus())
+	require.Empty(t, filter.GetTags())
+
+	filter, err = webhooks.ParseFilter("type=CREATED,tag=oncall,tag=prod")
+	require.NoError(t, err)
+	require.Equal(t, taskspb.TaskEventType_CREATED, filter.GetType())
+	require.Nil(t, filter.Status)
+	require.Equal(t, []string{"oncall", "prod"}, filter.GetTags())
+}
+
+func TestParseFilterRejectsUnknownValues(t *testing.T) {
+	for _, filter := range []string{"", "type=DELETED", "status=DONE", "colour=red", "tag="} {
+		_, err := webhooks.ParseFilter(filter)
+		require.Error(t, err, filter)
+	}
+}
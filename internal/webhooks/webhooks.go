@@ -0,0 +1,282 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/WadeCappa/taskmaster/internal/database"
+	"github.com/WadeCappa/taskmaster/internal/events"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	SIGNATURE_HEADER = "X-Taskmaster-Signature"
+	TIMESTAMP_HEADER = "X-Taskmaster-Timestamp"
+	DELIVERY_HEADER  = "X-Taskmaster-Delivery"
+	SIGNATURE_PREFIX = "sha256="
+
+	POLL_INTERVAL   = time.Second
+	BATCH_SIZE      = 32
+	REQUEST_TIMEOUT = 10 * time.Second
+	MAX_ATTEMPTS    = 12
+	MIN_RETRY_DELAY = 10 * time.Second
+	MAX_RETRY_DELAY = time.Hour
+)
+
+// Moves task events into the delivery outbox, and from the outbox to the
+// webhooks themselves.
+type Dispatcher struct {
+	db     *database.Database
+	client *http.Client
+}
+
+// Unless allowPrivate is set, deliveries to loopback, private and link-local
+// addresses are refused, so users of a hosted server cannot reach its internals.
+func NewDispatcher(db *database.Database, allowPrivate bool) *Dispatcher {
+	return &Dispatcher{
+		db:     db,
+		client: NewClient(allowPrivate),
+	}
+}
+
+// The client deliveries are sent with. The address is checked after it has
+// been resolved, so a public hostname pointing somewhere private is refused as
+// well. Proxies are ignored since they would dial on our behalf.
+func NewClient(allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: REQUEST_TIMEOUT}
+	if !allowPrivate {
+		dialer.Control = refusePrivate
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: REQUEST_TIMEOUT, Transport: transport}
+}
+
+func refusePrivate(network string, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("parsing dialed address: %w", err)
+	}
+	addr := addrPort.Addr().Unmap()
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() || addr.IsUnspecified() {
+		return fmt.Errorf("refusing to deliver to %s, it is not a public address", addr)
+	}
+	return nil
+}
+
+// Turns the task events that postgres records for webhooks into deliveries
+// until ctx is done. Any number of servers can run this against the same
+// database.
+func (d *Dispatcher) Enqueue(ctx context.Context) {
+	ticker := time.NewTicker(POLL_INTERVAL)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for ctx.Err() == nil {
+			handled, err := d.db.EnqueueDeliveries(ctx, BATCH_SIZE, payload)
+			if err != nil {
+				slog.Error("enqueueing webhook deliveries failed", "error", err.Error())
+				break
+			}
+			if handled < BATCH_SIZE {
+				break
+			}
+		}
+	}
+}
+
+// The WatchTasksResponse for the event as json, describing the task as it was
+// when the event happened
+func payload(event database.TaskEvent) (string, error) {
+	var addendums []database.Addendum
+	if addendum, exists := event.Addendum.Unwrap(); exists {
+		addendums = append(addendums, addendum)
+	}
+	wire := events.Event{
+		Type:           event.Type,
+		TaskId:         event.TaskId,
+		PreviousStatus: event.PreviousStatus,
+	}.ToWireType(event.Task, addendums)
+	encoded, err := protojson.Marshal(wire)
+	if err != nil {
+		return "", fmt.Errorf("converting event to json: %w", err)
+	}
+	return string(encoded), nil
+}
+
+// Sends pending deliveries from the outbox until ctx is done. Any number of
+// servers can run this against the same database.
+func (d *Dispatcher) Deliver(ctx context.Context) {
+	ticker := time.NewTicker(POLL_INTERVAL)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// keep going while there is a backlog rather than waiting for the next tick
+		for ctx.Err() == nil {
+			claimed, err := d.deliverBatch(ctx)
+			if err != nil {
+				slog.Error("delivering webhooks failed", "error", err.Error())
+				break
+			}
+			if claimed < BATCH_SIZE {
+				break
+			}
+		}
+	}
+}
+
+func (d *Dispatcher) deliverBatch(ctx context.Context) (int, error) {
+	// the lease outlasts any attempt, so a delivery is only claimed again if
+	// this server died while sending it
+	deliveries, err := d.db.ClaimDeliveries(ctx, BATCH_SIZE, time.Now().Add(2*REQUEST_TIMEOUT))
+	if err != nil {
+		return 0, fmt.Errorf("claiming deliveries: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			responseCode, err := Send(ctx, d.client, delivery, time.Now())
+			attempt := nextAttempt(delivery, responseCode, err, time.Now())
+			if err := d.db.FinishDeliveryAttempt(ctx, delivery.Id, attempt); err != nil {
+				slog.Error("recording delivery attempt failed", "delivery", delivery.Id, "error", err.Error())
+			}
+		}()
+	}
+	wg.Wait()
+	return len(deliveries), nil
+}
+
+func nextAttempt(
+	delivery database.PendingDelivery,
+	responseCode uint32,
+	err error,
+	now time.Time,
+) database.DeliveryAttempt {
+	if err == nil {
+		return database.DeliveryAttempt{
+			State:        database.Delivered,
+			NextAttempt:  now,
+			ResponseCode: responseCode,
+		}
+	}
+	attempts := delivery.Attempts + 1
+	state := database.Pending
+	if attempts >= MAX_ATTEMPTS {
+		state = database.Failed
+	}
+	return database.DeliveryAttempt{
+		State:        state,
+		NextAttempt:  now.Add(RetryDelay(attempts)),
+		ResponseCode: responseCode,
+		Error:        err.Error(),
+	}
+}
+
+// How long to wait before trying again once a delivery has failed attempts times.
+func RetryDelay(attempts uint32) time.Duration {
+	delay := MIN_RETRY_DELAY
+	for i := uint32(1); i < attempts && delay < MAX_RETRY_DELAY; i++ {
+		delay *= 2
+	}
+	return min(delay, MAX_RETRY_DELAY)
+}
+
+// Posts the delivery's payload to its webhook, returning the response code. Any
+// response other than a 2xx is an error.
+func Send(
+	ctx context.Context,
+	client *http.Client,
+	delivery database.PendingDelivery,
+	now time.Time,
+) (uint32, error) {
+	body := []byte(delivery.Payload)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("building request: %w", err)
+	}
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(DELIVERY_HEADER, strconv.FormatUint(uint64(delivery.Id), 10))
+	request.Header.Set(TIMESTAMP_HEADER, timestamp)
+	request.Header.Set(SIGNATURE_HEADER, Sign(delivery.Secret, timestamp, body))
+
+	response, err := client.Do(request)
+	if err != nil {
+		return 0, fmt.Errorf("posting to webhook: %w", err)
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(response.Body, 1<<16))
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return uint32(response.StatusCode), fmt.Errorf("webhook responded with %s", response.Status)
+	}
+	return uint32(response.StatusCode), nil
+}
+
+// Signs the timestamp along with the body so that a captured request cannot be
+// replayed later with a new timestamp.
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return SIGNATURE_PREFIX + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Checks that a request received by a webhook was signed with secret and sent
+// within tolerance of now.
+func Verify(
+	secret string,
+	header http.Header,
+	body []byte,
+	now time.Time,
+	tolerance time.Duration,
+) error {
+	timestamp := header.Get(TIMESTAMP_HEADER)
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", TIMESTAMP_HEADER, err)
+	}
+	if age := now.Sub(time.Unix(seconds, 0)); age > tolerance || age < -tolerance {
+		return fmt.Errorf("request was signed %v ago, outside of %v", age, tolerance)
+	}
+
+	signature := header.Get(SIGNATURE_HEADER)
+	if !strings.HasPrefix(signature, SIGNATURE_PREFIX) {
+		return fmt.Errorf("%s is missing or not sha256", SIGNATURE_HEADER)
+	}
+	if !hmac.Equal([]byte(signature), []byte(Sign(secret, timestamp, body))) {
+		return errors.New("signature does not match")
+	}
+	return nil
+}
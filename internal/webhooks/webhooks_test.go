@@ -0,0 +1,79 @@
+package webhooks_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/WadeCappa/taskmaster/internal/database"
+	"github.com/WadeCappa/taskmaster/internal/webhooks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSentDeliveriesVerifyWithTheWebhooksSecret(t *testing.T) {
+	now := time.Now()
+	var received *http.Request
+	var body []byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	responseCode, err := webhooks.Send(context.Background(), receiver.Client(), database.PendingDelivery{
+		Id:      101,
+		Url:     receiver.URL,
+		Secret:  "secret",
+		Payload: `{"type":"STATUS_CHANGED","taskId":"7"}`,
+	}, now)
+	require.NoError(t, err)
+	require.Equal(t, uint32(http.StatusNoContent), responseCode)
+
+	require.Equal(t, http.MethodPost, received.Method)
+	require.Equal(t, "101", received.Header.Get(webhooks.DELIVERY_HEADER))
+	require.Equal(t, `{"type":"STATUS_CHANGED","taskId":"7"}`, string(body))
+	require.NoError(t, webhooks.Verify("secret", received.Header, body, now, time.Minute))
+	require.Error(t, webhooks.Verify("not the secret", received.Header, body, now, time.Minute))
+	require.Error(t, webhooks.Verify("secret", received.Header, []byte(`{"type":"CREATED"}`), now, time.Minute))
+	require.Error(t, webhooks.Verify("secret", received.Header, body, now.Add(time.Hour), time.Minute))
+}
+
+func TestSendFailsOnNonSuccessResponses(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	responseCode, err := webhooks.Send(context.Background(), receiver.Client(), database.PendingDelivery{
+		Url:     receiver.URL,
+		Payload: "{}",
+	}, time.Now())
+	require.Error(t, err)
+	require.Equal(t, uint32(http.StatusServiceUnavailable), responseCode)
+}
+
+func TestClientRefusesPrivateAddressesUnlessAllowed(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+	delivery := database.PendingDelivery{Url: receiver.URL, Payload: "{}"}
+
+	_, err := webhooks.Send(context.Background(), webhooks.NewClient(false), delivery, time.Now())
+	require.ErrorContains(t, err, "not a public address")
+
+	responseCode, err := webhooks.Send(context.Background(), webhooks.NewClient(true), delivery, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, uint32(http.StatusNoContent), responseCode)
+}
+
+func TestRetryDelayDoublesUpToTheMaximum(t *testing.T) {
+	require.Equal(t, webhooks.MIN_RETRY_DELAY, webhooks.RetryDelay(1))
+	require.Equal(t, 2*webhooks.MIN_RETRY_DELAY, webhooks.RetryDelay(2))
+	require.Equal(t, 4*webhooks.MIN_RETRY_DELAY, webhooks.RetryDelay(3))
+	require.Equal(t, webhooks.MAX_RETRY_DELAY, webhooks.RetryDelay(webhooks.MAX_ATTEMPTS))
+}
@@ -22,6 +22,59 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Narrows GetTasks down by who created or is assigned a task
+type AssignmentFilter int32
+
+const (
+	AssignmentFilter_ALL_TASKS      AssignmentFilter = 0
+	AssignmentFilter_ASSIGNED_TO_ME AssignmentFilter = 1
+	AssignmentFilter_CREATED_BY_ME  AssignmentFilter = 2
+	AssignmentFilter_UNASSIGNED     AssignmentFilter = 3
+)
+
+// Enum value maps for AssignmentFilter.
+var (
+	AssignmentFilter_name = map[int32]string{
+		0: "ALL_TASKS",
+		1: "ASSIGNED_TO_ME",
+		2: "CREATED_BY_ME",
+		3: "UNASSIGNED",
+	}
+	AssignmentFilter_value = map[string]int32{
+		"ALL_TASKS":      0,
+		"ASSIGNED_TO_ME": 1,
+		"CREATED_BY_ME":  2,
+		"UNASSIGNED":     3,
+	}
+)
+
+func (x AssignmentFilter) Enum() *AssignmentFilter {
+	p := new(AssignmentFilter)
+	*p = x
+	return p
+}
+
+func (x AssignmentFilter) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AssignmentFilter) Descriptor() protoreflect.EnumDescriptor {
+	return file_tasks_v1_tasks_proto_enumTypes[0].Descriptor()
+}
+
+func (AssignmentFilter) Type() protoreflect.EnumType {
+	return &file_tasks_v1_tasks_proto_enumTypes[0]
+}
+
+func (x AssignmentFilter) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AssignmentFilter.Descriptor instead.
+func (AssignmentFilter) EnumDescriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{0}
+}
+
 type Priority int32
 
 const (
@@ -58,11 +111,11 @@ func (x Priority) String() string {
 }
 
 func (Priority) Descriptor() protoreflect.EnumDescriptor {
-	return file_tasks_v1_tasks_proto_enumTypes[0].Descriptor()
+	return file_tasks_v1_tasks_proto_enumTypes[1].Descriptor()
 }
 
 func (Priority) Type() protoreflect.EnumType {
-	return &file_tasks_v1_tasks_proto_enumTypes[0]
+	return &file_tasks_v1_tasks_proto_enumTypes[1]
 }
 
 func (x Priority) Number() protoreflect.EnumNumber {
@@ -71,7 +124,7 @@ func (x Priority) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Priority.Descriptor instead.
 func (Priority) EnumDescriptor() ([]byte, []int) {
-	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{0}
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{1}
 }
 
 type Status int32
@@ -107,11 +160,11 @@ func (x Status) String() string {
 }
 
 func (Status) Descriptor() protoreflect.EnumDescriptor {
-	return file_tasks_v1_tasks_proto_enumTypes[1].Descriptor()
+	return file_tasks_v1_tasks_proto_enumTypes[2].Descriptor()
 }
 
 func (Status) Type() protoreflect.EnumType {
-	return &file_tasks_v1_tasks_proto_enumTypes[1]
+	return &file_tasks_v1_tasks_proto_enumTypes[2]
 }
 
 func (x Status) Number() protoreflect.EnumNumber {
@@ -120,12 +173,279 @@ func (x Status) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Status.Descriptor instead.
 func (Status) EnumDescriptor() ([]byte, []int) {
-	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{1}
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{2}
+}
+
+type TaskEventType int32
+
+const (
+	TaskEventType_CREATED        TaskEventType = 0
+	TaskEventType_UPDATED        TaskEventType = 1
+	TaskEventType_STATUS_CHANGED TaskEventType = 2
+	TaskEventType_ADDENDUM_ADDED TaskEventType = 3
+	// sent in place of events that were dropped because the watcher fell behind,
+	// with no task. Watchers should refetch their tasks.
+	TaskEventType_RESYNC TaskEventType = 4
+)
+
+// Enum value maps for TaskEventType.
+var (
+	TaskEventType_name = map[int32]string{
+		0: "CREATED",
+		1: "UPDATED",
+		2: "STATUS_CHANGED",
+		3: "ADDENDUM_ADDED",
+		4: "RESYNC",
+	}
+	TaskEventType_value = map[string]int32{
+		"CREATED":        0,
+		"UPDATED":        1,
+		"STATUS_CHANGED": 2,
+		"ADDENDUM_ADDED": 3,
+		"RESYNC":         4,
+	}
+)
+
+func (x TaskEventType) Enum() *TaskEventType {
+	p := new(TaskEventType)
+	*p = x
+	return p
+}
+
+func (x TaskEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_tasks_v1_tasks_proto_enumTypes[3].Descriptor()
+}
+
+func (TaskEventType) Type() protoreflect.EnumType {
+	return &file_tasks_v1_tasks_proto_enumTypes[3]
+}
+
+func (x TaskEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskEventType.Descriptor instead.
+func (TaskEventType) EnumDescriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{3}
+}
+
+type DeliveryState int32
+
+const (
+	DeliveryState_PENDING   DeliveryState = 0
+	DeliveryState_DELIVERED DeliveryState = 1
+	DeliveryState_FAILED    DeliveryState = 2
+)
+
+// Enum value maps for DeliveryState.
+var (
+	DeliveryState_name = map[int32]string{
+		0: "PENDING",
+		1: "DELIVERED",
+		2: "FAILED",
+	}
+	DeliveryState_value = map[string]int32{
+		"PENDING":   0,
+		"DELIVERED": 1,
+		"FAILED":    2,
+	}
+)
+
+func (x DeliveryState) Enum() *DeliveryState {
+	p := new(DeliveryState)
+	*p = x
+	return p
+}
+
+func (x DeliveryState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeliveryState) Descriptor() protoreflect.EnumDescriptor {
+	return file_tasks_v1_tasks_proto_enumTypes[4].Descriptor()
+}
+
+func (DeliveryState) Type() protoreflect.EnumType {
+	return &file_tasks_v1_tasks_proto_enumTypes[4]
+}
+
+func (x DeliveryState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeliveryState.Descriptor instead.
+func (DeliveryState) EnumDescriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{4}
+}
+
+// What an access token may be used for. Both write scopes also allow reading.
+// Managing webhooks, access tokens, shares and workspaces always needs a login
+// session.
+type Scope int32
+
+const (
+	Scope_READ_ONLY       Scope = 0
+	Scope_WRITE_TASKS     Scope = 1
+	Scope_WRITE_ADDENDUMS Scope = 2
+)
+
+// Enum value maps for Scope.
+var (
+	Scope_name = map[int32]string{
+		0: "READ_ONLY",
+		1: "WRITE_TASKS",
+		2: "WRITE_ADDENDUMS",
+	}
+	Scope_value = map[string]int32{
+		"READ_ONLY":       0,
+		"WRITE_TASKS":     1,
+		"WRITE_ADDENDUMS": 2,
+	}
+)
+
+func (x Scope) Enum() *Scope {
+	p := new(Scope)
+	*p = x
+	return p
+}
+
+func (x Scope) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Scope) Descriptor() protoreflect.EnumDescriptor {
+	return file_tasks_v1_tasks_proto_enumTypes[5].Descriptor()
+}
+
+func (Scope) Type() protoreflect.EnumType {
+	return &file_tasks_v1_tasks_proto_enumTypes[5]
+}
+
+func (x Scope) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Scope.Descriptor instead.
+func (Scope) EnumDescriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{5}
+}
+
+// What a user may do with a task. Viewers can describe it, editors can also
+// mark it and change its status. Only the owner can replace or share it,
+// though members of a workspace can also replace its tasks.
+type Role int32
+
+const (
+	Role_VIEWER Role = 0
+	Role_EDITOR Role = 1
+	Role_OWNER  Role = 2
+)
+
+// Enum value maps for Role.
+var (
+	Role_name = map[int32]string{
+		0: "VIEWER",
+		1: "EDITOR",
+		2: "OWNER",
+	}
+	Role_value = map[string]int32{
+		"VIEWER": 0,
+		"EDITOR": 1,
+		"OWNER":  2,
+	}
+)
+
+func (x Role) Enum() *Role {
+	p := new(Role)
+	*p = x
+	return p
+}
+
+func (x Role) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Role) Descriptor() protoreflect.EnumDescriptor {
+	return file_tasks_v1_tasks_proto_enumTypes[6].Descriptor()
+}
+
+func (Role) Type() protoreflect.EnumType {
+	return &file_tasks_v1_tasks_proto_enumTypes[6]
+}
+
+func (x Role) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Role.Descriptor instead.
+func (Role) EnumDescriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{6}
+}
+
+// Owners manage who is in a workspace, members can do anything with its tasks
+// and viewers can only read them.
+type WorkspaceRole int32
+
+const (
+	WorkspaceRole_WORKSPACE_VIEWER WorkspaceRole = 0
+	WorkspaceRole_WORKSPACE_MEMBER WorkspaceRole = 1
+	WorkspaceRole_WORKSPACE_OWNER  WorkspaceRole = 2
+)
+
+// Enum value maps for WorkspaceRole.
+var (
+	WorkspaceRole_name = map[int32]string{
+		0: "WORKSPACE_VIEWER",
+		1: "WORKSPACE_MEMBER",
+		2: "WORKSPACE_OWNER",
+	}
+	WorkspaceRole_value = map[string]int32{
+		"WORKSPACE_VIEWER": 0,
+		"WORKSPACE_MEMBER": 1,
+		"WORKSPACE_OWNER":  2,
+	}
+)
+
+func (x WorkspaceRole) Enum() *WorkspaceRole {
+	p := new(WorkspaceRole)
+	*p = x
+	return p
+}
+
+func (x WorkspaceRole) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WorkspaceRole) Descriptor() protoreflect.EnumDescriptor {
+	return file_tasks_v1_tasks_proto_enumTypes[7].Descriptor()
 }
 
+func (WorkspaceRole) Type() protoreflect.EnumType {
+	return &file_tasks_v1_tasks_proto_enumTypes[7]
+}
+
+func (x WorkspaceRole) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WorkspaceRole.Descriptor instead.
+func (WorkspaceRole) EnumDescriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{7}
+}
+
+// When task_id is set the existing task is replaced, keeping its creation time
+// and addendums. Otherwise a new task is created.
 type PutTaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Task   *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	TaskId *uint64                `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3,oneof" json:"task_id,omitempty"`
+	// creates the task in a workspace rather than the caller's personal
+	// workspace. Replaced tasks stay in the workspace they were created in
+	WorkspaceId   *uint64 `protobuf:"varint,3,opt,name=workspace_id,json=workspaceId,proto3,oneof" json:"workspace_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -167,6 +487,20 @@ func (x *PutTaskRequest) GetTask() *Task {
 	return nil
 }
 
+func (x *PutTaskRequest) GetTaskId() uint64 {
+	if x != nil && x.TaskId != nil {
+		return *x.TaskId
+	}
+	return 0
+}
+
+func (x *PutTaskRequest) GetWorkspaceId() uint64 {
+	if x != nil && x.WorkspaceId != nil {
+		return *x.WorkspaceId
+	}
+	return 0
+}
+
 type PutTaskResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TaskId        uint64                 `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
@@ -214,9 +548,13 @@ func (x *PutTaskResponse) GetTaskId() uint64 {
 // All fields are optional. For any given field, if nothing is provided then all tasks within
 // that category are returned
 type GetTasksRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        Status                 `protobuf:"varint,1,opt,name=status,proto3,enum=tasks.Status" json:"status,omitempty"`
-	Tags          []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Status Status                 `protobuf:"varint,1,opt,name=status,proto3,enum=tasks.Status" json:"status,omitempty"`
+	Tags   []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	// when not set, tasks in the caller's personal workspace and tasks that have
+	// been shared with them are returned
+	WorkspaceId   *uint64          `protobuf:"varint,3,opt,name=workspace_id,json=workspaceId,proto3,oneof" json:"workspace_id,omitempty"`
+	Assignment    AssignmentFilter `protobuf:"varint,4,opt,name=assignment,proto3,enum=tasks.AssignmentFilter" json:"assignment,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -265,10 +603,27 @@ func (x *GetTasksRequest) GetTags() []string {
 	return nil
 }
 
+func (x *GetTasksRequest) GetWorkspaceId() uint64 {
+	if x != nil && x.WorkspaceId != nil {
+		return *x.WorkspaceId
+	}
+	return 0
+}
+
+func (x *GetTasksRequest) GetAssignment() AssignmentFilter {
+	if x != nil {
+		return x.Assignment
+	}
+	return AssignmentFilter_ALL_TASKS
+}
+
 type GetTasksResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TaskId        uint64                 `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
-	Task          *Task                  `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TaskId uint64                 `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Task   *Task                  `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
+	// the user the task belongs to, and what the caller may do with it
+	OwnerId       uint64 `protobuf:"varint,3,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	Role          Role   `protobuf:"varint,4,opt,name=role,proto3,enum=tasks.Role" json:"role,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -317,6 +672,20 @@ func (x *GetTasksResponse) GetTask() *Task {
 	return nil
 }
 
+func (x *GetTasksResponse) GetOwnerId() uint64 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+func (x *GetTasksResponse) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_VIEWER
+}
+
 type DescribeTaskRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TaskId        uint64                 `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
@@ -365,6 +734,8 @@ type DescribeTaskResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
 	Addendum      []*Addendum            `protobuf:"bytes,2,rep,name=addendum,proto3" json:"addendum,omitempty"`
+	OwnerId       uint64                 `protobuf:"varint,3,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	Role          Role                   `protobuf:"varint,4,opt,name=role,proto3,enum=tasks.Role" json:"role,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -413,10 +784,25 @@ func (x *DescribeTaskResponse) GetAddendum() []*Addendum {
 	return nil
 }
 
+func (x *DescribeTaskResponse) GetOwnerId() uint64 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+func (x *DescribeTaskResponse) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_VIEWER
+}
+
 type MarkTaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TaskId        uint64                 `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
-	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TaskId uint64                 `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// fields marked debug_redact are left out of the server's request logs
+	Content       string `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -502,7 +888,9 @@ func (*MarkTaskResponse) Descriptor() ([]byte, []int) {
 }
 
 type GetTagsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// when not set, the tags of the caller's personal workspace are returned
+	WorkspaceId   *uint64 `protobuf:"varint,1,opt,name=workspace_id,json=workspaceId,proto3,oneof" json:"workspace_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -537,6 +925,13 @@ func (*GetTagsRequest) Descriptor() ([]byte, []int) {
 	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{8}
 }
 
+func (x *GetTagsRequest) GetWorkspaceId() uint64 {
+	if x != nil && x.WorkspaceId != nil {
+		return *x.WorkspaceId
+	}
+	return 0
+}
+
 type GetTagsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TagId         uint64                 `protobuf:"varint,1,opt,name=tagId,proto3" json:"tagId,omitempty"`
@@ -606,9 +1001,11 @@ func (x *GetTagsResponse) GetCount() uint64 {
 }
 
 type Addendum struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
-	TimeCreated   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time_created,json=timeCreated,proto3" json:"time_created,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Content     string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	TimeCreated *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time_created,json=timeCreated,proto3" json:"time_created,omitempty"`
+	// the user who wrote the addendum, set by the server
+	AuthorId      uint64 `protobuf:"varint,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -657,6 +1054,13 @@ func (x *Addendum) GetTimeCreated() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Addendum) GetAuthorId() uint64 {
+	if x != nil {
+		return x.AuthorId
+	}
+	return 0
+}
+
 type Task struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
 	Name              string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -666,8 +1070,12 @@ type Task struct {
 	NumberOfAddendums uint64                 `protobuf:"varint,5,opt,name=numberOfAddendums,proto3" json:"numberOfAddendums,omitempty"`
 	Tags              []string               `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
 	Prerequisites     []uint64               `protobuf:"varint,7,rep,packed,name=prerequisites,proto3" json:"prerequisites,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+	TimeCreated       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=time_created,json=timeCreated,proto3" json:"time_created,omitempty"`
+	DueTime           *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=due_time,json=dueTime,proto3" json:"due_time,omitempty"`
+	// who is responsible for the task. Set with AssignTask, PutTask ignores it
+	AssigneeUserId *uint64 `protobuf:"varint,10,opt,name=assignee_user_id,json=assigneeUserId,proto3,oneof" json:"assignee_user_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *Task) Reset() {
@@ -749,6 +1157,27 @@ func (x *Task) GetPrerequisites() []uint64 {
 	return nil
 }
 
+func (x *Task) GetTimeCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TimeCreated
+	}
+	return nil
+}
+
+func (x *Task) GetDueTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueTime
+	}
+	return nil
+}
+
+func (x *Task) GetAssigneeUserId() uint64 {
+	if x != nil && x.AssigneeUserId != nil {
+		return *x.AssigneeUserId
+	}
+	return 0
+}
+
 type SetStatusRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TaskId        uint64                 `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
@@ -837,40 +1266,2477 @@ func (*SetStatusResponse) Descriptor() ([]byte, []int) {
 	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{13}
 }
 
-var File_tasks_v1_tasks_proto protoreflect.FileDescriptor
+// A single line of a user's data dump. Tasks carry their own tags and status,
+// addendums reference the task_id of the task record they belong to.
+type DataRecord struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Record:
+	//
+	//	*DataRecord_Task
+	//	*DataRecord_Addendum
+	Record        isDataRecord_Record `protobuf_oneof:"record"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_tasks_v1_tasks_proto_rawDesc = "" +
-	"\n" +
-	"\x14tasks/v1/tasks.proto\x12\x05tasks\x1a\x1fgoogle/protobuf/timestamp.proto\"1\n" +
-	"\x0ePutTaskRequest\x12\x1f\n" +
-	"\x04task\x18\x01 \x01(\v2\v.tasks.TaskR\x04task\"*\n" +
-	"\x0fPutTaskResponse\x12\x17\n" +
-	"\atask_id\x18\x01 \x01(\x04R\x06taskId\"L\n" +
-	"\x0fGetTasksRequest\x12%\n" +
-	"\x06status\x18\x01 \x01(\x0e2\r.tasks.StatusR\x06status\x12\x12\n" +
-	"\x04tags\x18\x02 \x03(\tR\x04tags\"L\n" +
-	"\x10GetTasksResponse\x12\x17\n" +
-	"\atask_id\x18\x01 \x01(\x04R\x06taskId\x12\x1f\n" +
-	"\x04task\x18\x02 \x01(\v2\v.tasks.TaskR\x04task\".\n" +
-	"\x13DescribeTaskRequest\x12\x17\n" +
-	"\atask_id\x18\x01 \x01(\x04R\x06taskId\"d\n" +
-	"\x14DescribeTaskResponse\x12\x1f\n" +
-	"\x04task\x18\x01 \x01(\v2\v.tasks.TaskR\x04task\x12+\n" +
-	"\baddendum\x18\x02 \x03(\v2\x0f.tasks.AddendumR\baddendum\"D\n" +
-	"\x0fMarkTaskRequest\x12\x17\n" +
-	"\atask_id\x18\x01 \x01(\x04R\x06taskId\x12\x18\n" +
-	"\acontent\x18\x02 \x01(\tR\acontent\"\x12\n" +
-	"\x10MarkTaskResponse\"\x10\n" +
-	"\x0eGetTagsRequest\"\x8c\x01\n" +
+func (x *DataRecord) Reset() {
+	*x = DataRecord{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DataRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataRecord) ProtoMessage() {}
+
+func (x *DataRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataRecord.ProtoReflect.Descriptor instead.
+func (*DataRecord) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DataRecord) GetRecord() isDataRecord_Record {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+func (x *DataRecord) GetTask() *TaskRecord {
+	if x != nil {
+		if x, ok := x.Record.(*DataRecord_Task); ok {
+			return x.Task
+		}
+	}
+	return nil
+}
+
+func (x *DataRecord) GetAddendum() *AddendumRecord {
+	if x != nil {
+		if x, ok := x.Record.(*DataRecord_Addendum); ok {
+			return x.Addendum
+		}
+	}
+	return nil
+}
+
+type isDataRecord_Record interface {
+	isDataRecord_Record()
+}
+
+type DataRecord_Task struct {
+	Task *TaskRecord `protobuf:"bytes,1,opt,name=task,proto3,oneof"`
+}
+
+type DataRecord_Addendum struct {
+	Addendum *AddendumRecord `protobuf:"bytes,2,opt,name=addendum,proto3,oneof"`
+}
+
+func (*DataRecord_Task) isDataRecord_Record() {}
+
+func (*DataRecord_Addendum) isDataRecord_Record() {}
+
+type TaskRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        uint64                 `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Task          *Task                  `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskRecord) Reset() {
+	*x = TaskRecord{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskRecord) ProtoMessage() {}
+
+func (x *TaskRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskRecord.ProtoReflect.Descriptor instead.
+func (*TaskRecord) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TaskRecord) GetTaskId() uint64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *TaskRecord) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+type AddendumRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        uint64                 `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Addendum      *Addendum              `protobuf:"bytes,2,opt,name=addendum,proto3" json:"addendum,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddendumRecord) Reset() {
+	*x = AddendumRecord{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddendumRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddendumRecord) ProtoMessage() {}
+
+func (x *AddendumRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddendumRecord.ProtoReflect.Descriptor instead.
+func (*AddendumRecord) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AddendumRecord) GetTaskId() uint64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *AddendumRecord) GetAddendum() *Addendum {
+	if x != nil {
+		return x.Addendum
+	}
+	return nil
+}
+
+type ExportDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportDataRequest) Reset() {
+	*x = ExportDataRequest{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportDataRequest) ProtoMessage() {}
+
+func (x *ExportDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportDataRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{17}
+}
+
+type ExportDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Record        *DataRecord            `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportDataResponse) Reset() {
+	*x = ExportDataResponse{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportDataResponse) ProtoMessage() {}
+
+func (x *ExportDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportDataResponse.ProtoReflect.Descriptor instead.
+func (*ExportDataResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ExportDataResponse) GetRecord() *DataRecord {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+// Records may arrive in any order. Task ids and prerequisites are remapped onto
+// newly created tasks once the stream has been closed.
+type ImportDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Record        *DataRecord            `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportDataRequest) Reset() {
+	*x = ImportDataRequest{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportDataRequest) ProtoMessage() {}
+
+func (x *ImportDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportDataRequest.ProtoReflect.Descriptor instead.
+func (*ImportDataRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ImportDataRequest) GetRecord() *DataRecord {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+type ImportDataResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// maps the task_id found in the imported records to the newly created task_id
+	TaskIds           map[uint64]uint64 `protobuf:"bytes,1,rep,name=task_ids,json=taskIds,proto3" json:"task_ids,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	NumberOfAddendums uint64            `protobuf:"varint,2,opt,name=number_of_addendums,json=numberOfAddendums,proto3" json:"number_of_addendums,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ImportDataResponse) Reset() {
+	*x = ImportDataResponse{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportDataResponse) ProtoMessage() {}
+
+func (x *ImportDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportDataResponse.ProtoReflect.Descriptor instead.
+func (*ImportDataResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ImportDataResponse) GetTaskIds() map[uint64]uint64 {
+	if x != nil {
+		return x.TaskIds
+	}
+	return nil
+}
+
+func (x *ImportDataResponse) GetNumberOfAddendums() uint64 {
+	if x != nil {
+		return x.NumberOfAddendums
+	}
+	return 0
+}
+
+// Takes the same filters as GetTasksRequest. Events for tasks that have just
+// left the requested status are sent as well, so that clients can drop them.
+// Without a workspace_id, events are sent for the caller's personal workspace
+// and the tasks shared with them.
+type WatchTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        Status                 `protobuf:"varint,1,opt,name=status,proto3,enum=tasks.Status" json:"status,omitempty"`
+	Tags          []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	WorkspaceId   *uint64                `protobuf:"varint,3,opt,name=workspace_id,json=workspaceId,proto3,oneof" json:"workspace_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchTasksRequest) Reset() {
+	*x = WatchTasksRequest{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTasksRequest) ProtoMessage() {}
+
+func (x *WatchTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTasksRequest.ProtoReflect.Descriptor instead.
+func (*WatchTasksRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *WatchTasksRequest) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_TRACKING
+}
+
+func (x *WatchTasksRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *WatchTasksRequest) GetWorkspaceId() uint64 {
+	if x != nil && x.WorkspaceId != nil {
+		return *x.WorkspaceId
+	}
+	return 0
+}
+
+type WatchTasksResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Type   TaskEventType          `protobuf:"varint,1,opt,name=type,proto3,enum=tasks.TaskEventType" json:"type,omitempty"`
+	TaskId uint64                 `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// the task as it is after the event
+	Task *Task `protobuf:"bytes,3,opt,name=task,proto3" json:"task,omitempty"`
+	// set for STATUS_CHANGED
+	PreviousStatus *Status `protobuf:"varint,4,opt,name=previous_status,json=previousStatus,proto3,enum=tasks.Status,oneof" json:"previous_status,omitempty"`
+	// set for ADDENDUM_ADDED
+	Addendum      *Addendum `protobuf:"bytes,5,opt,name=addendum,proto3" json:"addendum,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchTasksResponse) Reset() {
+	*x = WatchTasksResponse{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTasksResponse) ProtoMessage() {}
+
+func (x *WatchTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTasksResponse.ProtoReflect.Descriptor instead.
+func (*WatchTasksResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *WatchTasksResponse) GetType() TaskEventType {
+	if x != nil {
+		return x.Type
+	}
+	return TaskEventType_CREATED
+}
+
+func (x *WatchTasksResponse) GetTaskId() uint64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *WatchTasksResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *WatchTasksResponse) GetPreviousStatus() Status {
+	if x != nil && x.PreviousStatus != nil {
+		return *x.PreviousStatus
+	}
+	return Status_TRACKING
+}
+
+func (x *WatchTasksResponse) GetAddendum() *Addendum {
+	if x != nil {
+		return x.Addendum
+	}
+	return nil
+}
+
+// Every field that is set must match for the filter to match. Status and tags
+// are checked against the task as it is after the event.
+type WebhookFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          *TaskEventType         `protobuf:"varint,1,opt,name=type,proto3,enum=tasks.TaskEventType,oneof" json:"type,omitempty"`
+	Status        *Status                `protobuf:"varint,2,opt,name=status,proto3,enum=tasks.Status,oneof" json:"status,omitempty"`
+	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookFilter) Reset() {
+	*x = WebhookFilter{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookFilter) ProtoMessage() {}
+
+func (x *WebhookFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookFilter.ProtoReflect.Descriptor instead.
+func (*WebhookFilter) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *WebhookFilter) GetType() TaskEventType {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return TaskEventType_CREATED
+}
+
+func (x *WebhookFilter) GetStatus() Status {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return Status_TRACKING
+}
+
+func (x *WebhookFilter) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// A webhook is called for every event that matches any of its filters, or for
+// every event when it has no filters. The payload is the WatchTasksResponse for
+// the event as json.
+type Webhook struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Filters       []*WebhookFilter       `protobuf:"bytes,2,rep,name=filters,proto3" json:"filters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Webhook) Reset() {
+	*x = Webhook{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Webhook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Webhook) ProtoMessage() {}
+
+func (x *Webhook) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Webhook.ProtoReflect.Descriptor instead.
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Webhook) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Webhook) GetFilters() []*WebhookFilter {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+type CreateWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhook       *Webhook               `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebhookRequest) Reset() {
+	*x = CreateWebhookRequest{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookRequest) ProtoMessage() {}
+
+func (x *CreateWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookRequest.ProtoReflect.Descriptor instead.
+func (*CreateWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CreateWebhookRequest) GetWebhook() *Webhook {
+	if x != nil {
+		return x.Webhook
+	}
+	return nil
+}
+
+type CreateWebhookResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId uint64                 `protobuf:"varint,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	// signs every payload sent to this webhook, this is the only time it is returned
+	Secret        string `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebhookResponse) Reset() {
+	*x = CreateWebhookResponse{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookResponse) ProtoMessage() {}
+
+func (x *CreateWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookResponse.ProtoReflect.Descriptor instead.
+func (*CreateWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CreateWebhookResponse) GetWebhookId() uint64 {
+	if x != nil {
+		return x.WebhookId
+	}
+	return 0
+}
+
+func (x *CreateWebhookResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+type ListWebhooksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhooksRequest) Reset() {
+	*x = ListWebhooksRequest{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhooksRequest) ProtoMessage() {}
+
+func (x *ListWebhooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhooksRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhooksRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{27}
+}
+
+type ListWebhooksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId     uint64                 `protobuf:"varint,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	Webhook       *Webhook               `protobuf:"bytes,2,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	TimeCreated   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=time_created,json=timeCreated,proto3" json:"time_created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhooksResponse) Reset() {
+	*x = ListWebhooksResponse{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhooksResponse) ProtoMessage() {}
+
+func (x *ListWebhooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhooksResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhooksResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListWebhooksResponse) GetWebhookId() uint64 {
+	if x != nil {
+		return x.WebhookId
+	}
+	return 0
+}
+
+func (x *ListWebhooksResponse) GetWebhook() *Webhook {
+	if x != nil {
+		return x.Webhook
+	}
+	return nil
+}
+
+func (x *ListWebhooksResponse) GetTimeCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TimeCreated
+	}
+	return nil
+}
+
+type DeleteWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId     uint64                 `protobuf:"varint,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookRequest) Reset() {
+	*x = DeleteWebhookRequest{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookRequest) ProtoMessage() {}
+
+func (x *DeleteWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DeleteWebhookRequest) GetWebhookId() uint64 {
+	if x != nil {
+		return x.WebhookId
+	}
+	return 0
+}
+
+type DeleteWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookResponse) Reset() {
+	*x = DeleteWebhookResponse{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookResponse) ProtoMessage() {}
+
+func (x *DeleteWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookResponse.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{30}
+}
+
+// Returns the most recent deliveries first. When webhook_id is not set
+// deliveries for all of the user's webhooks are returned.
+type ListWebhookDeliveriesRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId *uint64                `protobuf:"varint,1,opt,name=webhook_id,json=webhookId,proto3,oneof" json:"webhook_id,omitempty"`
+	// defaults to 50
+	Limit         uint32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesRequest) Reset() {
+	*x = ListWebhookDeliveriesRequest{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesRequest) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListWebhookDeliveriesRequest) GetWebhookId() uint64 {
+	if x != nil && x.WebhookId != nil {
+		return *x.WebhookId
+	}
+	return 0
+}
+
+func (x *ListWebhookDeliveriesRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListWebhookDeliveriesResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	DeliveryId  uint64                 `protobuf:"varint,1,opt,name=delivery_id,json=deliveryId,proto3" json:"delivery_id,omitempty"`
+	WebhookId   uint64                 `protobuf:"varint,2,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	State       DeliveryState          `protobuf:"varint,3,opt,name=state,proto3,enum=tasks.DeliveryState" json:"state,omitempty"`
+	Payload     string                 `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	Attempts    uint32                 `protobuf:"varint,5,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	TimeCreated *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=time_created,json=timeCreated,proto3" json:"time_created,omitempty"`
+	// when the next attempt is due, only meaningful while PENDING
+	NextAttempt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=next_attempt,json=nextAttempt,proto3" json:"next_attempt,omitempty"`
+	LastError        string                 `protobuf:"bytes,8,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	LastResponseCode uint32                 `protobuf:"varint,9,opt,name=last_response_code,json=lastResponseCode,proto3" json:"last_response_code,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesResponse) Reset() {
+	*x = ListWebhookDeliveriesResponse{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesResponse) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListWebhookDeliveriesResponse) GetDeliveryId() uint64 {
+	if x != nil {
+		return x.DeliveryId
+	}
+	return 0
+}
+
+func (x *ListWebhookDeliveriesResponse) GetWebhookId() uint64 {
+	if x != nil {
+		return x.WebhookId
+	}
+	return 0
+}
+
+func (x *ListWebhookDeliveriesResponse) GetState() DeliveryState {
+	if x != nil {
+		return x.State
+	}
+	return DeliveryState_PENDING
+}
+
+func (x *ListWebhookDeliveriesResponse) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *ListWebhookDeliveriesResponse) GetAttempts() uint32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *ListWebhookDeliveriesResponse) GetTimeCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TimeCreated
+	}
+	return nil
+}
+
+func (x *ListWebhookDeliveriesResponse) GetNextAttempt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextAttempt
+	}
+	return nil
+}
+
+func (x *ListWebhookDeliveriesResponse) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *ListWebhookDeliveriesResponse) GetLastResponseCode() uint32 {
+	if x != nil {
+		return x.LastResponseCode
+	}
+	return 0
+}
+
+type CreateAccessTokenRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// a reminder of what the token is for
+	Name   string  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Scopes []Scope `protobuf:"varint,2,rep,packed,name=scopes,proto3,enum=tasks.Scope" json:"scopes,omitempty"`
+	// the token never expires when this is not set
+	Expires       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires,proto3" json:"expires,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAccessTokenRequest) Reset() {
+	*x = CreateAccessTokenRequest{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAccessTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAccessTokenRequest) ProtoMessage() {}
+
+func (x *CreateAccessTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAccessTokenRequest.ProtoReflect.Descriptor instead.
+func (*CreateAccessTokenRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CreateAccessTokenRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateAccessTokenRequest) GetScopes() []Scope {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *CreateAccessTokenRequest) GetExpires() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Expires
+	}
+	return nil
+}
+
+type CreateAccessTokenResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	TokenId uint64                 `protobuf:"varint,1,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	// only a hash is kept, this is the only time the token is returned
+	Token         string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAccessTokenResponse) Reset() {
+	*x = CreateAccessTokenResponse{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAccessTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAccessTokenResponse) ProtoMessage() {}
+
+func (x *CreateAccessTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAccessTokenResponse.ProtoReflect.Descriptor instead.
+func (*CreateAccessTokenResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *CreateAccessTokenResponse) GetTokenId() uint64 {
+	if x != nil {
+		return x.TokenId
+	}
+	return 0
+}
+
+func (x *CreateAccessTokenResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type ListAccessTokensRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccessTokensRequest) Reset() {
+	*x = ListAccessTokensRequest{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccessTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccessTokensRequest) ProtoMessage() {}
+
+func (x *ListAccessTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccessTokensRequest.ProtoReflect.Descriptor instead.
+func (*ListAccessTokensRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{35}
+}
+
+type ListAccessTokensResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TokenId       uint64                 `protobuf:"varint,1,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Scopes        []Scope                `protobuf:"varint,3,rep,packed,name=scopes,proto3,enum=tasks.Scope" json:"scopes,omitempty"`
+	TimeCreated   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=time_created,json=timeCreated,proto3" json:"time_created,omitempty"`
+	Expires       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires,proto3" json:"expires,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccessTokensResponse) Reset() {
+	*x = ListAccessTokensResponse{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccessTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccessTokensResponse) ProtoMessage() {}
+
+func (x *ListAccessTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccessTokensResponse.ProtoReflect.Descriptor instead.
+func (*ListAccessTokensResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListAccessTokensResponse) GetTokenId() uint64 {
+	if x != nil {
+		return x.TokenId
+	}
+	return 0
+}
+
+func (x *ListAccessTokensResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListAccessTokensResponse) GetScopes() []Scope {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *ListAccessTokensResponse) GetTimeCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TimeCreated
+	}
+	return nil
+}
+
+func (x *ListAccessTokensResponse) GetExpires() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Expires
+	}
+	return nil
+}
+
+type RevokeAccessTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TokenId       uint64                 `protobuf:"varint,1,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAccessTokenRequest) Reset() {
+	*x = RevokeAccessTokenRequest{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAccessTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAccessTokenRequest) ProtoMessage() {}
+
+func (x *RevokeAccessTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAccessTokenRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAccessTokenRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *RevokeAccessTokenRequest) GetTokenId() uint64 {
+	if x != nil {
+		return x.TokenId
+	}
+	return 0
+}
+
+type RevokeAccessTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAccessTokenResponse) Reset() {
+	*x = RevokeAccessTokenResponse{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAccessTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAccessTokenResponse) ProtoMessage() {}
+
+func (x *RevokeAccessTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAccessTokenResponse.ProtoReflect.Descriptor instead.
+func (*RevokeAccessTokenResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{38}
+}
+
+// A share of a single task, or of every task the owner has with a tag
+type ShareTarget struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Target:
+	//
+	//	*ShareTarget_TaskId
+	//	*ShareTarget_Tag
+	Target        isShareTarget_Target `protobuf_oneof:"target"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareTarget) Reset() {
+	*x = ShareTarget{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareTarget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareTarget) ProtoMessage() {}
+
+func (x *ShareTarget) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareTarget.ProtoReflect.Descriptor instead.
+func (*ShareTarget) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ShareTarget) GetTarget() isShareTarget_Target {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *ShareTarget) GetTaskId() uint64 {
+	if x != nil {
+		if x, ok := x.Target.(*ShareTarget_TaskId); ok {
+			return x.TaskId
+		}
+	}
+	return 0
+}
+
+func (x *ShareTarget) GetTag() string {
+	if x != nil {
+		if x, ok := x.Target.(*ShareTarget_Tag); ok {
+			return x.Tag
+		}
+	}
+	return ""
+}
+
+type isShareTarget_Target interface {
+	isShareTarget_Target()
+}
+
+type ShareTarget_TaskId struct {
+	TaskId uint64 `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3,oneof"`
+}
+
+type ShareTarget_Tag struct {
+	Tag string `protobuf:"bytes,2,opt,name=tag,proto3,oneof"`
+}
+
+func (*ShareTarget_TaskId) isShareTarget_Target() {}
+
+func (*ShareTarget_Tag) isShareTarget_Target() {}
+
+// Sharing something that is already shared with the user replaces their role
+type ShareRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Target *ShareTarget           `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	UserId uint64                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// either VIEWER or EDITOR
+	Role          Role `protobuf:"varint,3,opt,name=role,proto3,enum=tasks.Role" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareRequest) Reset() {
+	*x = ShareRequest{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareRequest) ProtoMessage() {}
+
+func (x *ShareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareRequest.ProtoReflect.Descriptor instead.
+func (*ShareRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ShareRequest) GetTarget() *ShareTarget {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *ShareRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ShareRequest) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_VIEWER
+}
+
+type ShareResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareResponse) Reset() {
+	*x = ShareResponse{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareResponse) ProtoMessage() {}
+
+func (x *ShareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareResponse.ProtoReflect.Descriptor instead.
+func (*ShareResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{41}
+}
+
+// Lists the shares that the caller has granted
+type ListSharesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSharesRequest) Reset() {
+	*x = ListSharesRequest{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSharesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSharesRequest) ProtoMessage() {}
+
+func (x *ListSharesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSharesRequest.ProtoReflect.Descriptor instead.
+func (*ListSharesRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{42}
+}
+
+type ListSharesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Target        *ShareTarget           `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	UserId        uint64                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          Role                   `protobuf:"varint,3,opt,name=role,proto3,enum=tasks.Role" json:"role,omitempty"`
+	TimeCreated   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=time_created,json=timeCreated,proto3" json:"time_created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSharesResponse) Reset() {
+	*x = ListSharesResponse{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSharesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSharesResponse) ProtoMessage() {}
+
+func (x *ListSharesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSharesResponse.ProtoReflect.Descriptor instead.
+func (*ListSharesResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListSharesResponse) GetTarget() *ShareTarget {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *ListSharesResponse) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListSharesResponse) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_VIEWER
+}
+
+func (x *ListSharesResponse) GetTimeCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TimeCreated
+	}
+	return nil
+}
+
+type UnshareRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Target        *ShareTarget           `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	UserId        uint64                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnshareRequest) Reset() {
+	*x = UnshareRequest{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnshareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnshareRequest) ProtoMessage() {}
+
+func (x *UnshareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnshareRequest.ProtoReflect.Descriptor instead.
+func (*UnshareRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *UnshareRequest) GetTarget() *ShareTarget {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *UnshareRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type UnshareResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnshareResponse) Reset() {
+	*x = UnshareResponse{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnshareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnshareResponse) ProtoMessage() {}
+
+func (x *UnshareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnshareResponse.ProtoReflect.Descriptor instead.
+func (*UnshareResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{45}
+}
+
+// Workspaces own the tasks and tags created in them. Every user also has an
+// implicit personal workspace, which has no id and holds everything created
+// without a workspace_id.
+type CreateWorkspaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWorkspaceRequest) Reset() {
+	*x = CreateWorkspaceRequest{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWorkspaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWorkspaceRequest) ProtoMessage() {}
+
+func (x *CreateWorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWorkspaceRequest.ProtoReflect.Descriptor instead.
+func (*CreateWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *CreateWorkspaceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateWorkspaceResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId uint64                 `protobuf:"varint,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	// hand this to others so they can join the workspace
+	InviteCode    string `protobuf:"bytes,2,opt,name=invite_code,json=inviteCode,proto3" json:"invite_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWorkspaceResponse) Reset() {
+	*x = CreateWorkspaceResponse{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWorkspaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWorkspaceResponse) ProtoMessage() {}
+
+func (x *CreateWorkspaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWorkspaceResponse.ProtoReflect.Descriptor instead.
+func (*CreateWorkspaceResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *CreateWorkspaceResponse) GetWorkspaceId() uint64 {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return 0
+}
+
+func (x *CreateWorkspaceResponse) GetInviteCode() string {
+	if x != nil {
+		return x.InviteCode
+	}
+	return ""
+}
+
+type ListWorkspacesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkspacesRequest) Reset() {
+	*x = ListWorkspacesRequest{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkspacesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkspacesRequest) ProtoMessage() {}
+
+func (x *ListWorkspacesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkspacesRequest.ProtoReflect.Descriptor instead.
+func (*ListWorkspacesRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{48}
+}
+
+type ListWorkspacesResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId uint64                 `protobuf:"varint,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Role        WorkspaceRole          `protobuf:"varint,3,opt,name=role,proto3,enum=tasks.WorkspaceRole" json:"role,omitempty"`
+	TimeJoined  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=time_joined,json=timeJoined,proto3" json:"time_joined,omitempty"`
+	// only returned to owners
+	InviteCode    string `protobuf:"bytes,5,opt,name=invite_code,json=inviteCode,proto3" json:"invite_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkspacesResponse) Reset() {
+	*x = ListWorkspacesResponse{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkspacesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkspacesResponse) ProtoMessage() {}
+
+func (x *ListWorkspacesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkspacesResponse.ProtoReflect.Descriptor instead.
+func (*ListWorkspacesResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ListWorkspacesResponse) GetWorkspaceId() uint64 {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return 0
+}
+
+func (x *ListWorkspacesResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListWorkspacesResponse) GetRole() WorkspaceRole {
+	if x != nil {
+		return x.Role
+	}
+	return WorkspaceRole_WORKSPACE_VIEWER
+}
+
+func (x *ListWorkspacesResponse) GetTimeJoined() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TimeJoined
+	}
+	return nil
+}
+
+func (x *ListWorkspacesResponse) GetInviteCode() string {
+	if x != nil {
+		return x.InviteCode
+	}
+	return ""
+}
+
+// Joins as a member. Joining a workspace the caller is already in keeps their role
+type JoinWorkspaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InviteCode    string                 `protobuf:"bytes,1,opt,name=invite_code,json=inviteCode,proto3" json:"invite_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinWorkspaceRequest) Reset() {
+	*x = JoinWorkspaceRequest{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinWorkspaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinWorkspaceRequest) ProtoMessage() {}
+
+func (x *JoinWorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinWorkspaceRequest.ProtoReflect.Descriptor instead.
+func (*JoinWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *JoinWorkspaceRequest) GetInviteCode() string {
+	if x != nil {
+		return x.InviteCode
+	}
+	return ""
+}
+
+type JoinWorkspaceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId   uint64                 `protobuf:"varint,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinWorkspaceResponse) Reset() {
+	*x = JoinWorkspaceResponse{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinWorkspaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinWorkspaceResponse) ProtoMessage() {}
+
+func (x *JoinWorkspaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinWorkspaceResponse.ProtoReflect.Descriptor instead.
+func (*JoinWorkspaceResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *JoinWorkspaceResponse) GetWorkspaceId() uint64 {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return 0
+}
+
+// A workspace always keeps at least one owner, so its last owner cannot leave
+type LeaveWorkspaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId   uint64                 `protobuf:"varint,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveWorkspaceRequest) Reset() {
+	*x = LeaveWorkspaceRequest{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveWorkspaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveWorkspaceRequest) ProtoMessage() {}
+
+func (x *LeaveWorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveWorkspaceRequest.ProtoReflect.Descriptor instead.
+func (*LeaveWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *LeaveWorkspaceRequest) GetWorkspaceId() uint64 {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return 0
+}
+
+type LeaveWorkspaceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveWorkspaceResponse) Reset() {
+	*x = LeaveWorkspaceResponse{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveWorkspaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveWorkspaceResponse) ProtoMessage() {}
+
+func (x *LeaveWorkspaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveWorkspaceResponse.ProtoReflect.Descriptor instead.
+func (*LeaveWorkspaceResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{53}
+}
+
+type ListWorkspaceMembersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId   uint64                 `protobuf:"varint,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkspaceMembersRequest) Reset() {
+	*x = ListWorkspaceMembersRequest{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkspaceMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkspaceMembersRequest) ProtoMessage() {}
+
+func (x *ListWorkspaceMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkspaceMembersRequest.ProtoReflect.Descriptor instead.
+func (*ListWorkspaceMembersRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ListWorkspaceMembersRequest) GetWorkspaceId() uint64 {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return 0
+}
+
+type ListWorkspaceMembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        uint64                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          WorkspaceRole          `protobuf:"varint,2,opt,name=role,proto3,enum=tasks.WorkspaceRole" json:"role,omitempty"`
+	TimeJoined    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=time_joined,json=timeJoined,proto3" json:"time_joined,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkspaceMembersResponse) Reset() {
+	*x = ListWorkspaceMembersResponse{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkspaceMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkspaceMembersResponse) ProtoMessage() {}
+
+func (x *ListWorkspaceMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkspaceMembersResponse.ProtoReflect.Descriptor instead.
+func (*ListWorkspaceMembersResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ListWorkspaceMembersResponse) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListWorkspaceMembersResponse) GetRole() WorkspaceRole {
+	if x != nil {
+		return x.Role
+	}
+	return WorkspaceRole_WORKSPACE_VIEWER
+}
+
+func (x *ListWorkspaceMembersResponse) GetTimeJoined() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TimeJoined
+	}
+	return nil
+}
+
+// Only owners can change roles, including their own
+type SetWorkspaceRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId   uint64                 `protobuf:"varint,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	UserId        uint64                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          WorkspaceRole          `protobuf:"varint,3,opt,name=role,proto3,enum=tasks.WorkspaceRole" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetWorkspaceRoleRequest) Reset() {
+	*x = SetWorkspaceRoleRequest{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetWorkspaceRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetWorkspaceRoleRequest) ProtoMessage() {}
+
+func (x *SetWorkspaceRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetWorkspaceRoleRequest.ProtoReflect.Descriptor instead.
+func (*SetWorkspaceRoleRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *SetWorkspaceRoleRequest) GetWorkspaceId() uint64 {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return 0
+}
+
+func (x *SetWorkspaceRoleRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SetWorkspaceRoleRequest) GetRole() WorkspaceRole {
+	if x != nil {
+		return x.Role
+	}
+	return WorkspaceRole_WORKSPACE_VIEWER
+}
+
+type SetWorkspaceRoleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetWorkspaceRoleResponse) Reset() {
+	*x = SetWorkspaceRoleResponse{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetWorkspaceRoleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetWorkspaceRoleResponse) ProtoMessage() {}
+
+func (x *SetWorkspaceRoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetWorkspaceRoleResponse.ProtoReflect.Descriptor instead.
+func (*SetWorkspaceRoleResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{57}
+}
+
+// Editors of a task can assign it to anyone who can see it. Every change of
+// assignee is recorded as an addendum on the task.
+type AssignTaskRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TaskId uint64                 `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// unassigns the task when not set
+	AssigneeUserId *uint64 `protobuf:"varint,2,opt,name=assignee_user_id,json=assigneeUserId,proto3,oneof" json:"assignee_user_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AssignTaskRequest) Reset() {
+	*x = AssignTaskRequest{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignTaskRequest) ProtoMessage() {}
+
+func (x *AssignTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignTaskRequest.ProtoReflect.Descriptor instead.
+func (*AssignTaskRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *AssignTaskRequest) GetTaskId() uint64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *AssignTaskRequest) GetAssigneeUserId() uint64 {
+	if x != nil && x.AssigneeUserId != nil {
+		return *x.AssigneeUserId
+	}
+	return 0
+}
+
+type AssignTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignTaskResponse) Reset() {
+	*x = AssignTaskResponse{}
+	mi := &file_tasks_v1_tasks_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignTaskResponse) ProtoMessage() {}
+
+func (x *AssignTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_v1_tasks_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignTaskResponse.ProtoReflect.Descriptor instead.
+func (*AssignTaskResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_v1_tasks_proto_rawDescGZIP(), []int{59}
+}
+
+var File_tasks_v1_tasks_proto protoreflect.FileDescriptor
+
+const file_tasks_v1_tasks_proto_rawDesc = "" +
+	"\n" +
+	"\x14tasks/v1/tasks.proto\x12\x05tasks\x1a\x1fgoogle/protobuf/timestamp.proto\"\x94\x01\n" +
+	"\x0ePutTaskRequest\x12\x1f\n" +
+	"\x04task\x18\x01 \x01(\v2\v.tasks.TaskR\x04task\x12\x1c\n" +
+	"\atask_id\x18\x02 \x01(\x04H\x00R\x06taskId\x88\x01\x01\x12&\n" +
+	"\fworkspace_id\x18\x03 \x01(\x04H\x01R\vworkspaceId\x88\x01\x01B\n" +
+	"\n" +
+	"\b_task_idB\x0f\n" +
+	"\r_workspace_id\"*\n" +
+	"\x0fPutTaskResponse\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x04R\x06taskId\"\xbe\x01\n" +
+	"\x0fGetTasksRequest\x12%\n" +
+	"\x06status\x18\x01 \x01(\x0e2\r.tasks.StatusR\x06status\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\x12&\n" +
+	"\fworkspace_id\x18\x03 \x01(\x04H\x00R\vworkspaceId\x88\x01\x01\x127\n" +
+	"\n" +
+	"assignment\x18\x04 \x01(\x0e2\x17.tasks.AssignmentFilterR\n" +
+	"assignmentB\x0f\n" +
+	"\r_workspace_id\"\x88\x01\n" +
+	"\x10GetTasksResponse\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x04R\x06taskId\x12\x1f\n" +
+	"\x04task\x18\x02 \x01(\v2\v.tasks.TaskR\x04task\x12\x19\n" +
+	"\bowner_id\x18\x03 \x01(\x04R\aownerId\x12\x1f\n" +
+	"\x04role\x18\x04 \x01(\x0e2\v.tasks.RoleR\x04role\".\n" +
+	"\x13DescribeTaskRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x04R\x06taskId\"\xa0\x01\n" +
+	"\x14DescribeTaskResponse\x12\x1f\n" +
+	"\x04task\x18\x01 \x01(\v2\v.tasks.TaskR\x04task\x12+\n" +
+	"\baddendum\x18\x02 \x03(\v2\x0f.tasks.AddendumR\baddendum\x12\x19\n" +
+	"\bowner_id\x18\x03 \x01(\x04R\aownerId\x12\x1f\n" +
+	"\x04role\x18\x04 \x01(\x0e2\v.tasks.RoleR\x04role\"I\n" +
+	"\x0fMarkTaskRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x04R\x06taskId\x12\x1d\n" +
+	"\acontent\x18\x02 \x01(\tB\x03\x80\x01\x01R\acontent\"\x12\n" +
+	"\x10MarkTaskResponse\"I\n" +
+	"\x0eGetTagsRequest\x12&\n" +
+	"\fworkspace_id\x18\x01 \x01(\x04H\x00R\vworkspaceId\x88\x01\x01B\x0f\n" +
+	"\r_workspace_id\"\x8c\x01\n" +
 	"\x0fGetTagsResponse\x12\x14\n" +
 	"\x05tagId\x18\x01 \x01(\x04R\x05tagId\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x129\n" +
 	"\n" +
 	"write_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\twriteTime\x12\x14\n" +
-	"\x05count\x18\x04 \x01(\x04R\x05count\"c\n" +
-	"\bAddendum\x12\x18\n" +
-	"\acontent\x18\x01 \x01(\tR\acontent\x12=\n" +
-	"\ftime_created\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vtimeCreated\"\x86\x02\n" +
+	"\x05count\x18\x04 \x01(\x04R\x05count\"\x85\x01\n" +
+	"\bAddendum\x12\x1d\n" +
+	"\acontent\x18\x01 \x01(\tB\x03\x80\x01\x01R\acontent\x12=\n" +
+	"\ftime_created\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vtimeCreated\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\x04R\bauthorId\"\xc0\x03\n" +
 	"\x04Task\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12.\n" +
 	"\x13minutes_to_complete\x18\x02 \x01(\x04R\x11minutesToComplete\x12+\n" +
@@ -878,11 +3744,176 @@ const file_tasks_v1_tasks_proto_rawDesc = "" +
 	"\x06status\x18\x04 \x01(\x0e2\r.tasks.StatusR\x06status\x12,\n" +
 	"\x11numberOfAddendums\x18\x05 \x01(\x04R\x11numberOfAddendums\x12\x12\n" +
 	"\x04tags\x18\x06 \x03(\tR\x04tags\x12$\n" +
-	"\rprerequisites\x18\a \x03(\x04R\rprerequisites\"R\n" +
+	"\rprerequisites\x18\a \x03(\x04R\rprerequisites\x12=\n" +
+	"\ftime_created\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vtimeCreated\x125\n" +
+	"\bdue_time\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\adueTime\x12-\n" +
+	"\x10assignee_user_id\x18\n" +
+	" \x01(\x04H\x00R\x0eassigneeUserId\x88\x01\x01B\x13\n" +
+	"\x11_assignee_user_id\"R\n" +
 	"\x10SetStatusRequest\x12\x17\n" +
 	"\atask_id\x18\x01 \x01(\x04R\x06taskId\x12%\n" +
 	"\x06status\x18\x02 \x01(\x0e2\r.tasks.StatusR\x06status\"\x13\n" +
-	"\x11SetStatusResponse*U\n" +
+	"\x11SetStatusResponse\"t\n" +
+	"\n" +
+	"DataRecord\x12'\n" +
+	"\x04task\x18\x01 \x01(\v2\x11.tasks.TaskRecordH\x00R\x04task\x123\n" +
+	"\baddendum\x18\x02 \x01(\v2\x15.tasks.AddendumRecordH\x00R\baddendumB\b\n" +
+	"\x06record\"F\n" +
+	"\n" +
+	"TaskRecord\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x04R\x06taskId\x12\x1f\n" +
+	"\x04task\x18\x02 \x01(\v2\v.tasks.TaskR\x04task\"V\n" +
+	"\x0eAddendumRecord\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x04R\x06taskId\x12+\n" +
+	"\baddendum\x18\x02 \x01(\v2\x0f.tasks.AddendumR\baddendum\"\x13\n" +
+	"\x11ExportDataRequest\"?\n" +
+	"\x12ExportDataResponse\x12)\n" +
+	"\x06record\x18\x01 \x01(\v2\x11.tasks.DataRecordR\x06record\">\n" +
+	"\x11ImportDataRequest\x12)\n" +
+	"\x06record\x18\x01 \x01(\v2\x11.tasks.DataRecordR\x06record\"\xc3\x01\n" +
+	"\x12ImportDataResponse\x12A\n" +
+	"\btask_ids\x18\x01 \x03(\v2&.tasks.ImportDataResponse.TaskIdsEntryR\ataskIds\x12.\n" +
+	"\x13number_of_addendums\x18\x02 \x01(\x04R\x11numberOfAddendums\x1a:\n" +
+	"\fTaskIdsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x04R\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x04R\x05value:\x028\x01\"\x87\x01\n" +
+	"\x11WatchTasksRequest\x12%\n" +
+	"\x06status\x18\x01 \x01(\x0e2\r.tasks.StatusR\x06status\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\x12&\n" +
+	"\fworkspace_id\x18\x03 \x01(\x04H\x00R\vworkspaceId\x88\x01\x01B\x0f\n" +
+	"\r_workspace_id\"\xf6\x01\n" +
+	"\x12WatchTasksResponse\x12(\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x14.tasks.TaskEventTypeR\x04type\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\x04R\x06taskId\x12\x1f\n" +
+	"\x04task\x18\x03 \x01(\v2\v.tasks.TaskR\x04task\x12;\n" +
+	"\x0fprevious_status\x18\x04 \x01(\x0e2\r.tasks.StatusH\x00R\x0epreviousStatus\x88\x01\x01\x12+\n" +
+	"\baddendum\x18\x05 \x01(\v2\x0f.tasks.AddendumR\baddendumB\x12\n" +
+	"\x10_previous_status\"\x92\x01\n" +
+	"\rWebhookFilter\x12-\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x14.tasks.TaskEventTypeH\x00R\x04type\x88\x01\x01\x12*\n" +
+	"\x06status\x18\x02 \x01(\x0e2\r.tasks.StatusH\x01R\x06status\x88\x01\x01\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tagsB\a\n" +
+	"\x05_typeB\t\n" +
+	"\a_status\"K\n" +
+	"\aWebhook\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12.\n" +
+	"\afilters\x18\x02 \x03(\v2\x14.tasks.WebhookFilterR\afilters\"@\n" +
+	"\x14CreateWebhookRequest\x12(\n" +
+	"\awebhook\x18\x01 \x01(\v2\x0e.tasks.WebhookR\awebhook\"N\n" +
+	"\x15CreateWebhookResponse\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\x04R\twebhookId\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\"\x15\n" +
+	"\x13ListWebhooksRequest\"\x9e\x01\n" +
+	"\x14ListWebhooksResponse\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\x04R\twebhookId\x12(\n" +
+	"\awebhook\x18\x02 \x01(\v2\x0e.tasks.WebhookR\awebhook\x12=\n" +
+	"\ftime_created\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vtimeCreated\"5\n" +
+	"\x14DeleteWebhookRequest\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\x04R\twebhookId\"\x17\n" +
+	"\x15DeleteWebhookResponse\"g\n" +
+	"\x1cListWebhookDeliveriesRequest\x12\"\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\x04H\x00R\twebhookId\x88\x01\x01\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\rR\x05limitB\r\n" +
+	"\v_webhook_id\"\x8c\x03\n" +
+	"\x1dListWebhookDeliveriesResponse\x12\x1f\n" +
+	"\vdelivery_id\x18\x01 \x01(\x04R\n" +
+	"deliveryId\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x02 \x01(\x04R\twebhookId\x12*\n" +
+	"\x05state\x18\x03 \x01(\x0e2\x14.tasks.DeliveryStateR\x05state\x12\x18\n" +
+	"\apayload\x18\x04 \x01(\tR\apayload\x12\x1a\n" +
+	"\battempts\x18\x05 \x01(\rR\battempts\x12=\n" +
+	"\ftime_created\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vtimeCreated\x12=\n" +
+	"\fnext_attempt\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vnextAttempt\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\b \x01(\tR\tlastError\x12,\n" +
+	"\x12last_response_code\x18\t \x01(\rR\x10lastResponseCode\"\x8a\x01\n" +
+	"\x18CreateAccessTokenRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12$\n" +
+	"\x06scopes\x18\x02 \x03(\x0e2\f.tasks.ScopeR\x06scopes\x124\n" +
+	"\aexpires\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aexpires\"L\n" +
+	"\x19CreateAccessTokenResponse\x12\x19\n" +
+	"\btoken_id\x18\x01 \x01(\x04R\atokenId\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"\x19\n" +
+	"\x17ListAccessTokensRequest\"\xe4\x01\n" +
+	"\x18ListAccessTokensResponse\x12\x19\n" +
+	"\btoken_id\x18\x01 \x01(\x04R\atokenId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12$\n" +
+	"\x06scopes\x18\x03 \x03(\x0e2\f.tasks.ScopeR\x06scopes\x12=\n" +
+	"\ftime_created\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vtimeCreated\x124\n" +
+	"\aexpires\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\aexpires\"5\n" +
+	"\x18RevokeAccessTokenRequest\x12\x19\n" +
+	"\btoken_id\x18\x01 \x01(\x04R\atokenId\"\x1b\n" +
+	"\x19RevokeAccessTokenResponse\"F\n" +
+	"\vShareTarget\x12\x19\n" +
+	"\atask_id\x18\x01 \x01(\x04H\x00R\x06taskId\x12\x12\n" +
+	"\x03tag\x18\x02 \x01(\tH\x00R\x03tagB\b\n" +
+	"\x06target\"t\n" +
+	"\fShareRequest\x12*\n" +
+	"\x06target\x18\x01 \x01(\v2\x12.tasks.ShareTargetR\x06target\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x04R\x06userId\x12\x1f\n" +
+	"\x04role\x18\x03 \x01(\x0e2\v.tasks.RoleR\x04role\"\x0f\n" +
+	"\rShareResponse\"\x13\n" +
+	"\x11ListSharesRequest\"\xb9\x01\n" +
+	"\x12ListSharesResponse\x12*\n" +
+	"\x06target\x18\x01 \x01(\v2\x12.tasks.ShareTargetR\x06target\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x04R\x06userId\x12\x1f\n" +
+	"\x04role\x18\x03 \x01(\x0e2\v.tasks.RoleR\x04role\x12=\n" +
+	"\ftime_created\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vtimeCreated\"U\n" +
+	"\x0eUnshareRequest\x12*\n" +
+	"\x06target\x18\x01 \x01(\v2\x12.tasks.ShareTargetR\x06target\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x04R\x06userId\"\x11\n" +
+	"\x0fUnshareResponse\",\n" +
+	"\x16CreateWorkspaceRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"]\n" +
+	"\x17CreateWorkspaceResponse\x12!\n" +
+	"\fworkspace_id\x18\x01 \x01(\x04R\vworkspaceId\x12\x1f\n" +
+	"\vinvite_code\x18\x02 \x01(\tR\n" +
+	"inviteCode\"\x17\n" +
+	"\x15ListWorkspacesRequest\"\xd7\x01\n" +
+	"\x16ListWorkspacesResponse\x12!\n" +
+	"\fworkspace_id\x18\x01 \x01(\x04R\vworkspaceId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12(\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x14.tasks.WorkspaceRoleR\x04role\x12;\n" +
+	"\vtime_joined\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"timeJoined\x12\x1f\n" +
+	"\vinvite_code\x18\x05 \x01(\tR\n" +
+	"inviteCode\"<\n" +
+	"\x14JoinWorkspaceRequest\x12$\n" +
+	"\vinvite_code\x18\x01 \x01(\tB\x03\x80\x01\x01R\n" +
+	"inviteCode\":\n" +
+	"\x15JoinWorkspaceResponse\x12!\n" +
+	"\fworkspace_id\x18\x01 \x01(\x04R\vworkspaceId\":\n" +
+	"\x15LeaveWorkspaceRequest\x12!\n" +
+	"\fworkspace_id\x18\x01 \x01(\x04R\vworkspaceId\"\x18\n" +
+	"\x16LeaveWorkspaceResponse\"@\n" +
+	"\x1bListWorkspaceMembersRequest\x12!\n" +
+	"\fworkspace_id\x18\x01 \x01(\x04R\vworkspaceId\"\x9e\x01\n" +
+	"\x1cListWorkspaceMembersResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x04R\x06userId\x12(\n" +
+	"\x04role\x18\x02 \x01(\x0e2\x14.tasks.WorkspaceRoleR\x04role\x12;\n" +
+	"\vtime_joined\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"timeJoined\"\x7f\n" +
+	"\x17SetWorkspaceRoleRequest\x12!\n" +
+	"\fworkspace_id\x18\x01 \x01(\x04R\vworkspaceId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x04R\x06userId\x12(\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x14.tasks.WorkspaceRoleR\x04role\"\x1a\n" +
+	"\x18SetWorkspaceRoleResponse\"p\n" +
+	"\x11AssignTaskRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x04R\x06taskId\x12-\n" +
+	"\x10assignee_user_id\x18\x02 \x01(\x04H\x00R\x0eassigneeUserId\x88\x01\x01B\x13\n" +
+	"\x11_assignee_user_id\"\x14\n" +
+	"\x12AssignTaskResponse*X\n" +
+	"\x10AssignmentFilter\x12\r\n" +
+	"\tALL_TASKS\x10\x00\x12\x12\n" +
+	"\x0eASSIGNED_TO_ME\x10\x01\x12\x11\n" +
+	"\rCREATED_BY_ME\x10\x02\x12\x0e\n" +
+	"\n" +
+	"UNASSIGNED\x10\x03*U\n" +
 	"\bPriority\x12\x13\n" +
 	"\x0fDO_BEFORE_SLEEP\x10\x00\x12\x12\n" +
 	"\x0eDO_IMMEDIATELY\x10\x01\x12\r\n" +
@@ -891,14 +3922,65 @@ const file_tasks_v1_tasks_proto_rawDesc = "" +
 	"\x06Status\x12\f\n" +
 	"\bTRACKING\x10\x00\x12\r\n" +
 	"\tCOMPLETED\x10\x01\x12\v\n" +
-	"\aBACKLOG\x10\x022\x8e\x03\n" +
+	"\aBACKLOG\x10\x02*]\n" +
+	"\rTaskEventType\x12\v\n" +
+	"\aCREATED\x10\x00\x12\v\n" +
+	"\aUPDATED\x10\x01\x12\x12\n" +
+	"\x0eSTATUS_CHANGED\x10\x02\x12\x12\n" +
+	"\x0eADDENDUM_ADDED\x10\x03\x12\n" +
+	"\n" +
+	"\x06RESYNC\x10\x04*7\n" +
+	"\rDeliveryState\x12\v\n" +
+	"\aPENDING\x10\x00\x12\r\n" +
+	"\tDELIVERED\x10\x01\x12\n" +
+	"\n" +
+	"\x06FAILED\x10\x02*<\n" +
+	"\x05Scope\x12\r\n" +
+	"\tREAD_ONLY\x10\x00\x12\x0f\n" +
+	"\vWRITE_TASKS\x10\x01\x12\x13\n" +
+	"\x0fWRITE_ADDENDUMS\x10\x02*)\n" +
+	"\x04Role\x12\n" +
+	"\n" +
+	"\x06VIEWER\x10\x00\x12\n" +
+	"\n" +
+	"\x06EDITOR\x10\x01\x12\t\n" +
+	"\x05OWNER\x10\x02*P\n" +
+	"\rWorkspaceRole\x12\x14\n" +
+	"\x10WORKSPACE_VIEWER\x10\x00\x12\x14\n" +
+	"\x10WORKSPACE_MEMBER\x10\x01\x12\x13\n" +
+	"\x0fWORKSPACE_OWNER\x10\x022\xc1\x0f\n" +
 	"\x05tasks\x12:\n" +
 	"\aPutTask\x12\x15.tasks.PutTaskRequest\x1a\x16.tasks.PutTaskResponse\"\x00\x12?\n" +
 	"\bGetTasks\x12\x16.tasks.GetTasksRequest\x1a\x17.tasks.GetTasksResponse\"\x000\x01\x12I\n" +
 	"\fDescribeTask\x12\x1a.tasks.DescribeTaskRequest\x1a\x1b.tasks.DescribeTaskResponse\"\x00\x12=\n" +
 	"\bMarkTask\x12\x16.tasks.MarkTaskRequest\x1a\x17.tasks.MarkTaskResponse\"\x00\x12<\n" +
 	"\aGetTags\x12\x15.tasks.GetTagsRequest\x1a\x16.tasks.GetTagsResponse\"\x000\x01\x12@\n" +
-	"\tSetStatus\x12\x17.tasks.SetStatusRequest\x1a\x18.tasks.SetStatusResponse\"\x00B9Z7github.com/WadeCappa/taskmaster/pkg/go/tasks/v1;taskspbb\x06proto3"
+	"\tSetStatus\x12\x17.tasks.SetStatusRequest\x1a\x18.tasks.SetStatusResponse\"\x00\x12E\n" +
+	"\n" +
+	"ExportData\x12\x18.tasks.ExportDataRequest\x1a\x19.tasks.ExportDataResponse\"\x000\x01\x12E\n" +
+	"\n" +
+	"ImportData\x12\x18.tasks.ImportDataRequest\x1a\x19.tasks.ImportDataResponse\"\x00(\x01\x12E\n" +
+	"\n" +
+	"WatchTasks\x12\x18.tasks.WatchTasksRequest\x1a\x19.tasks.WatchTasksResponse\"\x000\x01\x12L\n" +
+	"\rCreateWebhook\x12\x1b.tasks.CreateWebhookRequest\x1a\x1c.tasks.CreateWebhookResponse\"\x00\x12K\n" +
+	"\fListWebhooks\x12\x1a.tasks.ListWebhooksRequest\x1a\x1b.tasks.ListWebhooksResponse\"\x000\x01\x12L\n" +
+	"\rDeleteWebhook\x12\x1b.tasks.DeleteWebhookRequest\x1a\x1c.tasks.DeleteWebhookResponse\"\x00\x12f\n" +
+	"\x15ListWebhookDeliveries\x12#.tasks.ListWebhookDeliveriesRequest\x1a$.tasks.ListWebhookDeliveriesResponse\"\x000\x01\x12X\n" +
+	"\x11CreateAccessToken\x12\x1f.tasks.CreateAccessTokenRequest\x1a .tasks.CreateAccessTokenResponse\"\x00\x12W\n" +
+	"\x10ListAccessTokens\x12\x1e.tasks.ListAccessTokensRequest\x1a\x1f.tasks.ListAccessTokensResponse\"\x000\x01\x12X\n" +
+	"\x11RevokeAccessToken\x12\x1f.tasks.RevokeAccessTokenRequest\x1a .tasks.RevokeAccessTokenResponse\"\x00\x124\n" +
+	"\x05Share\x12\x13.tasks.ShareRequest\x1a\x14.tasks.ShareResponse\"\x00\x12E\n" +
+	"\n" +
+	"ListShares\x12\x18.tasks.ListSharesRequest\x1a\x19.tasks.ListSharesResponse\"\x000\x01\x12:\n" +
+	"\aUnshare\x12\x15.tasks.UnshareRequest\x1a\x16.tasks.UnshareResponse\"\x00\x12R\n" +
+	"\x0fCreateWorkspace\x12\x1d.tasks.CreateWorkspaceRequest\x1a\x1e.tasks.CreateWorkspaceResponse\"\x00\x12Q\n" +
+	"\x0eListWorkspaces\x12\x1c.tasks.ListWorkspacesRequest\x1a\x1d.tasks.ListWorkspacesResponse\"\x000\x01\x12L\n" +
+	"\rJoinWorkspace\x12\x1b.tasks.JoinWorkspaceRequest\x1a\x1c.tasks.JoinWorkspaceResponse\"\x00\x12O\n" +
+	"\x0eLeaveWorkspace\x12\x1c.tasks.LeaveWorkspaceRequest\x1a\x1d.tasks.LeaveWorkspaceResponse\"\x00\x12c\n" +
+	"\x14ListWorkspaceMembers\x12\".tasks.ListWorkspaceMembersRequest\x1a#.tasks.ListWorkspaceMembersResponse\"\x000\x01\x12U\n" +
+	"\x10SetWorkspaceRole\x12\x1e.tasks.SetWorkspaceRoleRequest\x1a\x1f.tasks.SetWorkspaceRoleResponse\"\x00\x12C\n" +
+	"\n" +
+	"AssignTask\x12\x18.tasks.AssignTaskRequest\x1a\x19.tasks.AssignTaskResponse\"\x00B9Z7github.com/WadeCappa/taskmaster/pkg/go/tasks/v1;taskspbb\x06proto3"
 
 var (
 	file_tasks_v1_tasks_proto_rawDescOnce sync.Once
@@ -912,55 +3994,190 @@ func file_tasks_v1_tasks_proto_rawDescGZIP() []byte {
 	return file_tasks_v1_tasks_proto_rawDescData
 }
 
-var file_tasks_v1_tasks_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_tasks_v1_tasks_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_tasks_v1_tasks_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_tasks_v1_tasks_proto_msgTypes = make([]protoimpl.MessageInfo, 61)
 var file_tasks_v1_tasks_proto_goTypes = []any{
-	(Priority)(0),                 // 0: tasks.Priority
-	(Status)(0),                   // 1: tasks.Status
-	(*PutTaskRequest)(nil),        // 2: tasks.PutTaskRequest
-	(*PutTaskResponse)(nil),       // 3: tasks.PutTaskResponse
-	(*GetTasksRequest)(nil),       // 4: tasks.GetTasksRequest
-	(*GetTasksResponse)(nil),      // 5: tasks.GetTasksResponse
-	(*DescribeTaskRequest)(nil),   // 6: tasks.DescribeTaskRequest
-	(*DescribeTaskResponse)(nil),  // 7: tasks.DescribeTaskResponse
-	(*MarkTaskRequest)(nil),       // 8: tasks.MarkTaskRequest
-	(*MarkTaskResponse)(nil),      // 9: tasks.MarkTaskResponse
-	(*GetTagsRequest)(nil),        // 10: tasks.GetTagsRequest
-	(*GetTagsResponse)(nil),       // 11: tasks.GetTagsResponse
-	(*Addendum)(nil),              // 12: tasks.Addendum
-	(*Task)(nil),                  // 13: tasks.Task
-	(*SetStatusRequest)(nil),      // 14: tasks.SetStatusRequest
-	(*SetStatusResponse)(nil),     // 15: tasks.SetStatusResponse
-	(*timestamppb.Timestamp)(nil), // 16: google.protobuf.Timestamp
+	(AssignmentFilter)(0),                 // 0: tasks.AssignmentFilter
+	(Priority)(0),                         // 1: tasks.Priority
+	(Status)(0),                           // 2: tasks.Status
+	(TaskEventType)(0),                    // 3: tasks.TaskEventType
+	(DeliveryState)(0),                    // 4: tasks.DeliveryState
+	(Scope)(0),                            // 5: tasks.Scope
+	(Role)(0),                             // 6: tasks.Role
+	(WorkspaceRole)(0),                    // 7: tasks.WorkspaceRole
+	(*PutTaskRequest)(nil),                // 8: tasks.PutTaskRequest
+	(*PutTaskResponse)(nil),               // 9: tasks.PutTaskResponse
+	(*GetTasksRequest)(nil),               // 10: tasks.GetTasksRequest
+	(*GetTasksResponse)(nil),              // 11: tasks.GetTasksResponse
+	(*DescribeTaskRequest)(nil),           // 12: tasks.DescribeTaskRequest
+	(*DescribeTaskResponse)(nil),          // 13: tasks.DescribeTaskResponse
+	(*MarkTaskRequest)(nil),               // 14: tasks.MarkTaskRequest
+	(*MarkTaskResponse)(nil),              // 15: tasks.MarkTaskResponse
+	(*GetTagsRequest)(nil),                // 16: tasks.GetTagsRequest
+	(*GetTagsResponse)(nil),               // 17: tasks.GetTagsResponse
+	(*Addendum)(nil),                      // 18: tasks.Addendum
+	(*Task)(nil),                          // 19: tasks.Task
+	(*SetStatusRequest)(nil),              // 20: tasks.SetStatusRequest
+	(*SetStatusResponse)(nil),             // 21: tasks.SetStatusResponse
+	(*DataRecord)(nil),                    // 22: tasks.DataRecord
+	(*TaskRecord)(nil),                    // 23: tasks.TaskRecord
+	(*AddendumRecord)(nil),                // 24: tasks.AddendumRecord
+	(*ExportDataRequest)(nil),             // 25: tasks.ExportDataRequest
+	(*ExportDataResponse)(nil),            // 26: tasks.ExportDataResponse
+	(*ImportDataRequest)(nil),             // 27: tasks.ImportDataRequest
+	(*ImportDataResponse)(nil),            // 28: tasks.ImportDataResponse
+	(*WatchTasksRequest)(nil),             // 29: tasks.WatchTasksRequest
+	(*WatchTasksResponse)(nil),            // 30: tasks.WatchTasksResponse
+	(*WebhookFilter)(nil),                 // 31: tasks.WebhookFilter
+	(*Webhook)(nil),                       // 32: tasks.Webhook
+	(*CreateWebhookRequest)(nil),          // 33: tasks.CreateWebhookRequest
+	(*CreateWebhookResponse)(nil),         // 34: tasks.CreateWebhookResponse
+	(*ListWebhooksRequest)(nil),           // 35: tasks.ListWebhooksRequest
+	(*ListWebhooksResponse)(nil),          // 36: tasks.ListWebhooksResponse
+	(*DeleteWebhookRequest)(nil),          // 37: tasks.DeleteWebhookRequest
+	(*DeleteWebhookResponse)(nil),         // 38: tasks.DeleteWebhookResponse
+	(*ListWebhookDeliveriesRequest)(nil),  // 39: tasks.ListWebhookDeliveriesRequest
+	(*ListWebhookDeliveriesResponse)(nil), // 40: tasks.ListWebhookDeliveriesResponse
+	(*CreateAccessTokenRequest)(nil),      // 41: tasks.CreateAccessTokenRequest
+	(*CreateAccessTokenResponse)(nil),     // 42: tasks.CreateAccessTokenResponse
+	(*ListAccessTokensRequest)(nil),       // 43: tasks.ListAccessTokensRequest
+	(*ListAccessTokensResponse)(nil),      // 44: tasks.ListAccessTokensResponse
+	(*RevokeAccessTokenRequest)(nil),      // 45: tasks.RevokeAccessTokenRequest
+	(*RevokeAccessTokenResponse)(nil),     // 46: tasks.RevokeAccessTokenResponse
+	(*ShareTarget)(nil),                   // 47: tasks.ShareTarget
+	(*ShareRequest)(nil),                  // 48: tasks.ShareRequest
+	(*ShareResponse)(nil),                 // 49: tasks.ShareResponse
+	(*ListSharesRequest)(nil),             // 50: tasks.ListSharesRequest
+	(*ListSharesResponse)(nil),            // 51: tasks.ListSharesResponse
+	(*UnshareRequest)(nil),                // 52: tasks.UnshareRequest
+	(*UnshareResponse)(nil),               // 53: tasks.UnshareResponse
+	(*CreateWorkspaceRequest)(nil),        // 54: tasks.CreateWorkspaceRequest
+	(*CreateWorkspaceResponse)(nil),       // 55: tasks.CreateWorkspaceResponse
+	(*ListWorkspacesRequest)(nil),         // 56: tasks.ListWorkspacesRequest
+	(*ListWorkspacesResponse)(nil),        // 57: tasks.ListWorkspacesResponse
+	(*JoinWorkspaceRequest)(nil),          // 58: tasks.JoinWorkspaceRequest
+	(*JoinWorkspaceResponse)(nil),         // 59: tasks.JoinWorkspaceResponse
+	(*LeaveWorkspaceRequest)(nil),         // 60: tasks.LeaveWorkspaceRequest
+	(*LeaveWorkspaceResponse)(nil),        // 61: tasks.LeaveWorkspaceResponse
+	(*ListWorkspaceMembersRequest)(nil),   // 62: tasks.ListWorkspaceMembersRequest
+	(*ListWorkspaceMembersResponse)(nil),  // 63: tasks.ListWorkspaceMembersResponse
+	(*SetWorkspaceRoleRequest)(nil),       // 64: tasks.SetWorkspaceRoleRequest
+	(*SetWorkspaceRoleResponse)(nil),      // 65: tasks.SetWorkspaceRoleResponse
+	(*AssignTaskRequest)(nil),             // 66: tasks.AssignTaskRequest
+	(*AssignTaskResponse)(nil),            // 67: tasks.AssignTaskResponse
+	nil,                                   // 68: tasks.ImportDataResponse.TaskIdsEntry
+	(*timestamppb.Timestamp)(nil),         // 69: google.protobuf.Timestamp
 }
 var file_tasks_v1_tasks_proto_depIdxs = []int32{
-	13, // 0: tasks.PutTaskRequest.task:type_name -> tasks.Task
-	1,  // 1: tasks.GetTasksRequest.status:type_name -> tasks.Status
-	13, // 2: tasks.GetTasksResponse.task:type_name -> tasks.Task
-	13, // 3: tasks.DescribeTaskResponse.task:type_name -> tasks.Task
-	12, // 4: tasks.DescribeTaskResponse.addendum:type_name -> tasks.Addendum
-	16, // 5: tasks.GetTagsResponse.write_time:type_name -> google.protobuf.Timestamp
-	16, // 6: tasks.Addendum.time_created:type_name -> google.protobuf.Timestamp
-	0,  // 7: tasks.Task.priority:type_name -> tasks.Priority
-	1,  // 8: tasks.Task.status:type_name -> tasks.Status
-	1,  // 9: tasks.SetStatusRequest.status:type_name -> tasks.Status
-	2,  // 10: tasks.tasks.PutTask:input_type -> tasks.PutTaskRequest
-	4,  // 11: tasks.tasks.GetTasks:input_type -> tasks.GetTasksRequest
-	6,  // 12: tasks.tasks.DescribeTask:input_type -> tasks.DescribeTaskRequest
-	8,  // 13: tasks.tasks.MarkTask:input_type -> tasks.MarkTaskRequest
-	10, // 14: tasks.tasks.GetTags:input_type -> tasks.GetTagsRequest
-	14, // 15: tasks.tasks.SetStatus:input_type -> tasks.SetStatusRequest
-	3,  // 16: tasks.tasks.PutTask:output_type -> tasks.PutTaskResponse
-	5,  // 17: tasks.tasks.GetTasks:output_type -> tasks.GetTasksResponse
-	7,  // 18: tasks.tasks.DescribeTask:output_type -> tasks.DescribeTaskResponse
-	9,  // 19: tasks.tasks.MarkTask:output_type -> tasks.MarkTaskResponse
-	11, // 20: tasks.tasks.GetTags:output_type -> tasks.GetTagsResponse
-	15, // 21: tasks.tasks.SetStatus:output_type -> tasks.SetStatusResponse
-	16, // [16:22] is the sub-list for method output_type
-	10, // [10:16] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	19, // 0: tasks.PutTaskRequest.task:type_name -> tasks.Task
+	2,  // 1: tasks.GetTasksRequest.status:type_name -> tasks.Status
+	0,  // 2: tasks.GetTasksRequest.assignment:type_name -> tasks.AssignmentFilter
+	19, // 3: tasks.GetTasksResponse.task:type_name -> tasks.Task
+	6,  // 4: tasks.GetTasksResponse.role:type_name -> tasks.Role
+	19, // 5: tasks.DescribeTaskResponse.task:type_name -> tasks.Task
+	18, // 6: tasks.DescribeTaskResponse.addendum:type_name -> tasks.Addendum
+	6,  // 7: tasks.DescribeTaskResponse.role:type_name -> tasks.Role
+	69, // 8: tasks.GetTagsResponse.write_time:type_name -> google.protobuf.Timestamp
+	69, // 9: tasks.Addendum.time_created:type_name -> google.protobuf.Timestamp
+	1,  // 10: tasks.Task.priority:type_name -> tasks.Priority
+	2,  // 11: tasks.Task.status:type_name -> tasks.Status
+	69, // 12: tasks.Task.time_created:type_name -> google.protobuf.Timestamp
+	69, // 13: tasks.Task.due_time:type_name -> google.protobuf.Timestamp
+	2,  // 14: tasks.SetStatusRequest.status:type_name -> tasks.Status
+	23, // 15: tasks.DataRecord.task:type_name -> tasks.TaskRecord
+	24, // 16: tasks.DataRecord.addendum:type_name -> tasks.AddendumRecord
+	19, // 17: tasks.TaskRecord.task:type_name -> tasks.Task
+	18, // 18: tasks.AddendumRecord.addendum:type_name -> tasks.Addendum
+	22, // 19: tasks.ExportDataResponse.record:type_name -> tasks.DataRecord
+	22, // 20: tasks.ImportDataRequest.record:type_name -> tasks.DataRecord
+	68, // 21: tasks.ImportDataResponse.task_ids:type_name -> tasks.ImportDataResponse.TaskIdsEntry
+	2,  // 22: tasks.WatchTasksRequest.status:type_name -> tasks.Status
+	3,  // 23: tasks.WatchTasksResponse.type:type_name -> tasks.TaskEventType
+	19, // 24: tasks.WatchTasksResponse.task:type_name -> tasks.Task
+	2,  // 25: tasks.WatchTasksResponse.previous_status:type_name -> tasks.Status
+	18, // 26: tasks.WatchTasksResponse.addendum:type_name -> tasks.Addendum
+	3,  // 27: tasks.WebhookFilter.type:type_name -> tasks.TaskEventType
+	2,  // 28: tasks.WebhookFilter.status:type_name -> tasks.Status
+	31, // 29: tasks.Webhook.filters:type_name -> tasks.WebhookFilter
+	32, // 30: tasks.CreateWebhookRequest.webhook:type_name -> tasks.Webhook
+	32, // 31: tasks.ListWebhooksResponse.webhook:type_name -> tasks.Webhook
+	69, // 32: tasks.ListWebhooksResponse.time_created:type_name -> google.protobuf.Timestamp
+	4,  // 33: tasks.ListWebhookDeliveriesResponse.state:type_name -> tasks.DeliveryState
+	69, // 34: tasks.ListWebhookDeliveriesResponse.time_created:type_name -> google.protobuf.Timestamp
+	69, // 35: tasks.ListWebhookDeliveriesResponse.next_attempt:type_name -> google.protobuf.Timestamp
+	5,  // 36: tasks.CreateAccessTokenRequest.scopes:type_name -> tasks.Scope
+	69, // 37: tasks.CreateAccessTokenRequest.expires:type_name -> google.protobuf.Timestamp
+	5,  // 38: tasks.ListAccessTokensResponse.scopes:type_name -> tasks.Scope
+	69, // 39: tasks.ListAccessTokensResponse.time_created:type_name -> google.protobuf.Timestamp
+	69, // 40: tasks.ListAccessTokensResponse.expires:type_name -> google.protobuf.Timestamp
+	47, // 41: tasks.ShareRequest.target:type_name -> tasks.ShareTarget
+	6,  // 42: tasks.ShareRequest.role:type_name -> tasks.Role
+	47, // 43: tasks.ListSharesResponse.target:type_name -> tasks.ShareTarget
+	6,  // 44: tasks.ListSharesResponse.role:type_name -> tasks.Role
+	69, // 45: tasks.ListSharesResponse.time_created:type_name -> google.protobuf.Timestamp
+	47, // 46: tasks.UnshareRequest.target:type_name -> tasks.ShareTarget
+	7,  // 47: tasks.ListWorkspacesResponse.role:type_name -> tasks.WorkspaceRole
+	69, // 48: tasks.ListWorkspacesResponse.time_joined:type_name -> google.protobuf.Timestamp
+	7,  // 49: tasks.ListWorkspaceMembersResponse.role:type_name -> tasks.WorkspaceRole
+	69, // 50: tasks.ListWorkspaceMembersResponse.time_joined:type_name -> google.protobuf.Timestamp
+	7,  // 51: tasks.SetWorkspaceRoleRequest.role:type_name -> tasks.WorkspaceRole
+	8,  // 52: tasks.tasks.PutTask:input_type -> tasks.PutTaskRequest
+	10, // 53: tasks.tasks.GetTasks:input_type -> tasks.GetTasksRequest
+	12, // 54: tasks.tasks.DescribeTask:input_type -> tasks.DescribeTaskRequest
+	14, // 55: tasks.tasks.MarkTask:input_type -> tasks.MarkTaskRequest
+	16, // 56: tasks.tasks.GetTags:input_type -> tasks.GetTagsRequest
+	20, // 57: tasks.tasks.SetStatus:input_type -> tasks.SetStatusRequest
+	25, // 58: tasks.tasks.ExportData:input_type -> tasks.ExportDataRequest
+	27, // 59: tasks.tasks.ImportData:input_type -> tasks.ImportDataRequest
+	29, // 60: tasks.tasks.WatchTasks:input_type -> tasks.WatchTasksRequest
+	33, // 61: tasks.tasks.CreateWebhook:input_type -> tasks.CreateWebhookRequest
+	35, // 62: tasks.tasks.ListWebhooks:input_type -> tasks.ListWebhooksRequest
+	37, // 63: tasks.tasks.DeleteWebhook:input_type -> tasks.DeleteWebhookRequest
+	39, // 64: tasks.tasks.ListWebhookDeliveries:input_type -> tasks.ListWebhookDeliveriesRequest
+	41, // 65: tasks.tasks.CreateAccessToken:input_type -> tasks.CreateAccessTokenRequest
+	43, // 66: tasks.tasks.ListAccessTokens:input_type -> tasks.ListAccessTokensRequest
+	45, // 67: tasks.tasks.RevokeAccessToken:input_type -> tasks.RevokeAccessTokenRequest
+	48, // 68: tasks.tasks.Share:input_type -> tasks.ShareRequest
+	50, // 69: tasks.tasks.ListShares:input_type -> tasks.ListSharesRequest
+	52, // 70: tasks.tasks.Unshare:input_type -> tasks.UnshareRequest
+	54, // 71: tasks.tasks.CreateWorkspace:input_type -> tasks.CreateWorkspaceRequest
+	56, // 72: tasks.tasks.ListWorkspaces:input_type -> tasks.ListWorkspacesRequest
+	58, // 73: tasks.tasks.JoinWorkspace:input_type -> tasks.JoinWorkspaceRequest
+	60, // 74: tasks.tasks.LeaveWorkspace:input_type -> tasks.LeaveWorkspaceRequest
+	62, // 75: tasks.tasks.ListWorkspaceMembers:input_type -> tasks.ListWorkspaceMembersRequest
+	64, // 76: tasks.tasks.SetWorkspaceRole:input_type -> tasks.SetWorkspaceRoleRequest
+	66, // 77: tasks.tasks.AssignTask:input_type -> tasks.AssignTaskRequest
+	9,  // 78: tasks.tasks.PutTask:output_type -> tasks.PutTaskResponse
+	11, // 79: tasks.tasks.GetTasks:output_type -> tasks.GetTasksResponse
+	13, // 80: tasks.tasks.DescribeTask:output_type -> tasks.DescribeTaskResponse
+	15, // 81: tasks.tasks.MarkTask:output_type -> tasks.MarkTaskResponse
+	17, // 82: tasks.tasks.GetTags:output_type -> tasks.GetTagsResponse
+	21, // 83: tasks.tasks.SetStatus:output_type -> tasks.SetStatusResponse
+	26, // 84: tasks.tasks.ExportData:output_type -> tasks.ExportDataResponse
+	28, // 85: tasks.tasks.ImportData:output_type -> tasks.ImportDataResponse
+	30, // 86: tasks.tasks.WatchTasks:output_type -> tasks.WatchTasksResponse
+	34, // 87: tasks.tasks.CreateWebhook:output_type -> tasks.CreateWebhookResponse
+	36, // 88: tasks.tasks.ListWebhooks:output_type -> tasks.ListWebhooksResponse
+	38, // 89: tasks.tasks.DeleteWebhook:output_type -> tasks.DeleteWebhookResponse
+	40, // 90: tasks.tasks.ListWebhookDeliveries:output_type -> tasks.ListWebhookDeliveriesResponse
+	42, // 91: tasks.tasks.CreateAccessToken:output_type -> tasks.CreateAccessTokenResponse
+	44, // 92: tasks.tasks.ListAccessTokens:output_type -> tasks.ListAccessTokensResponse
+	46, // 93: tasks.tasks.RevokeAccessToken:output_type -> tasks.RevokeAccessTokenResponse
+	49, // 94: tasks.tasks.Share:output_type -> tasks.ShareResponse
+	51, // 95: tasks.tasks.ListShares:output_type -> tasks.ListSharesResponse
+	53, // 96: tasks.tasks.Unshare:output_type -> tasks.UnshareResponse
+	55, // 97: tasks.tasks.CreateWorkspace:output_type -> tasks.CreateWorkspaceResponse
+	57, // 98: tasks.tasks.ListWorkspaces:output_type -> tasks.ListWorkspacesResponse
+	59, // 99: tasks.tasks.JoinWorkspace:output_type -> tasks.JoinWorkspaceResponse
+	61, // 100: tasks.tasks.LeaveWorkspace:output_type -> tasks.LeaveWorkspaceResponse
+	63, // 101: tasks.tasks.ListWorkspaceMembers:output_type -> tasks.ListWorkspaceMembersResponse
+	65, // 102: tasks.tasks.SetWorkspaceRole:output_type -> tasks.SetWorkspaceRoleResponse
+	67, // 103: tasks.tasks.AssignTask:output_type -> tasks.AssignTaskResponse
+	78, // [78:104] is the sub-list for method output_type
+	52, // [52:78] is the sub-list for method input_type
+	52, // [52:52] is the sub-list for extension type_name
+	52, // [52:52] is the sub-list for extension extendee
+	0,  // [0:52] is the sub-list for field type_name
 }
 
 func init() { file_tasks_v1_tasks_proto_init() }
@@ -968,13 +4185,30 @@ func file_tasks_v1_tasks_proto_init() {
 	if File_tasks_v1_tasks_proto != nil {
 		return
 	}
+	file_tasks_v1_tasks_proto_msgTypes[0].OneofWrappers = []any{}
+	file_tasks_v1_tasks_proto_msgTypes[2].OneofWrappers = []any{}
+	file_tasks_v1_tasks_proto_msgTypes[8].OneofWrappers = []any{}
+	file_tasks_v1_tasks_proto_msgTypes[11].OneofWrappers = []any{}
+	file_tasks_v1_tasks_proto_msgTypes[14].OneofWrappers = []any{
+		(*DataRecord_Task)(nil),
+		(*DataRecord_Addendum)(nil),
+	}
+	file_tasks_v1_tasks_proto_msgTypes[21].OneofWrappers = []any{}
+	file_tasks_v1_tasks_proto_msgTypes[22].OneofWrappers = []any{}
+	file_tasks_v1_tasks_proto_msgTypes[23].OneofWrappers = []any{}
+	file_tasks_v1_tasks_proto_msgTypes[31].OneofWrappers = []any{}
+	file_tasks_v1_tasks_proto_msgTypes[39].OneofWrappers = []any{
+		(*ShareTarget_TaskId)(nil),
+		(*ShareTarget_Tag)(nil),
+	}
+	file_tasks_v1_tasks_proto_msgTypes[58].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_tasks_v1_tasks_proto_rawDesc), len(file_tasks_v1_tasks_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   14,
+			NumEnums:      8,
+			NumMessages:   61,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
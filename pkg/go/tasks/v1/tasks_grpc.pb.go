@@ -19,12 +19,32 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Tasks_PutTask_FullMethodName      = "/tasks.tasks/PutTask"
-	Tasks_GetTasks_FullMethodName     = "/tasks.tasks/GetTasks"
-	Tasks_DescribeTask_FullMethodName = "/tasks.tasks/DescribeTask"
-	Tasks_MarkTask_FullMethodName     = "/tasks.tasks/MarkTask"
-	Tasks_GetTags_FullMethodName      = "/tasks.tasks/GetTags"
-	Tasks_SetStatus_FullMethodName    = "/tasks.tasks/SetStatus"
+	Tasks_PutTask_FullMethodName               = "/tasks.tasks/PutTask"
+	Tasks_GetTasks_FullMethodName              = "/tasks.tasks/GetTasks"
+	Tasks_DescribeTask_FullMethodName          = "/tasks.tasks/DescribeTask"
+	Tasks_MarkTask_FullMethodName              = "/tasks.tasks/MarkTask"
+	Tasks_GetTags_FullMethodName               = "/tasks.tasks/GetTags"
+	Tasks_SetStatus_FullMethodName             = "/tasks.tasks/SetStatus"
+	Tasks_ExportData_FullMethodName            = "/tasks.tasks/ExportData"
+	Tasks_ImportData_FullMethodName            = "/tasks.tasks/ImportData"
+	Tasks_WatchTasks_FullMethodName            = "/tasks.tasks/WatchTasks"
+	Tasks_CreateWebhook_FullMethodName         = "/tasks.tasks/CreateWebhook"
+	Tasks_ListWebhooks_FullMethodName          = "/tasks.tasks/ListWebhooks"
+	Tasks_DeleteWebhook_FullMethodName         = "/tasks.tasks/DeleteWebhook"
+	Tasks_ListWebhookDeliveries_FullMethodName = "/tasks.tasks/ListWebhookDeliveries"
+	Tasks_CreateAccessToken_FullMethodName     = "/tasks.tasks/CreateAccessToken"
+	Tasks_ListAccessTokens_FullMethodName      = "/tasks.tasks/ListAccessTokens"
+	Tasks_RevokeAccessToken_FullMethodName     = "/tasks.tasks/RevokeAccessToken"
+	Tasks_Share_FullMethodName                 = "/tasks.tasks/Share"
+	Tasks_ListShares_FullMethodName            = "/tasks.tasks/ListShares"
+	Tasks_Unshare_FullMethodName               = "/tasks.tasks/Unshare"
+	Tasks_CreateWorkspace_FullMethodName       = "/tasks.tasks/CreateWorkspace"
+	Tasks_ListWorkspaces_FullMethodName        = "/tasks.tasks/ListWorkspaces"
+	Tasks_JoinWorkspace_FullMethodName         = "/tasks.tasks/JoinWorkspace"
+	Tasks_LeaveWorkspace_FullMethodName        = "/tasks.tasks/LeaveWorkspace"
+	Tasks_ListWorkspaceMembers_FullMethodName  = "/tasks.tasks/ListWorkspaceMembers"
+	Tasks_SetWorkspaceRole_FullMethodName      = "/tasks.tasks/SetWorkspaceRole"
+	Tasks_AssignTask_FullMethodName            = "/tasks.tasks/AssignTask"
 )
 
 // TasksClient is the client API for Tasks service.
@@ -37,6 +57,26 @@ type TasksClient interface {
 	MarkTask(ctx context.Context, in *MarkTaskRequest, opts ...grpc.CallOption) (*MarkTaskResponse, error)
 	GetTags(ctx context.Context, in *GetTagsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetTagsResponse], error)
 	SetStatus(ctx context.Context, in *SetStatusRequest, opts ...grpc.CallOption) (*SetStatusResponse, error)
+	ExportData(ctx context.Context, in *ExportDataRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportDataResponse], error)
+	ImportData(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportDataRequest, ImportDataResponse], error)
+	WatchTasks(ctx context.Context, in *WatchTasksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchTasksResponse], error)
+	CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*CreateWebhookResponse, error)
+	ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListWebhooksResponse], error)
+	DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error)
+	ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListWebhookDeliveriesResponse], error)
+	CreateAccessToken(ctx context.Context, in *CreateAccessTokenRequest, opts ...grpc.CallOption) (*CreateAccessTokenResponse, error)
+	ListAccessTokens(ctx context.Context, in *ListAccessTokensRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListAccessTokensResponse], error)
+	RevokeAccessToken(ctx context.Context, in *RevokeAccessTokenRequest, opts ...grpc.CallOption) (*RevokeAccessTokenResponse, error)
+	Share(ctx context.Context, in *ShareRequest, opts ...grpc.CallOption) (*ShareResponse, error)
+	ListShares(ctx context.Context, in *ListSharesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListSharesResponse], error)
+	Unshare(ctx context.Context, in *UnshareRequest, opts ...grpc.CallOption) (*UnshareResponse, error)
+	CreateWorkspace(ctx context.Context, in *CreateWorkspaceRequest, opts ...grpc.CallOption) (*CreateWorkspaceResponse, error)
+	ListWorkspaces(ctx context.Context, in *ListWorkspacesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListWorkspacesResponse], error)
+	JoinWorkspace(ctx context.Context, in *JoinWorkspaceRequest, opts ...grpc.CallOption) (*JoinWorkspaceResponse, error)
+	LeaveWorkspace(ctx context.Context, in *LeaveWorkspaceRequest, opts ...grpc.CallOption) (*LeaveWorkspaceResponse, error)
+	ListWorkspaceMembers(ctx context.Context, in *ListWorkspaceMembersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListWorkspaceMembersResponse], error)
+	SetWorkspaceRole(ctx context.Context, in *SetWorkspaceRoleRequest, opts ...grpc.CallOption) (*SetWorkspaceRoleResponse, error)
+	AssignTask(ctx context.Context, in *AssignTaskRequest, opts ...grpc.CallOption) (*AssignTaskResponse, error)
 }
 
 type tasksClient struct {
@@ -125,6 +165,281 @@ func (c *tasksClient) SetStatus(ctx context.Context, in *SetStatusRequest, opts
 	return out, nil
 }
 
+func (c *tasksClient) ExportData(ctx context.Context, in *ExportDataRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportDataResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Tasks_ServiceDesc.Streams[2], Tasks_ExportData_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportDataRequest, ExportDataResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Tasks_ExportDataClient = grpc.ServerStreamingClient[ExportDataResponse]
+
+func (c *tasksClient) ImportData(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportDataRequest, ImportDataResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Tasks_ServiceDesc.Streams[3], Tasks_ImportData_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportDataRequest, ImportDataResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Tasks_ImportDataClient = grpc.ClientStreamingClient[ImportDataRequest, ImportDataResponse]
+
+func (c *tasksClient) WatchTasks(ctx context.Context, in *WatchTasksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchTasksResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Tasks_ServiceDesc.Streams[4], Tasks_WatchTasks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchTasksRequest, WatchTasksResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Tasks_WatchTasksClient = grpc.ServerStreamingClient[WatchTasksResponse]
+
+func (c *tasksClient) CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*CreateWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateWebhookResponse)
+	err := c.cc.Invoke(ctx, Tasks_CreateWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksClient) ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListWebhooksResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Tasks_ServiceDesc.Streams[5], Tasks_ListWebhooks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListWebhooksRequest, ListWebhooksResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Tasks_ListWebhooksClient = grpc.ServerStreamingClient[ListWebhooksResponse]
+
+func (c *tasksClient) DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteWebhookResponse)
+	err := c.cc.Invoke(ctx, Tasks_DeleteWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksClient) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListWebhookDeliveriesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Tasks_ServiceDesc.Streams[6], Tasks_ListWebhookDeliveries_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListWebhookDeliveriesRequest, ListWebhookDeliveriesResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Tasks_ListWebhookDeliveriesClient = grpc.ServerStreamingClient[ListWebhookDeliveriesResponse]
+
+func (c *tasksClient) CreateAccessToken(ctx context.Context, in *CreateAccessTokenRequest, opts ...grpc.CallOption) (*CreateAccessTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateAccessTokenResponse)
+	err := c.cc.Invoke(ctx, Tasks_CreateAccessToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksClient) ListAccessTokens(ctx context.Context, in *ListAccessTokensRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListAccessTokensResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Tasks_ServiceDesc.Streams[7], Tasks_ListAccessTokens_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListAccessTokensRequest, ListAccessTokensResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Tasks_ListAccessTokensClient = grpc.ServerStreamingClient[ListAccessTokensResponse]
+
+func (c *tasksClient) RevokeAccessToken(ctx context.Context, in *RevokeAccessTokenRequest, opts ...grpc.CallOption) (*RevokeAccessTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeAccessTokenResponse)
+	err := c.cc.Invoke(ctx, Tasks_RevokeAccessToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksClient) Share(ctx context.Context, in *ShareRequest, opts ...grpc.CallOption) (*ShareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShareResponse)
+	err := c.cc.Invoke(ctx, Tasks_Share_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksClient) ListShares(ctx context.Context, in *ListSharesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListSharesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Tasks_ServiceDesc.Streams[8], Tasks_ListShares_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListSharesRequest, ListSharesResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Tasks_ListSharesClient = grpc.ServerStreamingClient[ListSharesResponse]
+
+func (c *tasksClient) Unshare(ctx context.Context, in *UnshareRequest, opts ...grpc.CallOption) (*UnshareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnshareResponse)
+	err := c.cc.Invoke(ctx, Tasks_Unshare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksClient) CreateWorkspace(ctx context.Context, in *CreateWorkspaceRequest, opts ...grpc.CallOption) (*CreateWorkspaceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateWorkspaceResponse)
+	err := c.cc.Invoke(ctx, Tasks_CreateWorkspace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksClient) ListWorkspaces(ctx context.Context, in *ListWorkspacesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListWorkspacesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Tasks_ServiceDesc.Streams[9], Tasks_ListWorkspaces_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListWorkspacesRequest, ListWorkspacesResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Tasks_ListWorkspacesClient = grpc.ServerStreamingClient[ListWorkspacesResponse]
+
+func (c *tasksClient) JoinWorkspace(ctx context.Context, in *JoinWorkspaceRequest, opts ...grpc.CallOption) (*JoinWorkspaceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JoinWorkspaceResponse)
+	err := c.cc.Invoke(ctx, Tasks_JoinWorkspace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksClient) LeaveWorkspace(ctx context.Context, in *LeaveWorkspaceRequest, opts ...grpc.CallOption) (*LeaveWorkspaceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaveWorkspaceResponse)
+	err := c.cc.Invoke(ctx, Tasks_LeaveWorkspace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksClient) ListWorkspaceMembers(ctx context.Context, in *ListWorkspaceMembersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListWorkspaceMembersResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Tasks_ServiceDesc.Streams[10], Tasks_ListWorkspaceMembers_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListWorkspaceMembersRequest, ListWorkspaceMembersResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Tasks_ListWorkspaceMembersClient = grpc.ServerStreamingClient[ListWorkspaceMembersResponse]
+
+func (c *tasksClient) SetWorkspaceRole(ctx context.Context, in *SetWorkspaceRoleRequest, opts ...grpc.CallOption) (*SetWorkspaceRoleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetWorkspaceRoleResponse)
+	err := c.cc.Invoke(ctx, Tasks_SetWorkspaceRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksClient) AssignTask(ctx context.Context, in *AssignTaskRequest, opts ...grpc.CallOption) (*AssignTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AssignTaskResponse)
+	err := c.cc.Invoke(ctx, Tasks_AssignTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TasksServer is the server API for Tasks service.
 // All implementations must embed UnimplementedTasksServer
 // for forward compatibility.
@@ -135,6 +450,26 @@ type TasksServer interface {
 	MarkTask(context.Context, *MarkTaskRequest) (*MarkTaskResponse, error)
 	GetTags(*GetTagsRequest, grpc.ServerStreamingServer[GetTagsResponse]) error
 	SetStatus(context.Context, *SetStatusRequest) (*SetStatusResponse, error)
+	ExportData(*ExportDataRequest, grpc.ServerStreamingServer[ExportDataResponse]) error
+	ImportData(grpc.ClientStreamingServer[ImportDataRequest, ImportDataResponse]) error
+	WatchTasks(*WatchTasksRequest, grpc.ServerStreamingServer[WatchTasksResponse]) error
+	CreateWebhook(context.Context, *CreateWebhookRequest) (*CreateWebhookResponse, error)
+	ListWebhooks(*ListWebhooksRequest, grpc.ServerStreamingServer[ListWebhooksResponse]) error
+	DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error)
+	ListWebhookDeliveries(*ListWebhookDeliveriesRequest, grpc.ServerStreamingServer[ListWebhookDeliveriesResponse]) error
+	CreateAccessToken(context.Context, *CreateAccessTokenRequest) (*CreateAccessTokenResponse, error)
+	ListAccessTokens(*ListAccessTokensRequest, grpc.ServerStreamingServer[ListAccessTokensResponse]) error
+	RevokeAccessToken(context.Context, *RevokeAccessTokenRequest) (*RevokeAccessTokenResponse, error)
+	Share(context.Context, *ShareRequest) (*ShareResponse, error)
+	ListShares(*ListSharesRequest, grpc.ServerStreamingServer[ListSharesResponse]) error
+	Unshare(context.Context, *UnshareRequest) (*UnshareResponse, error)
+	CreateWorkspace(context.Context, *CreateWorkspaceRequest) (*CreateWorkspaceResponse, error)
+	ListWorkspaces(*ListWorkspacesRequest, grpc.ServerStreamingServer[ListWorkspacesResponse]) error
+	JoinWorkspace(context.Context, *JoinWorkspaceRequest) (*JoinWorkspaceResponse, error)
+	LeaveWorkspace(context.Context, *LeaveWorkspaceRequest) (*LeaveWorkspaceResponse, error)
+	ListWorkspaceMembers(*ListWorkspaceMembersRequest, grpc.ServerStreamingServer[ListWorkspaceMembersResponse]) error
+	SetWorkspaceRole(context.Context, *SetWorkspaceRoleRequest) (*SetWorkspaceRoleResponse, error)
+	AssignTask(context.Context, *AssignTaskRequest) (*AssignTaskResponse, error)
 	mustEmbedUnimplementedTasksServer()
 }
 
@@ -163,6 +498,66 @@ func (UnimplementedTasksServer) GetTags(*GetTagsRequest, grpc.ServerStreamingSer
 func (UnimplementedTasksServer) SetStatus(context.Context, *SetStatusRequest) (*SetStatusResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method SetStatus not implemented")
 }
+func (UnimplementedTasksServer) ExportData(*ExportDataRequest, grpc.ServerStreamingServer[ExportDataResponse]) error {
+	return status.Error(codes.Unimplemented, "method ExportData not implemented")
+}
+func (UnimplementedTasksServer) ImportData(grpc.ClientStreamingServer[ImportDataRequest, ImportDataResponse]) error {
+	return status.Error(codes.Unimplemented, "method ImportData not implemented")
+}
+func (UnimplementedTasksServer) WatchTasks(*WatchTasksRequest, grpc.ServerStreamingServer[WatchTasksResponse]) error {
+	return status.Error(codes.Unimplemented, "method WatchTasks not implemented")
+}
+func (UnimplementedTasksServer) CreateWebhook(context.Context, *CreateWebhookRequest) (*CreateWebhookResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (UnimplementedTasksServer) ListWebhooks(*ListWebhooksRequest, grpc.ServerStreamingServer[ListWebhooksResponse]) error {
+	return status.Error(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (UnimplementedTasksServer) DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (UnimplementedTasksServer) ListWebhookDeliveries(*ListWebhookDeliveriesRequest, grpc.ServerStreamingServer[ListWebhookDeliveriesResponse]) error {
+	return status.Error(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
+func (UnimplementedTasksServer) CreateAccessToken(context.Context, *CreateAccessTokenRequest) (*CreateAccessTokenResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateAccessToken not implemented")
+}
+func (UnimplementedTasksServer) ListAccessTokens(*ListAccessTokensRequest, grpc.ServerStreamingServer[ListAccessTokensResponse]) error {
+	return status.Error(codes.Unimplemented, "method ListAccessTokens not implemented")
+}
+func (UnimplementedTasksServer) RevokeAccessToken(context.Context, *RevokeAccessTokenRequest) (*RevokeAccessTokenResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RevokeAccessToken not implemented")
+}
+func (UnimplementedTasksServer) Share(context.Context, *ShareRequest) (*ShareResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Share not implemented")
+}
+func (UnimplementedTasksServer) ListShares(*ListSharesRequest, grpc.ServerStreamingServer[ListSharesResponse]) error {
+	return status.Error(codes.Unimplemented, "method ListShares not implemented")
+}
+func (UnimplementedTasksServer) Unshare(context.Context, *UnshareRequest) (*UnshareResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Unshare not implemented")
+}
+func (UnimplementedTasksServer) CreateWorkspace(context.Context, *CreateWorkspaceRequest) (*CreateWorkspaceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateWorkspace not implemented")
+}
+func (UnimplementedTasksServer) ListWorkspaces(*ListWorkspacesRequest, grpc.ServerStreamingServer[ListWorkspacesResponse]) error {
+	return status.Error(codes.Unimplemented, "method ListWorkspaces not implemented")
+}
+func (UnimplementedTasksServer) JoinWorkspace(context.Context, *JoinWorkspaceRequest) (*JoinWorkspaceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method JoinWorkspace not implemented")
+}
+func (UnimplementedTasksServer) LeaveWorkspace(context.Context, *LeaveWorkspaceRequest) (*LeaveWorkspaceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method LeaveWorkspace not implemented")
+}
+func (UnimplementedTasksServer) ListWorkspaceMembers(*ListWorkspaceMembersRequest, grpc.ServerStreamingServer[ListWorkspaceMembersResponse]) error {
+	return status.Error(codes.Unimplemented, "method ListWorkspaceMembers not implemented")
+}
+func (UnimplementedTasksServer) SetWorkspaceRole(context.Context, *SetWorkspaceRoleRequest) (*SetWorkspaceRoleResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetWorkspaceRole not implemented")
+}
+func (UnimplementedTasksServer) AssignTask(context.Context, *AssignTaskRequest) (*AssignTaskResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AssignTask not implemented")
+}
 func (UnimplementedTasksServer) mustEmbedUnimplementedTasksServer() {}
 func (UnimplementedTasksServer) testEmbeddedByValue()               {}
 
@@ -278,6 +673,299 @@ func _Tasks_SetStatus_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Tasks_ExportData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportDataRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TasksServer).ExportData(m, &grpc.GenericServerStream[ExportDataRequest, ExportDataResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Tasks_ExportDataServer = grpc.ServerStreamingServer[ExportDataResponse]
+
+func _Tasks_ImportData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TasksServer).ImportData(&grpc.GenericServerStream[ImportDataRequest, ImportDataResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Tasks_ImportDataServer = grpc.ClientStreamingServer[ImportDataRequest, ImportDataResponse]
+
+func _Tasks_WatchTasks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchTasksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TasksServer).WatchTasks(m, &grpc.GenericServerStream[WatchTasksRequest, WatchTasksResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Tasks_WatchTasksServer = grpc.ServerStreamingServer[WatchTasksResponse]
+
+func _Tasks_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tasks_CreateWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServer).CreateWebhook(ctx, req.(*CreateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tasks_ListWebhooks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListWebhooksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TasksServer).ListWebhooks(m, &grpc.GenericServerStream[ListWebhooksRequest, ListWebhooksResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Tasks_ListWebhooksServer = grpc.ServerStreamingServer[ListWebhooksResponse]
+
+func _Tasks_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tasks_DeleteWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServer).DeleteWebhook(ctx, req.(*DeleteWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tasks_ListWebhookDeliveries_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListWebhookDeliveriesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TasksServer).ListWebhookDeliveries(m, &grpc.GenericServerStream[ListWebhookDeliveriesRequest, ListWebhookDeliveriesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Tasks_ListWebhookDeliveriesServer = grpc.ServerStreamingServer[ListWebhookDeliveriesResponse]
+
+func _Tasks_CreateAccessToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAccessTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServer).CreateAccessToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tasks_CreateAccessToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServer).CreateAccessToken(ctx, req.(*CreateAccessTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tasks_ListAccessTokens_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListAccessTokensRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TasksServer).ListAccessTokens(m, &grpc.GenericServerStream[ListAccessTokensRequest, ListAccessTokensResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Tasks_ListAccessTokensServer = grpc.ServerStreamingServer[ListAccessTokensResponse]
+
+func _Tasks_RevokeAccessToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeAccessTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServer).RevokeAccessToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tasks_RevokeAccessToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServer).RevokeAccessToken(ctx, req.(*RevokeAccessTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tasks_Share_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServer).Share(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tasks_Share_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServer).Share(ctx, req.(*ShareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tasks_ListShares_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListSharesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TasksServer).ListShares(m, &grpc.GenericServerStream[ListSharesRequest, ListSharesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Tasks_ListSharesServer = grpc.ServerStreamingServer[ListSharesResponse]
+
+func _Tasks_Unshare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnshareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServer).Unshare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tasks_Unshare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServer).Unshare(ctx, req.(*UnshareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tasks_CreateWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWorkspaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServer).CreateWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tasks_CreateWorkspace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServer).CreateWorkspace(ctx, req.(*CreateWorkspaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tasks_ListWorkspaces_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListWorkspacesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TasksServer).ListWorkspaces(m, &grpc.GenericServerStream[ListWorkspacesRequest, ListWorkspacesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Tasks_ListWorkspacesServer = grpc.ServerStreamingServer[ListWorkspacesResponse]
+
+func _Tasks_JoinWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinWorkspaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServer).JoinWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tasks_JoinWorkspace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServer).JoinWorkspace(ctx, req.(*JoinWorkspaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tasks_LeaveWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaveWorkspaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServer).LeaveWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tasks_LeaveWorkspace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServer).LeaveWorkspace(ctx, req.(*LeaveWorkspaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tasks_ListWorkspaceMembers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListWorkspaceMembersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TasksServer).ListWorkspaceMembers(m, &grpc.GenericServerStream[ListWorkspaceMembersRequest, ListWorkspaceMembersResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Tasks_ListWorkspaceMembersServer = grpc.ServerStreamingServer[ListWorkspaceMembersResponse]
+
+func _Tasks_SetWorkspaceRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetWorkspaceRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServer).SetWorkspaceRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tasks_SetWorkspaceRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServer).SetWorkspaceRole(ctx, req.(*SetWorkspaceRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tasks_AssignTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServer).AssignTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tasks_AssignTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServer).AssignTask(ctx, req.(*AssignTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Tasks_ServiceDesc is the grpc.ServiceDesc for Tasks service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -301,6 +989,50 @@ var Tasks_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SetStatus",
 			Handler:    _Tasks_SetStatus_Handler,
 		},
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _Tasks_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _Tasks_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "CreateAccessToken",
+			Handler:    _Tasks_CreateAccessToken_Handler,
+		},
+		{
+			MethodName: "RevokeAccessToken",
+			Handler:    _Tasks_RevokeAccessToken_Handler,
+		},
+		{
+			MethodName: "Share",
+			Handler:    _Tasks_Share_Handler,
+		},
+		{
+			MethodName: "Unshare",
+			Handler:    _Tasks_Unshare_Handler,
+		},
+		{
+			MethodName: "CreateWorkspace",
+			Handler:    _Tasks_CreateWorkspace_Handler,
+		},
+		{
+			MethodName: "JoinWorkspace",
+			Handler:    _Tasks_JoinWorkspace_Handler,
+		},
+		{
+			MethodName: "LeaveWorkspace",
+			Handler:    _Tasks_LeaveWorkspace_Handler,
+		},
+		{
+			MethodName: "SetWorkspaceRole",
+			Handler:    _Tasks_SetWorkspaceRole_Handler,
+		},
+		{
+			MethodName: "AssignTask",
+			Handler:    _Tasks_AssignTask_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -313,6 +1045,51 @@ var Tasks_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Tasks_GetTags_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ExportData",
+			Handler:       _Tasks_ExportData_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportData",
+			Handler:       _Tasks_ImportData_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WatchTasks",
+			Handler:       _Tasks_WatchTasks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListWebhooks",
+			Handler:       _Tasks_ListWebhooks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListWebhookDeliveries",
+			Handler:       _Tasks_ListWebhookDeliveries_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListAccessTokens",
+			Handler:       _Tasks_ListAccessTokens_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListShares",
+			Handler:       _Tasks_ListShares_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListWorkspaces",
+			Handler:       _Tasks_ListWorkspaces_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListWorkspaceMembers",
+			Handler:       _Tasks_ListWorkspaceMembers_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "tasks/v1/tasks.proto",
 }
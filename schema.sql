@@ -8,11 +8,21 @@ create table if not exists tasks (
 	fields jsonb,
 	priority smallint,
 	status smallint,
+	-- null for tasks in the personal workspace of user_id
+	workspace_id bigint,
+	-- null for tasks that nobody is assigned to
+	assignee_id bigint,
     
 	primary key (task_id)
 );
+-- columns added since the table was first created, which create table if not
+-- exists never adds to an existing database
+alter table tasks add column if not exists workspace_id bigint;
+alter table tasks add column if not exists assignee_id bigint;
 -- we'll be doing queries on this, where priority may not be specified
 CREATE index if not exists task_lookup on tasks (user_id, status, priority);
+create index if not exists workspace_task_lookup on tasks (workspace_id, status, priority);
+create index if not exists assignee_task_lookup on tasks (assignee_id, status, priority);
 create sequence if not exists task_ids start 101;
 
 create table if not exists tags (
@@ -20,11 +30,15 @@ create table if not exists tags (
 	tag_id bigint,
 	write_time timestamptz,
 	name varchar(256),
+	-- tags in a workspace are shared by everyone in it, null for personal tags
+	workspace_id bigint,
 
 	primary key (tag_id)
 );
+alter table tags add column if not exists workspace_id bigint;
 -- we need to lookup tags by tag id, so we need this index
 create index if not exists tag_lookup on tags (name);
+create index if not exists workspace_tag_lookup on tags (workspace_id, name);
 create sequence if not exists tag_ids start 101;
 
 create table if not exists tags_to_tasks (
@@ -40,6 +54,7 @@ create index if not exists tasks_to_tags_index on tags_to_tasks (task_id, tag_id
 
 create table if not exists addendums (
 	addendum_id bigint,
+	-- the author, who is not always the owner of the task
 	user_id bigint,
 	task_id bigint,
 	content text,
@@ -51,3 +66,238 @@ create table if not exists addendums (
 -- sorting by write_time so that we can return addendums in the correct order
 create index if not exists addendum_lookup on addendums (task_id, write_time);
 create sequence if not exists addendum_ids start 101;
+
+create table if not exists webhooks (
+	webhook_id bigint,
+	user_id bigint,
+	url text,
+	secret text,
+	filters jsonb,
+	write_time timestamptz,
+
+	primary key (webhook_id)
+);
+create index if not exists webhook_lookup on webhooks (user_id);
+create sequence if not exists webhook_ids start 101;
+
+-- outbox of payloads to send to webhooks. Rows are written once an event matches
+-- a webhook and retried from here until they are delivered or give up
+create table if not exists webhook_deliveries (
+	delivery_id bigint,
+	webhook_id bigint,
+	user_id bigint,
+	payload text,
+	state smallint,
+	attempts integer,
+	next_attempt timestamptz,
+	last_error text,
+	last_response_code integer,
+	write_time timestamptz,
+
+	primary key (delivery_id),
+	foreign key (webhook_id) references webhooks(webhook_id) on delete cascade
+);
+-- senders poll for pending deliveries that are due
+create index if not exists pending_deliveries on webhook_deliveries (state, next_attempt);
+create index if not exists delivery_lookup on webhook_deliveries (user_id, delivery_id);
+create sequence if not exists delivery_ids start 101;
+
+-- writes to tasks that webhooks may want, recorded by record_webhook_event in
+-- the same transaction as the write. The task is copied as it was once the
+-- write committed, so payloads describe the event rather than the task as it
+-- is when the event is turned into deliveries.
+create table if not exists webhook_events (
+	event_id bigint,
+	type text,
+	task_id bigint,
+	previous_status smallint,
+	-- everyone who could see the task and had a webhook
+	user_ids bigint[],
+	fields jsonb,
+	priority smallint,
+	status smallint,
+	assignee_id bigint,
+	tags text[],
+	number_of_addendums bigint,
+	-- only set for addendum events
+	addendum_author bigint,
+	addendum_content text,
+	addendum_time timestamptz,
+	write_time timestamptz,
+
+	primary key (event_id)
+);
+create sequence if not exists webhook_event_ids start 101;
+
+-- long lived tokens that users hand to scripts. Only a sha256 of each token is
+-- kept, the token itself is shown once when it is created
+create table if not exists access_tokens (
+	token_id bigint,
+	user_id bigint,
+	name text,
+	token_hash bytea,
+	scopes integer[],
+	expires timestamptz,
+	write_time timestamptz,
+
+	primary key (token_id),
+	unique (token_hash)
+);
+create index if not exists access_token_lookup on access_tokens (user_id);
+create sequence if not exists access_token_ids start 101;
+
+create table if not exists workspaces (
+	workspace_id bigint,
+	name text,
+	invite_code text,
+	write_time timestamptz,
+
+	primary key (workspace_id),
+	unique (invite_code)
+);
+create sequence if not exists workspace_ids start 101;
+
+create table if not exists workspace_members (
+	workspace_id bigint,
+	user_id bigint,
+	role smallint,
+	write_time timestamptz,
+
+	primary key (workspace_id, user_id),
+	foreign key (workspace_id) references workspaces(workspace_id) on delete cascade
+);
+create index if not exists workspace_member_lookup on workspace_members (user_id);
+
+-- users that a single task has been shared with
+create table if not exists task_shares (
+	task_id bigint,
+	user_id bigint,
+	role smallint,
+	write_time timestamptz,
+
+	primary key (task_id, user_id),
+	foreign key (task_id) references tasks(task_id) on delete cascade
+);
+create index if not exists task_share_lookup on task_shares (user_id);
+
+-- shares every task that owner_id has with a tag in their personal workspace,
+-- including tasks tagged later
+create table if not exists tag_shares (
+	tag_id bigint,
+	owner_id bigint,
+	user_id bigint,
+	role smallint,
+	write_time timestamptz,
+
+	primary key (tag_id, owner_id, user_id),
+	foreign key (tag_id) references tags(tag_id) on delete cascade
+);
+create index if not exists tag_share_lookup on tag_shares (user_id);
+create index if not exists tag_share_owner_lookup on tag_shares (owner_id);
+
+-- every user that can see a task, with one row for each way they can see it.
+-- Roles are 0 for viewers, 1 for editors and 2 for the owner, so the highest
+-- role of a user on a task is what they may do with it. Workspace roles use the
+-- same numbers, so owners of a workspace own its tasks, members edit them and
+-- viewers view them. workspace_id is only set
+-- for access through a workspace, so that a user's personal workspace is
+-- everything they can see with a null workspace_id
+create or replace view task_access as
+	select t.task_id, t.user_id, 2::smallint as role, null::bigint as workspace_id from tasks t
+		where t.workspace_id is null
+	union all
+	select ts.task_id, ts.user_id, ts.role, null from task_shares ts
+	union all
+	select ttt.task_id, s.user_id, s.role, null from tag_shares s
+		join tags_to_tasks ttt on ttt.tag_id = s.tag_id
+		join tasks t on t.task_id = ttt.task_id and t.user_id = s.owner_id and t.workspace_id is null
+	union all
+	select t.task_id, m.user_id, m.role, m.workspace_id from workspace_members m
+		join tasks t on t.workspace_id = m.workspace_id;
+
+-- every write to a task or addendum is published to listening taskmaster servers
+-- once its transaction commits, see internal/events
+create or replace function notify_task_event() returns trigger as $$
+declare
+	recipients bigint[];
+	workspace bigint;
+	payload json;
+begin
+	-- events go to everyone who sees the task outside of a workspace, and to
+	-- whoever is watching the task's workspace
+	select coalesce(array_agg(distinct a.user_id), '{}') into recipients from task_access a where a.task_id = NEW.task_id and a.workspace_id is null;
+	select t.workspace_id into workspace from tasks t where t.task_id = NEW.task_id;
+	if TG_TABLE_NAME = 'addendums' then
+		payload := json_build_object('type', 'ADDENDUM_ADDED', 'user_ids', recipients, 'workspace_id', workspace, 'task_id', NEW.task_id);
+	elsif TG_OP = 'INSERT' then
+		payload := json_build_object('type', 'CREATED', 'user_ids', recipients, 'workspace_id', workspace, 'task_id', NEW.task_id);
+	elsif OLD.status is distinct from NEW.status then
+		payload := json_build_object('type', 'STATUS_CHANGED', 'user_ids', recipients, 'workspace_id', workspace, 'task_id', NEW.task_id, 'previous_status', OLD.status);
+	else
+		payload := json_build_object('type', 'UPDATED', 'user_ids', recipients, 'workspace_id', workspace, 'task_id', NEW.task_id);
+	end if;
+	perform pg_notify('task_events', payload::text);
+	return null;
+end;
+$$ language plpgsql;
+
+create or replace trigger task_events after insert or update on tasks
+	for each row execute function notify_task_event();
+create or replace trigger addendum_events after insert on addendums
+	for each row execute function notify_task_event();
+
+-- runs as the transaction commits, once new tasks have had their tags linked.
+-- Nothing is recorded for tasks that nobody with a webhook can see.
+create or replace function record_webhook_event() returns trigger as $$
+declare
+	recipients bigint[];
+	event_task tasks;
+	event_type text;
+	previous smallint;
+	added_by bigint;
+	added_content text;
+	added_time timestamptz;
+begin
+	select coalesce(array_agg(distinct a.user_id), '{}') into recipients from task_access a
+		where a.task_id = NEW.task_id and exists (select 1 from webhooks w where w.user_id = a.user_id);
+	if cardinality(recipients) = 0 then
+		return null;
+	end if;
+	if TG_TABLE_NAME = 'addendums' then
+		select * into event_task from tasks t where t.task_id = NEW.task_id;
+		event_type := 'ADDENDUM_ADDED';
+		added_by := NEW.user_id;
+		added_content := NEW.content;
+		added_time := NEW.write_time;
+	else
+		event_task := NEW;
+		if TG_OP = 'INSERT' then
+			event_type := 'CREATED';
+		elsif OLD.status is distinct from NEW.status then
+			event_type := 'STATUS_CHANGED';
+			previous := OLD.status;
+		else
+			event_type := 'UPDATED';
+		end if;
+	end if;
+	insert into webhook_events (event_id, type, task_id, previous_status, user_ids, fields, priority, status, assignee_id, tags, number_of_addendums, addendum_author, addendum_content, addendum_time, write_time)
+		values (
+			nextval('webhook_event_ids'), event_type, event_task.task_id, previous, recipients,
+			event_task.fields, event_task.priority, event_task.status, event_task.assignee_id,
+			array(select tg.name from tags_to_tasks ttt join tags tg on tg.tag_id = ttt.tag_id where ttt.task_id = event_task.task_id order by tg.name),
+			(select count(*) from addendums a where a.task_id = event_task.task_id),
+			added_by, added_content, added_time, now()
+		);
+	return null;
+end;
+$$ language plpgsql;
+
+-- constraint triggers cannot be replaced, only dropped and created again
+drop trigger if exists task_webhook_events on tasks;
+create constraint trigger task_webhook_events after insert or update on tasks
+	deferrable initially deferred
+	for each row execute function record_webhook_event();
+drop trigger if exists addendum_webhook_events on addendums;
+create constraint trigger addendum_webhook_events after insert on addendums
+	deferrable initially deferred
+	for each row execute function record_webhook_event();